@@ -11,29 +11,76 @@ import (
 	"time"
 
 	spycatagency "github.com/4oBuko/spy-cat-agency/internal"
+	"github.com/4oBuko/spy-cat-agency/internal/config"
+	"github.com/4oBuko/spy-cat-agency/internal/grpcapi"
 	"github.com/4oBuko/spy-cat-agency/internal/repositories"
 	"github.com/4oBuko/spy-cat-agency/internal/services"
 	"github.com/4oBuko/spy-cat-agency/pkg/catapi"
+	"github.com/4oBuko/spy-cat-agency/pkg/storage"
 	_ "github.com/go-sql-driver/mysql"
 )
 
 func main() {
-	dsn := "user:password@/spycatagency"
+	dsn := "user:password@/spycatagency?parseTime=true"
 	catAPIUrl := "https://api.thecatapi.com/v1/breeds"
+	configPath := "config/config.json"
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
 	db := initDBConnection(dsn)
 	catRepo := repositories.NewMySQLCatRepository(db)
 	catAPI := catapi.NewCatAPIClient(catAPIUrl, 1, time.Second)
-	catService := services.NewDefaultCatService(catRepo, catAPI)
+	salaryRepo := repositories.NewMySQLSalaryRepository(db)
+	statusRepo := repositories.NewMySQLStatusRepository(db)
+	rankRepo := repositories.NewMySQLRankRepository(db)
+	ranks := services.NewRanks(cfg.Ranks)
+	transactor := repositories.NewMySQLTransactor(db)
+	budgetService := services.NewDefaultBudgetService(repositories.NewMySQLBudgetRepository(db), cfg.PayrollBudget)
+	photoStorage := storage.NewLocalStorage(cfg.Photos.Dir, spycatagency.PhotoBaseURL)
+	photoService := services.NewDefaultPhotoService(catRepo, photoStorage, cfg.Photos.MaxSize, cfg.Photos.ThumbnailSizes)
+	catService := services.NewDefaultCatService(catRepo, salaryRepo, statusRepo, rankRepo, transactor, catAPI, ranks, budgetService, photoService)
 	missionRepo := repositories.NewMySQLMissionRepository(db)
 	targetRepo := repositories.NewMySQLTargetRepository(db)
-	missionService := services.NewDefaultMissionService(missionRepo, targetRepo, catRepo)
-	server := spycatagency.NewServer(catService, catAPI, missionService)
+	missionTypes := services.NewMissionTypes(cfg.MissionTypes, cfg.DefaultMissionType)
+	templateRepo := repositories.NewMySQLTemplateRepository(db)
+	skillRepo := repositories.NewMySQLSkillRepository(db)
+	absenceRepo := repositories.NewMySQLAbsenceRepository(db)
+	ledgerRepo := repositories.NewMySQLLedgerRepository(db)
+	missionService := services.NewDefaultMissionService(missionRepo, targetRepo, catRepo, templateRepo, skillRepo, absenceRepo, rankRepo, ledgerRepo, transactor, missionTypes, ranks, budgetService)
+	templateService := services.NewDefaultTemplateService(templateRepo, missionTypes)
+	payrollService := services.NewDefaultPayrollService(salaryRepo)
+	skillService := services.NewDefaultSkillService(skillRepo, catRepo)
+	absenceService := services.NewDefaultAbsenceService(absenceRepo, catRepo)
+	statsService := services.NewDefaultStatsService(repositories.NewMySQLStatsRepository(db))
+	ledgerService := services.NewDefaultLedgerService(ledgerRepo, catRepo)
+	handlerService := services.NewDefaultHandlerService(repositories.NewMySQLHandlerRepository(db), catRepo, transactor, catService, missionService, budgetService)
+	importService := services.NewDefaultImportService(catRepo, transactor, catAPI, ranks, budgetService)
+	exportService := services.NewDefaultExportService(catRepo, missionRepo)
+	idempotencyRepo := newIdempotencyRepository(cfg.Idempotency.Store, db)
+	server := spycatagency.NewServer(catService, catAPI, missionService, templateService, payrollService, skillService, absenceService, statsService, ledgerService,
+		handlerService, photoService, budgetService, importService, exportService, transactor, idempotencyRepo, cfg.Idempotency.TTL.Duration)
+
+	cleanupCtx, stopCleanup := context.WithCancel(context.Background())
+	defer stopCleanup()
+	go spycatagency.DeleteExpiredIdempotencyKeys(cleanupCtx, idempotencyRepo, time.Hour)
 
 	go func() {
 		if err := server.Run(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server failed to start: %v", err)
 		}
 	}()
+	shutdowns := []func(context.Context) error{server.Shutdown}
+
+	if cfg.GRPC.Addr != "" {
+		grpcServer := grpcapi.NewServer(cfg.GRPC.Addr, catService, missionService)
+		go func() {
+			if err := grpcServer.Run(); err != nil {
+				log.Fatalf("gRPC server failed to start: %v", err)
+			}
+		}()
+		shutdowns = append(shutdowns, grpcServer.Shutdown)
+	}
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -44,13 +91,29 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+	// HTTP and gRPC servers finish active requests at the same time and share the timeout
+	errs := make(chan error, len(shutdowns))
+	for _, shutdown := range shutdowns {
+		go func() {
+			errs <- shutdown(ctx)
+		}()
+	}
+	for range shutdowns {
+		if err := <-errs; err != nil {
+			log.Fatalf("Server forced to shutdown: %v", err)
+		}
 	}
 
 	log.Println("Server exited")
 }
 
+func newIdempotencyRepository(store string, db *sql.DB) repositories.IdempotencyRepository {
+	if store == config.IdempotencyStoreSQL {
+		return repositories.NewMySQLIdempotencyRepository(db)
+	}
+	return repositories.NewMemoryIdempotencyRepository()
+}
+
 func initDBConnection(dsn string) *sql.DB {
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+	"github.com/4oBuko/spy-cat-agency/internal/services"
+	"github.com/4oBuko/spy-cat-agency/pkg/catapi"
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// one-off command that stores breed details of cats added before the details were kept.
+// Breeds unknown to TheCatAPI are reported and left without details
+func main() {
+	dsn := flag.String("dsn", "user:password@/spycatagency?parseTime=true", "database connection string")
+	catAPIUrl := flag.String("breeds-url", "https://api.thecatapi.com/v1/breeds", "TheCatAPI breeds endpoint")
+	dryRun := flag.Bool("dry-run", false, "only report changes without updating rows")
+	flag.Parse()
+
+	db, err := sql.Open("mysql", *dsn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	catRepo := repositories.NewMySQLCatRepository(db)
+	catAPI := catapi.NewCatAPIClient(*catAPIUrl, 1, time.Second)
+	usage, err := catRepo.GetBreedsWithoutDetails(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	breeds := make([]string, 0, len(usage))
+	for breed := range usage {
+		breeds = append(breeds, breed)
+	}
+	sort.Strings(breeds)
+
+	var updated int64
+	var unresolved []string
+	for _, id := range breeds {
+		breed, err := catAPI.GetBreedById(ctx, id)
+		if err != nil {
+			if errors.Is(err, catapi.ErrBreedNotFound) {
+				unresolved = append(unresolved, id)
+				continue
+			}
+			log.Fatalf("failed to resolve breed %q: %v", id, err)
+		}
+		if *dryRun {
+			fmt.Printf("%q -> %s: %d cats\n", id, breed.Name, usage[id])
+			continue
+		}
+		n, err := catRepo.UpdateBreedDetails(ctx, id, *services.NewBreedDetails(breed))
+		if err != nil {
+			log.Fatalf("failed to backfill %q: %v", id, err)
+		}
+		updated += n
+	}
+
+	fmt.Printf("updated cats: %d\n", updated)
+	if len(unresolved) > 0 {
+		fmt.Printf("unresolved breeds: %d\n", len(unresolved))
+		for _, id := range unresolved {
+			fmt.Printf("  %q: %d cats\n", id, usage[id])
+		}
+	}
+}
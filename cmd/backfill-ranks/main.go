@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/config"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+	"github.com/4oBuko/spy-cat-agency/internal/services"
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// one-off command that gives cats added before ranks were introduced the highest rank of the configured ladder they meet.
+// The rank isn't a promotion, so salary of the cats stays the same
+func main() {
+	dsn := flag.String("dsn", "user:password@/spycatagency?parseTime=true", "database connection string")
+	configPath := flag.String("config", "config/config.json", "agency settings with the rank ladder")
+	dryRun := flag.Bool("dry-run", false, "only report changes without updating rows")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	db, err := sql.Open("mysql", *dsn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	rankRepo := repositories.NewMySQLRankRepository(db)
+	ranks := services.NewRanks(cfg.Ranks)
+	unranked, err := rankRepo.GetUnranked(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ranked := make(map[string]int)
+	for _, cat := range unranked {
+		rank := ranks.For(cat.YearsOfExperience, cat.CompletedMissions).Name
+		ranked[rank]++
+		if *dryRun {
+			continue
+		}
+		if err := rankRepo.SetRank(ctx, cat.CatId, rank); err != nil {
+			log.Fatalf("failed to rank cat %d: %v", cat.CatId, err)
+		}
+	}
+
+	fmt.Printf("ranked cats: %d\n", len(unranked))
+	for _, rank := range ranks.All() {
+		if ranked[rank.Name] > 0 {
+			fmt.Printf("  %s: %d cats\n", rank.Name, ranked[rank.Name])
+		}
+	}
+}
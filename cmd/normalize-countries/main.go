@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+	"github.com/4oBuko/spy-cat-agency/pkg/country"
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// one-off command that replaces free-text countries of existing targets
+// with ISO 3166 names and codes. Countries that can't be resolved are reported and left as is
+func main() {
+	dsn := flag.String("dsn", "user:password@/spycatagency", "database connection string")
+	dryRun := flag.Bool("dry-run", false, "only report changes without updating rows")
+	flag.Parse()
+
+	db, err := sql.Open("mysql", *dsn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	targetRepo := repositories.NewMySQLTargetRepository(db)
+	usage, err := targetRepo.GetCountryUsage(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	values := make([]string, 0, len(usage))
+	for value := range usage {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	var updated int64
+	var unresolved []string
+	for _, value := range values {
+		c, err := country.Lookup(value)
+		if err != nil {
+			unresolved = append(unresolved, value)
+			continue
+		}
+		if *dryRun {
+			fmt.Printf("%q -> %s (%s): %d targets\n", value, c.Name, c.Alpha2, usage[value])
+			continue
+		}
+		n, err := targetRepo.UpdateCountry(ctx, value, c.Name, c.Alpha2)
+		if err != nil {
+			log.Fatalf("failed to normalize %q: %v", value, err)
+		}
+		updated += n
+	}
+
+	fmt.Printf("updated targets: %d\n", updated)
+	if len(unresolved) > 0 {
+		fmt.Printf("unresolved countries: %d\n", len(unresolved))
+		for _, value := range unresolved {
+			fmt.Printf("  %q: %d targets\n", value, usage[value])
+		}
+	}
+}
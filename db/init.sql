@@ -1,18 +1,60 @@
+CREATE TABLE
+    handlers (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        handler_name VARCHAR(100) NOT NULL,
+        email VARCHAR(255) NOT NULL DEFAULT '',
+        budget INT NULL
+    );
+
+CREATE TABLE
+    budget_lock (id INT PRIMARY KEY);
+
 CREATE TABLE
     cats (
         id INT AUTO_INCREMENT PRIMARY KEY,
         cat_name VARCHAR(50),
         years_of_experience INT NOT NULL,
         salary INT NOT NULL,
-        breed VARCHAR(100) NOT NULL
+        breed VARCHAR(100) NOT NULL,
+        breed_name VARCHAR(100) NOT NULL DEFAULT '',
+        breed_origin VARCHAR(100) NOT NULL DEFAULT '',
+        breed_temperament VARCHAR(255) NOT NULL DEFAULT '',
+        breed_life_span VARCHAR(20) NOT NULL DEFAULT '',
+        status VARCHAR(20) NOT NULL DEFAULT 'active',
+        cat_rank VARCHAR(50) NOT NULL DEFAULT '',
+        handler_id INT NOT NULL,
+        photo_url VARCHAR(255) NOT NULL DEFAULT '',
+        CONSTRAINT fk_cat_handler FOREIGN KEY (handler_id) REFERENCES handlers (id) ON DELETE RESTRICT
     );
 
 CREATE TABLE
     missions (
         id INT AUTO_INCREMENT PRIMARY KEY,
         cat_id INT,
+        mission_type VARCHAR(50) NOT NULL,
+        starts_at DATETIME NULL,
+        ends_at DATETIME NULL,
+        bonus INT NOT NULL DEFAULT 0,
+        assigned_at DATETIME NULL,
+        completed_at DATETIME NULL,
         completed BOOLEAN NOT NULL DEFAULT FALSE,
-        CONSTRAINT fk_mission_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+        INDEX idx_missions_cat_assigned (cat_id, assigned_at),
+        CONSTRAINT fk_mission_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE RESTRICT
+    );
+
+CREATE TABLE
+    mission_assignments (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        mission_id INT NOT NULL,
+        cat_id INT NOT NULL,
+        handler_id INT NOT NULL,
+        assigned_at DATETIME NOT NULL,
+        released_at DATETIME NULL,
+        INDEX idx_mission_assignments_cat_assigned (cat_id, assigned_at),
+        INDEX idx_mission_assignments_handler (handler_id),
+        CONSTRAINT fk_assignment_mission FOREIGN KEY (mission_id) REFERENCES missions (id) ON DELETE RESTRICT,
+        CONSTRAINT fk_assignment_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE RESTRICT,
+        CONSTRAINT fk_assignment_handler FOREIGN KEY (handler_id) REFERENCES handlers (id) ON DELETE RESTRICT
     );
 
 CREATE TABLE
@@ -21,7 +63,141 @@ CREATE TABLE
         mission_id INT NOT NULL,
         target_name VARCHAR(50) NOT NULL,
         country VARCHAR(100) NOT NULL,
+        country_code CHAR(2),
         notes TEXT,
         completed BOOLEAN NOT NULL DEFAULT FALSE,
         CONSTRAINT fk_target_mission FOREIGN KEY (mission_id) REFERENCES missions (id) ON DELETE CASCADE
-    );
\ No newline at end of file
+    );
+CREATE TABLE
+    mission_templates (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        template_name VARCHAR(100) NOT NULL,
+        mission_type VARCHAR(50) NOT NULL
+    );
+
+CREATE TABLE
+    template_targets (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        template_id INT NOT NULL,
+        target_name VARCHAR(50) NOT NULL,
+        country VARCHAR(100) NOT NULL,
+        country_code CHAR(2) NOT NULL,
+        notes TEXT,
+        CONSTRAINT fk_template_target_template FOREIGN KEY (template_id) REFERENCES mission_templates (id) ON DELETE CASCADE
+    );
+
+CREATE TABLE
+    salary_changes (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        cat_id INT NOT NULL,
+        salary INT NOT NULL,
+        effective_from DATETIME NOT NULL,
+        reason VARCHAR(255) NOT NULL DEFAULT '',
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        INDEX idx_salary_changes_cat_effective (cat_id, effective_from),
+        CONSTRAINT fk_salary_change_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+    );
+
+CREATE TABLE
+    status_changes (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        cat_id INT NOT NULL,
+        status VARCHAR(20) NOT NULL,
+        effective_from DATETIME NOT NULL,
+        reason VARCHAR(255) NOT NULL DEFAULT '',
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        INDEX idx_status_changes_cat_effective (cat_id, effective_from),
+        CONSTRAINT fk_status_change_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+    );
+
+CREATE TABLE
+    promotions (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        cat_id INT NOT NULL,
+        from_rank VARCHAR(50) NOT NULL,
+        to_rank VARCHAR(50) NOT NULL,
+        salary_raise INT NOT NULL DEFAULT 0,
+        promoted_at DATETIME NOT NULL,
+        INDEX idx_promotions_cat (cat_id, promoted_at),
+        CONSTRAINT fk_promotion_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+    );
+
+CREATE TABLE
+    ledger_entries (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        cat_id INT NOT NULL,
+        kind VARCHAR(20) NOT NULL,
+        amount INT NOT NULL,
+        mission_id INT NULL,
+        description VARCHAR(255) NOT NULL DEFAULT '',
+        posted_at DATETIME NOT NULL,
+        INDEX idx_ledger_entries_cat (cat_id, posted_at),
+        UNIQUE KEY uq_ledger_entries_mission_kind (mission_id, kind),
+        CONSTRAINT fk_ledger_entry_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE,
+        CONSTRAINT fk_ledger_entry_mission FOREIGN KEY (mission_id) REFERENCES missions (id) ON DELETE SET NULL
+    );
+
+CREATE TABLE
+    skills (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        skill_name VARCHAR(50) NOT NULL UNIQUE,
+        description VARCHAR(255) NOT NULL DEFAULT ''
+    );
+
+CREATE TABLE
+    cat_skills (
+        cat_id INT NOT NULL,
+        skill_id INT NOT NULL,
+        proficiency TINYINT NOT NULL,
+        PRIMARY KEY (cat_id, skill_id),
+        CONSTRAINT fk_cat_skill_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE,
+        CONSTRAINT fk_cat_skill_skill FOREIGN KEY (skill_id) REFERENCES skills (id) ON DELETE CASCADE
+    );
+
+CREATE TABLE
+    target_skills (
+        target_id INT NOT NULL,
+        skill_id INT NOT NULL,
+        min_proficiency TINYINT NOT NULL,
+        PRIMARY KEY (target_id, skill_id),
+        CONSTRAINT fk_target_skill_target FOREIGN KEY (target_id) REFERENCES targets (id) ON DELETE CASCADE,
+        CONSTRAINT fk_target_skill_skill FOREIGN KEY (skill_id) REFERENCES skills (id) ON DELETE CASCADE
+    );
+
+CREATE TABLE
+    cat_absences (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        cat_id INT NOT NULL,
+        kind VARCHAR(20) NOT NULL,
+        starts_at DATETIME NOT NULL,
+        ends_at DATETIME NOT NULL,
+        note VARCHAR(255) NOT NULL DEFAULT '',
+        INDEX idx_cat_absences_cat_period (cat_id, starts_at, ends_at),
+        CONSTRAINT fk_cat_absence_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+    );
+
+CREATE TABLE
+    idempotency_keys (
+        idempotency_key VARCHAR(255) NOT NULL PRIMARY KEY,
+        fingerprint CHAR(64) NOT NULL,
+        completed BOOLEAN NOT NULL DEFAULT FALSE,
+        status_code INT NOT NULL DEFAULT 0,
+        content_type VARCHAR(255) NOT NULL DEFAULT '',
+        body MEDIUMBLOB NULL,
+        expires_at DATETIME NOT NULL,
+        INDEX idx_idempotency_keys_expires (expires_at)
+    );
+
+INSERT INTO
+    skills (skill_name, description)
+VALUES
+    ('infiltration', 'Getting in and out unnoticed'),
+    ('lockpicking', 'Opening doors, safes and cat flaps'),
+    ('languages', 'Speaking with humans and other animals'),
+    ('hacking', 'Breaking into computer systems'),
+    ('disguise', 'Looking like a different cat');
+
+INSERT INTO
+    budget_lock (id)
+VALUES
+    (1);
@@ -0,0 +1 @@
+ALTER TABLE targets ADD COLUMN country_code CHAR(2) AFTER country;
@@ -0,0 +1,4 @@
+-- existing missions follow the 1-3 targets rule of the standard type from the default configuration.
+-- Missions of a type that is not configured are handled as missions of the configured default type
+ALTER TABLE missions ADD COLUMN mission_type VARCHAR(50) NOT NULL DEFAULT 'standard' AFTER cat_id;
+ALTER TABLE missions ALTER COLUMN mission_type DROP DEFAULT;
@@ -0,0 +1,17 @@
+CREATE TABLE
+    mission_templates (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        template_name VARCHAR(100) NOT NULL,
+        mission_type VARCHAR(50) NOT NULL
+    );
+
+CREATE TABLE
+    template_targets (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        template_id INT NOT NULL,
+        target_name VARCHAR(50) NOT NULL,
+        country VARCHAR(100) NOT NULL,
+        country_code CHAR(2) NOT NULL,
+        notes TEXT,
+        CONSTRAINT fk_template_target_template FOREIGN KEY (template_id) REFERENCES mission_templates (id) ON DELETE CASCADE
+    );
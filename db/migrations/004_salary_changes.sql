@@ -0,0 +1,15 @@
+CREATE TABLE
+    salary_changes (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        cat_id INT NOT NULL,
+        salary INT NOT NULL,
+        effective_from DATETIME NOT NULL,
+        reason VARCHAR(255) NOT NULL DEFAULT '',
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        INDEX idx_salary_changes_cat_effective (cat_id, effective_from),
+        CONSTRAINT fk_salary_change_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+    );
+
+-- start date of existing salaries is unknown, so they are considered effective since the beginning
+INSERT INTO salary_changes (cat_id, salary, effective_from, reason)
+SELECT id, salary, '1970-01-01', 'initial salary' FROM cats;
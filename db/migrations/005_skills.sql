@@ -0,0 +1,35 @@
+CREATE TABLE
+    skills (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        skill_name VARCHAR(50) NOT NULL UNIQUE,
+        description VARCHAR(255) NOT NULL DEFAULT ''
+    );
+
+CREATE TABLE
+    cat_skills (
+        cat_id INT NOT NULL,
+        skill_id INT NOT NULL,
+        proficiency TINYINT NOT NULL,
+        PRIMARY KEY (cat_id, skill_id),
+        CONSTRAINT fk_cat_skill_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE,
+        CONSTRAINT fk_cat_skill_skill FOREIGN KEY (skill_id) REFERENCES skills (id) ON DELETE CASCADE
+    );
+
+CREATE TABLE
+    target_skills (
+        target_id INT NOT NULL,
+        skill_id INT NOT NULL,
+        min_proficiency TINYINT NOT NULL,
+        PRIMARY KEY (target_id, skill_id),
+        CONSTRAINT fk_target_skill_target FOREIGN KEY (target_id) REFERENCES targets (id) ON DELETE CASCADE,
+        CONSTRAINT fk_target_skill_skill FOREIGN KEY (skill_id) REFERENCES skills (id) ON DELETE CASCADE
+    );
+
+INSERT INTO
+    skills (skill_name, description)
+VALUES
+    ('infiltration', 'Getting in and out unnoticed'),
+    ('lockpicking', 'Opening doors, safes and cat flaps'),
+    ('languages', 'Speaking with humans and other animals'),
+    ('hacking', 'Breaking into computer systems'),
+    ('disguise', 'Looking like a different cat');
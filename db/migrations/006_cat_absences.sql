@@ -0,0 +1,15 @@
+ALTER TABLE missions
+    ADD COLUMN starts_at DATETIME NULL AFTER mission_type,
+    ADD COLUMN ends_at DATETIME NULL AFTER starts_at;
+
+CREATE TABLE
+    cat_absences (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        cat_id INT NOT NULL,
+        kind VARCHAR(20) NOT NULL,
+        starts_at DATETIME NOT NULL,
+        ends_at DATETIME NOT NULL,
+        note VARCHAR(255) NOT NULL DEFAULT '',
+        INDEX idx_cat_absences_cat_period (cat_id, starts_at, ends_at),
+        CONSTRAINT fk_cat_absence_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+    );
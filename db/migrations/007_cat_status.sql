@@ -0,0 +1,17 @@
+ALTER TABLE cats ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active';
+
+CREATE TABLE
+    status_changes (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        cat_id INT NOT NULL,
+        status VARCHAR(20) NOT NULL,
+        effective_from DATETIME NOT NULL,
+        reason VARCHAR(255) NOT NULL DEFAULT '',
+        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        INDEX idx_status_changes_cat_effective (cat_id, effective_from),
+        CONSTRAINT fk_status_change_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+    );
+
+-- hiring date of existing cats is unknown, so they are considered active since the beginning
+INSERT INTO status_changes (cat_id, status, effective_from, reason)
+SELECT id, 'active', '1970-01-01', 'initial status' FROM cats;
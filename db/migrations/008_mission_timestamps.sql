@@ -0,0 +1,4 @@
+-- assignment and completion times of existing missions are unknown and stay NULL
+ALTER TABLE missions
+    ADD COLUMN assigned_at DATETIME NULL AFTER ends_at,
+    ADD COLUMN completed_at DATETIME NULL AFTER assigned_at;
@@ -0,0 +1,14 @@
+-- ranks are configured, so existing cats stay unranked until cmd/backfill-ranks gives them the rank of the configured ladder
+ALTER TABLE cats ADD COLUMN cat_rank VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE TABLE
+    promotions (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        cat_id INT NOT NULL,
+        from_rank VARCHAR(50) NOT NULL,
+        to_rank VARCHAR(50) NOT NULL,
+        salary_raise INT NOT NULL DEFAULT 0,
+        promoted_at DATETIME NOT NULL,
+        INDEX idx_promotions_cat (cat_id, promoted_at),
+        CONSTRAINT fk_promotion_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+    );
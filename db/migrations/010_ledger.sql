@@ -0,0 +1,16 @@
+ALTER TABLE missions ADD COLUMN bonus INT NOT NULL DEFAULT 0 AFTER ends_at;
+
+CREATE TABLE
+    ledger_entries (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        cat_id INT NOT NULL,
+        kind VARCHAR(20) NOT NULL,
+        amount INT NOT NULL,
+        mission_id INT NULL,
+        description VARCHAR(255) NOT NULL DEFAULT '',
+        posted_at DATETIME NOT NULL,
+        INDEX idx_ledger_entries_cat (cat_id, posted_at),
+        UNIQUE KEY uq_ledger_entries_mission_kind (mission_id, kind),
+        CONSTRAINT fk_ledger_entry_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE,
+        CONSTRAINT fk_ledger_entry_mission FOREIGN KEY (mission_id) REFERENCES missions (id) ON DELETE SET NULL
+    );
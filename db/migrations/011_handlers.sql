@@ -0,0 +1,15 @@
+CREATE TABLE
+    handlers (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        handler_name VARCHAR(100) NOT NULL,
+        email VARCHAR(255) NOT NULL DEFAULT ''
+    );
+
+-- existing cats are owned by this handler until they are transferred to a real one
+INSERT INTO handlers (handler_name) VALUES ('Unassigned');
+
+ALTER TABLE cats ADD COLUMN handler_id INT NULL;
+UPDATE cats SET handler_id = (SELECT id FROM handlers WHERE handler_name = 'Unassigned');
+ALTER TABLE cats
+    MODIFY handler_id INT NOT NULL,
+    ADD CONSTRAINT fk_cat_handler FOREIGN KEY (handler_id) REFERENCES handlers (id) ON DELETE RESTRICT;
@@ -0,0 +1,2 @@
+ALTER TABLE cats
+    ADD COLUMN photo_url VARCHAR(255) NOT NULL DEFAULT '';
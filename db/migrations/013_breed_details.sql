@@ -0,0 +1,6 @@
+-- run cmd/backfill-breeds afterwards to fill details of existing cats
+ALTER TABLE cats
+    ADD COLUMN breed_name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN breed_origin VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN breed_temperament VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN breed_life_span VARCHAR(20) NOT NULL DEFAULT '';
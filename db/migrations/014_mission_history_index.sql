@@ -0,0 +1,2 @@
+-- speeds up mission history of a cat filtered by date range
+CREATE INDEX idx_missions_cat_assigned ON missions (cat_id, assigned_at);
@@ -0,0 +1,7 @@
+-- monthly payroll cap of the handler. NULL means there is no cap
+ALTER TABLE handlers
+    ADD COLUMN budget INT NULL;
+
+-- the only row is locked while payroll is checked against the agency budget, so concurrent changes are checked one by one
+CREATE TABLE budget_lock (id INT PRIMARY KEY);
+INSERT INTO budget_lock (id) VALUES (1);
@@ -0,0 +1,12 @@
+-- responses of POST requests stored by their Idempotency-Key header so retries can be replayed
+CREATE TABLE
+    idempotency_keys (
+        idempotency_key VARCHAR(255) NOT NULL PRIMARY KEY,
+        fingerprint CHAR(64) NOT NULL,
+        completed BOOLEAN NOT NULL DEFAULT FALSE,
+        status_code INT NOT NULL DEFAULT 0,
+        content_type VARCHAR(255) NOT NULL DEFAULT '',
+        body MEDIUMBLOB NULL,
+        expires_at DATETIME NOT NULL,
+        INDEX idx_idempotency_keys_expires (expires_at)
+    );
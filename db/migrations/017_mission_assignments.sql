@@ -0,0 +1,24 @@
+CREATE TABLE
+    mission_assignments (
+        id INT AUTO_INCREMENT PRIMARY KEY,
+        mission_id INT NOT NULL,
+        cat_id INT NOT NULL,
+        handler_id INT NOT NULL,
+        assigned_at DATETIME NOT NULL,
+        released_at DATETIME NULL,
+        INDEX idx_mission_assignments_cat_assigned (cat_id, assigned_at),
+        INDEX idx_mission_assignments_handler (handler_id),
+        CONSTRAINT fk_assignment_mission FOREIGN KEY (mission_id) REFERENCES missions (id) ON DELETE RESTRICT,
+        CONSTRAINT fk_assignment_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE RESTRICT,
+        CONSTRAINT fk_assignment_handler FOREIGN KEY (handler_id) REFERENCES handlers (id) ON DELETE RESTRICT
+    );
+
+-- assignment time of some existing missions is unknown, they are considered assigned since the beginning
+-- by the current handler of the cat
+INSERT INTO mission_assignments (mission_id, cat_id, handler_id, assigned_at)
+SELECT m.id, m.cat_id, c.handler_id, COALESCE(m.assigned_at, '1970-01-01')
+FROM missions m JOIN cats c ON c.id = m.cat_id;
+
+-- missions are removed together with a cat explicitly, so history of cats is never cascaded away
+ALTER TABLE missions DROP FOREIGN KEY fk_mission_cat;
+ALTER TABLE missions ADD CONSTRAINT fk_mission_cat FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE RESTRICT;
@@ -0,0 +1,255 @@
+package spycatagency
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/gin-gonic/gin"
+)
+
+// handleBatch runs operations one by one through the router in a single database transaction. Responses of
+// all operations are returned when every operation succeeds. The first failed operation rolls back changes
+// of the whole batch and its problem is returned with index of the operation
+func (s *Server) handleBatch(ctx *gin.Context) {
+	var request models.BatchRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	if violations := batchViolations(request.Operations); len(violations) > 0 {
+		ctx.Error(myerrors.NewViolationsError(violations))
+		return
+	}
+
+	prefix := APIV1
+	if apiVersion(ctx) == 2 {
+		prefix = APIV2
+	}
+	var results []models.BatchResult
+	err := s.transactor.InTransaction(ctx.Request.Context(), func(txCtx context.Context) error {
+		ids := make(map[string]int64)
+		for i, op := range request.Operations {
+			result, err := s.runOperation(txCtx, prefix, op, ids)
+			if err != nil {
+				return &operationError{index: i, err: err}
+			}
+			results = append(results, result)
+			// redirects are failures too, the batch can't follow them inside its transaction
+			if result.Status < http.StatusOK || result.Status >= http.StatusMultipleChoices {
+				return &operationError{index: i, result: result}
+			}
+			if op.Ref != "" {
+				var created struct {
+					Id *int64 `json:"id"`
+				}
+				if json.Unmarshal(result.Body, &created) == nil && created.Id != nil {
+					ids[op.Ref] = *created.Id
+				}
+			}
+		}
+		return nil
+	})
+
+	var opErr *operationError
+	switch {
+	case errors.As(err, &opErr):
+		problem := opErr.problem(ctx.Request.URL.Path)
+		ctx.Header("Content-Type", myerrors.ProblemContentType)
+		ctx.JSON(problem.Status, problem)
+	case err != nil:
+		ctx.Error(myerrors.NewServerError(err.Error()))
+	default:
+		ctx.JSON(http.StatusOK, models.BatchResponse{Results: results})
+	}
+}
+
+// batchViolations checks that refs are unique and paths refer only to operations that come earlier
+func batchViolations(operations []models.BatchOperation) []myerrors.Violation {
+	var violations []myerrors.Violation
+	refs := make(map[string]bool)
+	for i, op := range operations {
+		for _, segment := range strings.Split(op.Path, "/") {
+			if ref, ok := strings.CutPrefix(segment, "$"); ok && !refs[ref] {
+				violations = append(violations, myerrors.Violation{Field: fmt.Sprintf("operations[%d].path", i), Rule: "ref",
+					Message: fmt.Sprintf("must refer to an earlier operation, %q is unknown", ref)})
+			}
+		}
+		if isBatchPath(op.Path) {
+			violations = append(violations, myerrors.Violation{Field: fmt.Sprintf("operations[%d].path", i), Rule: "nested",
+				Message: "must not be a batch"})
+		}
+		if op.Ref == "" {
+			continue
+		}
+		if refs[op.Ref] {
+			violations = append(violations, myerrors.Violation{Field: fmt.Sprintf("operations[%d].ref", i), Rule: "unique",
+				Message: "must be unique"})
+		}
+		refs[op.Ref] = true
+	}
+	return violations
+}
+
+// isBatchPath reports whether the router serves the path with the batch route. The path is decoded and cleaned,
+// so "/./batch" or "/%62atch" are detected too
+func isBatchPath(p string) bool {
+	u, err := url.Parse(p)
+	if err != nil {
+		return false
+	}
+	clean := path.Clean("/" + u.Path)
+	return clean == Endpoints.Batch || strings.HasPrefix(clean, Endpoints.Batch+"/")
+}
+
+// runOperation serves the operation with the router. Its request has context of the batch transaction
+func (s *Server) runOperation(ctx context.Context, prefix string, op models.BatchOperation, ids map[string]int64) (models.BatchResult, error) {
+	opPath, err := resolvePath(op.Path, ids)
+	if err != nil {
+		return models.BatchResult{}, err
+	}
+	body, err := resolveBody(op.Body, ids)
+	if err != nil {
+		return models.BatchResult{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, op.Method, prefix+opPath, bytes.NewReader(body))
+	if err != nil {
+		return models.BatchResult{}, myerrors.NewBadRequestError(err.Error())
+	}
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	recorder := &operationRecorder{header: make(http.Header)}
+	s.router.ServeHTTP(recorder, req)
+
+	result := models.BatchResult{Ref: op.Ref, Status: recorder.status}
+	if recorder.status == 0 {
+		result.Status = http.StatusOK
+	}
+	if recorder.body.Len() > 0 {
+		if !json.Valid(recorder.body.Bytes()) {
+			return models.BatchResult{}, myerrors.NewBadRequestError(op.Method + " " + op.Path + " doesn't respond with JSON")
+		}
+		result.Body = recorder.body.Bytes()
+	}
+	return result, nil
+}
+
+// resolvePath replaces "$ref" segments with ids of results of earlier operations
+func resolvePath(path string, ids map[string]int64) (string, error) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if ref, ok := strings.CutPrefix(segment, "$"); ok {
+			id, ok := ids[ref]
+			if !ok {
+				return "", myerrors.NewUnprocessableEntityError(fmt.Sprintf("result of operation %q has no id", ref))
+			}
+			segments[i] = fmt.Sprint(id)
+		}
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// resolveBody replaces string values "$ref" with ids of results of earlier operations.
+// Strings that don't match a ref of an earlier operation are kept as they are
+func resolveBody(body json.RawMessage, ids map[string]int64) ([]byte, error) {
+	if len(body) == 0 || len(ids) == 0 {
+		return body, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, myerrors.NewValidationError(err)
+	}
+	return json.Marshal(replaceRefs(value, ids))
+}
+
+func replaceRefs(value any, ids map[string]int64) any {
+	switch v := value.(type) {
+	case string:
+		if ref, ok := strings.CutPrefix(v, "$"); ok {
+			if id, ok := ids[ref]; ok {
+				return id
+			}
+		}
+	case map[string]any:
+		for key, item := range v {
+			v[key] = replaceRefs(item, ids)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = replaceRefs(item, ids)
+		}
+	}
+	return value
+}
+
+// operationError stops the batch and rolls back its transaction
+type operationError struct {
+	index int
+	// result is the failed response of the operation, err is set when the operation couldn't be served
+	result models.BatchResult
+	err    error
+}
+
+func (o *operationError) Error() string {
+	if o.err != nil {
+		return fmt.Sprintf("batch operation %d failed: %v", o.index, o.err)
+	}
+	return fmt.Sprintf("batch operation %d failed with status %d", o.index, o.result.Status)
+}
+
+// problem describes failure of the operation as a problem of the batch
+func (o *operationError) problem(instance string) myerrors.Problem {
+	var problem myerrors.Problem
+	if o.err != nil {
+		var appErr *myerrors.AppError
+		if !errors.As(o.err, &appErr) {
+			appErr = myerrors.NewServerError(o.err.Error())
+		}
+		problem = newProblem(appErr, instance)
+	} else if json.Unmarshal(o.result.Body, &problem) != nil || problem.Status == 0 {
+		appErr := &myerrors.AppError{StatusCode: o.result.Status, Code: myerrors.CodeBadRequest}
+		if o.result.Status < http.StatusBadRequest {
+			appErr.StatusCode = http.StatusBadRequest
+			appErr.Message = fmt.Sprintf("operation responded with status %d", o.result.Status)
+		}
+		problem = myerrors.NewProblem(appErr, instance, "")
+	}
+	problem.Instance = instance
+	problem.Operation = &o.index
+	return problem
+}
+
+// operationRecorder keeps the response of a batch operation
+type operationRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (o *operationRecorder) Header() http.Header {
+	return o.header
+}
+
+func (o *operationRecorder) Write(b []byte) (int, error) {
+	if o.status == 0 {
+		o.status = http.StatusOK
+	}
+	return o.body.Write(b)
+}
+
+func (o *operationRecorder) WriteHeader(status int) {
+	if o.status == 0 {
+		o.status = status
+	}
+}
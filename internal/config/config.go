@@ -0,0 +1,204 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+type Config struct {
+	DefaultMissionType string               `json:"defaultMissionType"`
+	MissionTypes       []models.MissionType `json:"missionTypes"`
+	Ranks              []models.Rank        `json:"ranks"`
+	Photos             Photos               `json:"photos"`
+	// PayrollBudget caps monthly salaries of all cats on payroll. Zero means there is no cap
+	PayrollBudget int         `json:"payrollBudget"`
+	Idempotency   Idempotency `json:"idempotency"`
+	GRPC          GRPC        `json:"grpc"`
+}
+
+type Photos struct {
+	// Dir is the directory where photos are stored on local disk
+	Dir            string `json:"dir"`
+	MaxSize        int64  `json:"maxSize"`
+	ThumbnailSizes []int  `json:"thumbnailSizes"`
+}
+
+// Stores of idempotency keys
+const (
+	IdempotencyStoreMemory = "memory"
+	IdempotencyStoreSQL    = "sql"
+)
+
+type Idempotency struct {
+	// Store is "memory" to keep keys in the process or "sql" to share them between instances through the database
+	Store string `json:"store"`
+	// TTL is how long responses are replayed for retries with the same key
+	TTL Duration `json:"ttl"`
+}
+
+// GRPC is the gRPC listener that runs next to the HTTP server
+type GRPC struct {
+	// Addr is the address gRPC server listens on. Empty address disables gRPC
+	Addr string `json:"addr"`
+}
+
+// Duration is written in JSON as a string like "24h" or "90m"
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = duration
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Default returns configuration that matches behaviour of the agency before it became configurable
+func Default() Config {
+	return Config{
+		DefaultMissionType: "standard",
+		MissionTypes: []models.MissionType{
+			{Name: "standard", MinTargets: 1, MaxTargets: 3},
+		},
+		Ranks: []models.Rank{
+			{Name: "cadet"},
+			{Name: "field agent", MinExperience: 2, MinCompletedMissions: 3},
+			{Name: "senior agent", MinExperience: 5, MinCompletedMissions: 10},
+			{Name: "spymaster", MinExperience: 10, MinCompletedMissions: 25},
+		},
+		Photos: Photos{
+			Dir:            "data/photos",
+			MaxSize:        5 << 20,
+			ThumbnailSizes: []int{64, 256},
+		},
+		Idempotency: Idempotency{
+			Store: IdempotencyStoreMemory,
+			TTL:   Duration{24 * time.Hour},
+		},
+		GRPC: GRPC{Addr: ":9090"},
+	}
+}
+
+// Load reads configuration from JSON file. Fields missing in the file keep their default values
+func Load(path string) (Config, error) {
+	cfg := Default()
+	file, err := os.Open(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to open config: %w", err)
+	}
+	defer file.Close()
+
+	if err := json.NewDecoder(file).Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+func (c Config) Validate() error {
+	if len(c.MissionTypes) == 0 {
+		return errors.New("at least one mission type must be configured")
+	}
+	seen := make(map[string]bool, len(c.MissionTypes))
+	for _, mt := range c.MissionTypes {
+		if mt.Name == "" {
+			return errors.New("mission type name must not be empty")
+		}
+		if seen[mt.Name] {
+			return fmt.Errorf("mission type %q is configured twice", mt.Name)
+		}
+		seen[mt.Name] = true
+		if mt.MinTargets < 1 || mt.MaxTargets < mt.MinTargets {
+			return fmt.Errorf("mission type %q must allow at least one target and maxTargets must not be less than minTargets", mt.Name)
+		}
+		if mt.MinExperience < 0 || mt.SalaryBonus < 0 {
+			return fmt.Errorf("mission type %q must not have negative experience or bonus", mt.Name)
+		}
+	}
+	if !seen[c.DefaultMissionType] {
+		return fmt.Errorf("default mission type %q is not configured", c.DefaultMissionType)
+	}
+	if err := c.validateRanks(); err != nil {
+		return err
+	}
+	if c.PayrollBudget < 0 {
+		return errors.New("payroll budget must not be negative")
+	}
+	if err := c.validateIdempotency(); err != nil {
+		return err
+	}
+	return c.validatePhotos()
+}
+
+func (c Config) validateIdempotency() error {
+	if c.Idempotency.Store != IdempotencyStoreMemory && c.Idempotency.Store != IdempotencyStoreSQL {
+		return fmt.Errorf("idempotency store must be %q or %q", IdempotencyStoreMemory, IdempotencyStoreSQL)
+	}
+	if c.Idempotency.TTL.Duration <= 0 {
+		return errors.New("idempotency key ttl must be positive")
+	}
+	return nil
+}
+
+func (c Config) validatePhotos() error {
+	if c.Photos.Dir == "" {
+		return errors.New("photos directory must be configured")
+	}
+	if c.Photos.MaxSize <= 0 {
+		return errors.New("max photo size must be positive")
+	}
+	for _, size := range c.Photos.ThumbnailSizes {
+		if size <= 0 || size > 1024 {
+			return fmt.Errorf("thumbnail size %d must be between 1 and 1024", size)
+		}
+	}
+	return nil
+}
+
+// validateRanks checks that ranks are ordered from the lowest to the highest and every cat can get the first one
+func (c Config) validateRanks() error {
+	if len(c.Ranks) == 0 {
+		return errors.New("at least one rank must be configured")
+	}
+	if c.Ranks[0].MinExperience != 0 || c.Ranks[0].MinCompletedMissions != 0 {
+		return fmt.Errorf("first rank %q must not have requirements", c.Ranks[0].Name)
+	}
+	seen := make(map[string]bool, len(c.Ranks))
+	for i, r := range c.Ranks {
+		if r.Name == "" {
+			return errors.New("rank name must not be empty")
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("rank %q is configured twice", r.Name)
+		}
+		seen[r.Name] = true
+		if r.SalaryRaise < 0 {
+			return fmt.Errorf("rank %q must not have negative salary raise", r.Name)
+		}
+		if i > 0 {
+			prev := c.Ranks[i-1]
+			if r.MinExperience < prev.MinExperience || r.MinCompletedMissions < prev.MinCompletedMissions {
+				return fmt.Errorf("rank %q must not have lower requirements than %q", r.Name, prev.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("load shipped config", func(t *testing.T) {
+		cfg, err := Load("../../config/config.json")
+		require.NoError(t, err)
+		assert.NotEmpty(t, cfg.MissionTypes)
+		assert.Equal(t, IdempotencyStoreSQL, cfg.Idempotency.Store)
+		assert.Equal(t, 24*time.Hour, cfg.Idempotency.TTL.Duration)
+		assert.Equal(t, ":9090", cfg.GRPC.Addr)
+	})
+
+	t.Run("default mission type must be configured", func(t *testing.T) {
+		cfg := Default()
+		cfg.DefaultMissionType = "unknown"
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("max targets must not be less than min targets", func(t *testing.T) {
+		cfg := Default()
+		cfg.MissionTypes[0].MaxTargets = 0
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("ranks must be ordered by requirements", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ranks[1], cfg.Ranks[2] = cfg.Ranks[2], cfg.Ranks[1]
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("first rank must not have requirements", func(t *testing.T) {
+		cfg := Default()
+		cfg.Ranks[0].MinExperience = 1
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("payroll budget must not be negative", func(t *testing.T) {
+		cfg := Default()
+		cfg.PayrollBudget = -1
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("idempotency store must be known", func(t *testing.T) {
+		cfg := Default()
+		cfg.Idempotency.Store = "redis"
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("idempotency key ttl must be positive", func(t *testing.T) {
+		cfg := Default()
+		cfg.Idempotency.TTL.Duration = 0
+		assert.Error(t, cfg.Validate())
+	})
+
+	t.Run("thumbnail size must be positive", func(t *testing.T) {
+		cfg := Default()
+		cfg.Photos.ThumbnailSizes = []int{64, 0}
+		assert.Error(t, cfg.Validate())
+	})
+}
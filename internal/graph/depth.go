@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// maxQueryDepth limits nesting of fields. Cats and missions refer to each other, so without a limit a small
+// query could load the same objects over and over
+const maxQueryDepth = 8
+
+// checkDepth rejects operations with fields nested deeper than maxQueryDepth. The document must be validated,
+// validation rejects fragments that spread themselves
+func checkDepth(document *ast.Document) []gqlerrors.FormattedError {
+	d := depthCounter{fragments: make(map[string]*ast.FragmentDefinition), depths: make(map[string]int)}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			d.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	var errs []gqlerrors.FormattedError
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if depth := d.selectionSet(operation.SelectionSet); depth > maxQueryDepth {
+			errs = append(errs, gqlerrors.NewFormattedError(
+				fmt.Sprintf("query depth %d exceeds the limit of %d", depth, maxQueryDepth)))
+		}
+	}
+	return errs
+}
+
+// depthCounter remembers depths of fragments, so fragments spread many times are counted once
+type depthCounter struct {
+	fragments map[string]*ast.FragmentDefinition
+	depths    map[string]int
+}
+
+func (d *depthCounter) selectionSet(set *ast.SelectionSet) int {
+	if set == nil {
+		return 0
+	}
+	depth := 0
+	for _, selection := range set.Selections {
+		var selectionDepth int
+		switch s := selection.(type) {
+		case *ast.Field:
+			// introspection is served from the schema and doesn't call services
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			selectionDepth = 1 + d.selectionSet(s.SelectionSet)
+		case *ast.InlineFragment:
+			selectionDepth = d.selectionSet(s.SelectionSet)
+		case *ast.FragmentSpread:
+			selectionDepth = d.fragment(s.Name.Value)
+		}
+		depth = max(depth, selectionDepth)
+	}
+	return depth
+}
+
+func (d *depthCounter) fragment(name string) int {
+	if depth, ok := d.depths[name]; ok {
+		return depth
+	}
+	fragment, ok := d.fragments[name]
+	if !ok {
+		return 0
+	}
+	depth := d.selectionSet(fragment.SelectionSet)
+	d.depths[name] = depth
+	return depth
+}
@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/services"
+)
+
+// loader batches loads of values by keys. Resolvers register keys and return thunks, the executor calls
+// thunks after all fields of the same depth are resolved. The first called thunk loads every key registered
+// so far with a single call of batch, so nested lists don't load their items one by one
+type loader[K comparable, V any] struct {
+	mu      sync.Mutex
+	batch   func(ctx context.Context, keys []K) (map[K]V, error)
+	pending []K
+	values  map[K]V
+	errs    map[K]error
+}
+
+func newLoader[K comparable, V any](batch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		batch:  batch,
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+// load registers the key and returns thunk of its value. Keys missing in the batch result have zero value
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	if !l.known(key) {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+			values, err := l.batch(ctx, keys)
+			for _, k := range keys {
+				if err != nil {
+					l.errs[k] = err
+				} else {
+					l.values[k] = values[k]
+				}
+			}
+		}
+		return l.values[key], l.errs[key]
+	}
+}
+
+func (l *loader[K, V]) known(key K) bool {
+	if _, ok := l.values[key]; ok {
+		return true
+	}
+	if _, ok := l.errs[key]; ok {
+		return true
+	}
+	for _, k := range l.pending {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// loaders are created for every request, so values are not shared between requests
+type loaders struct {
+	cats     *loader[int64, *models.Cat]
+	missions *loader[int64, []models.Mission]
+}
+
+func newLoaders(catService services.CatService, missionService services.MissionService) *loaders {
+	return &loaders{
+		cats: newLoader(func(ctx context.Context, ids []int64) (map[int64]*models.Cat, error) {
+			cats, err := catService.GetByIds(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[int64]*models.Cat, len(cats))
+			for id, cat := range cats {
+				result[id] = &cat
+			}
+			return result, nil
+		}),
+		missions: newLoader(missionService.GetByCatIds),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,557 @@
+// Package graph serves cats, their missions and targets over GraphQL. Resolvers use the same services
+// as the REST API, nested cats and missions are loaded in batches
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/services"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Request is body of GraphQL requests sent over HTTP
+type Request struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type Schema struct {
+	schema         graphql.Schema
+	catService     services.CatService
+	missionService services.MissionService
+}
+
+func NewSchema(catService services.CatService, missionService services.MissionService) (*Schema, error) {
+	s := &Schema{catService: catService, missionService: missionService}
+	schema, err := graphql.NewSchema(s.config())
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// Execute runs the request. Every request has its own loaders. Queries nested deeper than maxQueryDepth
+// are rejected before they are executed
+func (s *Schema) Execute(ctx context.Context, request Request) *graphql.Result {
+	document, err := parser.Parse(parser.ParseParams{Source: request.Query})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if validation := graphql.ValidateDocument(&s.schema, document, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+	if errs := checkDepth(document); len(errs) > 0 {
+		return &graphql.Result{Errors: errs}
+	}
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       withLoaders(ctx, newLoaders(s.catService, s.missionService)),
+	})
+}
+
+// AppError returns the error of a service that caused the GraphQL error.
+// Errors of the query itself, such as syntax errors, have none
+func AppError(err gqlerrors.FormattedError) *myerrors.AppError {
+	var cause error = err
+	for cause != nil {
+		var appErr *myerrors.AppError
+		if errors.As(cause, &appErr) {
+			return appErr
+		}
+		switch e := cause.(type) {
+		case gqlerrors.FormattedError:
+			cause = e.OriginalError()
+		case *gqlerrors.Error:
+			cause = e.OriginalError
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *Schema) config() graphql.SchemaConfig {
+	breedDetailsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BreedDetails",
+		Fields: graphql.Fields{
+			"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"origin":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"temperament": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"lifeSpan":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	skillRequirementType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SkillRequirement",
+		Fields: graphql.Fields{
+			"skill":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"minProficiency": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+	targetType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Target",
+		Fields: graphql.Fields{
+			"id":             &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"name":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"country":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"countryCode":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"notes":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"completed":      &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"requiredSkills": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(skillRequirementType)))},
+		},
+	})
+
+	// cats and missions refer to each other, so their fields are created lazily
+	var catType, missionType *graphql.Object
+	catType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Cat",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":                &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"name":              &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"yearsOfExperience": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"breed":             &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"breedDetails":      &graphql.Field{Type: breedDetailsType},
+				"salary":            &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"status":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"rank":              &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"handlerId": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return optionalId(source[models.Cat](p).HandlerId), nil
+				}},
+				"photoUrl": &graphql.Field{Type: graphql.String},
+				"missions": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(missionType))),
+					Description: "Current and past missions of the cat, including missions it was released from",
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return thunk(loadersFrom(p.Context).missions.load(p.Context, source[models.Cat](p).Id)), nil
+					},
+				},
+			}
+		}),
+	})
+	missionType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mission",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"catId": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return optionalId(source[models.Mission](p).CatId), nil
+				}},
+				"cat": &graphql.Field{
+					Type:        catType,
+					Description: "Cat assigned to the mission",
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						catId := source[models.Mission](p).CatId
+						if catId == 0 {
+							return nil, nil
+						}
+						return thunk(loadersFrom(p.Context).cats.load(p.Context, catId)), nil
+					},
+				},
+				"type":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"startsAt":  &graphql.Field{Type: graphql.DateTime},
+				"endsAt":    &graphql.Field{Type: graphql.DateTime},
+				"bonus":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"completed": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+				"targets":   &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(targetType)))},
+			}
+		}),
+	})
+	paginationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Pagination",
+		Fields: graphql.Fields{
+			"pageSize":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"page":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"total":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"totalPages": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+	catPageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CatPage",
+		Fields: graphql.Fields{
+			"cats": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(catType)))},
+			"meta": &graphql.Field{Type: graphql.NewNonNull(paginationType)},
+		},
+	})
+	missionPageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "MissionPage",
+		Fields: graphql.Fields{
+			"missions": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(missionType)))},
+			"meta":     &graphql.Field{Type: graphql.NewNonNull(paginationType)},
+		},
+	})
+	assignResultType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "AssignResult",
+		Fields: graphql.Fields{
+			"mission":  &graphql.Field{Type: graphql.NewNonNull(missionType)},
+			"warnings": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"cat": &graphql.Field{
+				Type: catType,
+				Args: graphql.FieldConfigArgument{"id": idArg()},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return s.catService.GetById(p.Context, id(p, "id"))
+				},
+			},
+			"cats": &graphql.Field{
+				Type: graphql.NewNonNull(catPageType),
+				Args: graphql.FieldConfigArgument{
+					"page":    &graphql.ArgumentConfig{Type: graphql.Int},
+					"size":    &graphql.ArgumentConfig{Type: graphql.Int},
+					"skill":   &graphql.ArgumentConfig{Type: graphql.String},
+					"status":  &graphql.ArgumentConfig{Type: graphql.String},
+					"handler": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					query, err := decode[models.CatQuery](map[string]any{
+						"page": p.Args["page"], "size": p.Args["size"], "skill": p.Args["skill"],
+						"status": p.Args["status"], "handlerId": p.Args["handler"],
+					})
+					if err != nil {
+						return nil, err
+					}
+					return s.catService.GetAll(p.Context, query)
+				},
+			},
+			"mission": &graphql.Field{
+				Type: missionType,
+				Args: graphql.FieldConfigArgument{"id": idArg()},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return s.missionService.GetById(p.Context, id(p, "id"))
+				},
+			},
+			"missions": &graphql.Field{
+				Type: graphql.NewNonNull(missionPageType),
+				Args: graphql.FieldConfigArgument{
+					"page":    &graphql.ArgumentConfig{Type: graphql.Int},
+					"size":    &graphql.ArgumentConfig{Type: graphql.Int},
+					"handler": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					query, err := decode[models.PaginationQuery](map[string]any{"page": p.Args["page"], "size": p.Args["size"]})
+					if err != nil {
+						return nil, err
+					}
+					filter := models.MissionFilter{HandlerId: optionalInt(p.Args["handler"])}
+					return s.missionService.GetAll(p.Context, query, filter)
+				},
+			},
+		},
+	})
+
+	return graphql.SchemaConfig{Query: query, Mutation: s.mutation(catType, missionType, targetType, assignResultType)}
+}
+
+func (s *Schema) mutation(catType, missionType, targetType, assignResultType *graphql.Object) *graphql.Object {
+	skillRequirementInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "SkillRequirementInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"skill":          &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"minProficiency": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		},
+	})
+	targetInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TargetInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":           &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"country":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"notes":          &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"requiredSkills": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(skillRequirementInput))},
+		},
+	})
+	catInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CatInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":              &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"yearsOfExperience": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"breed":             &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"salary":            &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"status":            &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"handlerId":         &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		},
+	})
+	catUpdateInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CatUpdateInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"salary":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"effectiveFrom": &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+			"reason":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	catPatchInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "CatPatchInput",
+		Description: "Fields of the profile to change. Omitted fields keep their values",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":              &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"yearsOfExperience": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"breed":             &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"salary":            &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		},
+	})
+	statusUpdateInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "StatusUpdateInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"status":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"effectiveFrom": &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+			"reason":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	missionInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "MissionInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"type":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"startsAt": &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+			"endsAt":   &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+			"bonus":    &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"targets":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(targetInput)))},
+		},
+	})
+	targetUpdateInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TargetUpdateInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"notes": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	targetArgs := graphql.FieldConfigArgument{"missionId": idArg(), "targetId": idArg()}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"addCat": &graphql.Field{
+				Type: graphql.NewNonNull(catType),
+				Args: graphql.FieldConfigArgument{"input": inputArg(catInput)},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					cat, err := decode[models.Cat](p.Args["input"])
+					if err != nil {
+						return nil, err
+					}
+					return s.catService.Add(p.Context, cat)
+				},
+			},
+			"updateCat": &graphql.Field{
+				Type:        graphql.NewNonNull(catType),
+				Description: "Changes salary of the cat",
+				Args:        graphql.FieldConfigArgument{"id": idArg(), "input": inputArg(catUpdateInput)},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					update, err := decode[models.CatUpdate](p.Args["input"])
+					if err != nil {
+						return nil, err
+					}
+					return s.catService.Update(p.Context, id(p, "id"), update)
+				},
+			},
+			"patchCat": &graphql.Field{
+				Type:        graphql.NewNonNull(catType),
+				Description: "Changes the profile of the cat like a merge patch of the REST API",
+				Args:        graphql.FieldConfigArgument{"id": idArg(), "input": inputArg(catPatchInput)},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					patch, err := json.Marshal(p.Args["input"])
+					if err != nil {
+						return nil, myerrors.NewBadRequestError(err.Error())
+					}
+					return s.catService.Patch(p.Context, id(p, "id"), patch)
+				},
+			},
+			"updateCatStatus": &graphql.Field{
+				Type: graphql.NewNonNull(catType),
+				Args: graphql.FieldConfigArgument{"id": idArg(), "input": inputArg(statusUpdateInput)},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					update, err := decode[models.StatusUpdate](p.Args["input"])
+					if err != nil {
+						return nil, err
+					}
+					return s.catService.UpdateStatus(p.Context, id(p, "id"), update)
+				},
+			},
+			"deleteCat": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{"id": idArg()},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return done(s.catService.DeleteById(p.Context, id(p, "id")))
+				},
+			},
+			"addMission": &graphql.Field{
+				Type: graphql.NewNonNull(missionType),
+				Args: graphql.FieldConfigArgument{"input": inputArg(missionInput)},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					mission, err := decode[models.Mission](p.Args["input"])
+					if err != nil {
+						return nil, err
+					}
+					return s.missionService.Add(p.Context, mission)
+				},
+			},
+			"addMissionFromTemplate": &graphql.Field{
+				Type: graphql.NewNonNull(missionType),
+				Args: graphql.FieldConfigArgument{"templateId": idArg()},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return s.missionService.AddFromTemplate(p.Context, id(p, "templateId"))
+				},
+			},
+			"cloneMission": &graphql.Field{
+				Type: graphql.NewNonNull(missionType),
+				Args: graphql.FieldConfigArgument{"id": idArg()},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return s.missionService.Clone(p.Context, id(p, "id"))
+				},
+			},
+			"assignMission": &graphql.Field{
+				Type:        graphql.NewNonNull(assignResultType),
+				Description: "Assigns the cat to the mission. Cats lacking required skills are assigned only with override",
+				Args: graphql.FieldConfigArgument{
+					"missionId": idArg(),
+					"catId":     idArg(),
+					"override":  &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					missionId := id(p, "missionId")
+					override, _ := p.Args["override"].(bool)
+					result, err := s.missionService.Assign(p.Context, missionId, id(p, "catId"), override)
+					if err != nil {
+						return nil, err
+					}
+					mission, err := s.missionService.GetById(p.Context, missionId)
+					if err != nil {
+						return nil, err
+					}
+					warnings := result.Warnings
+					if warnings == nil {
+						warnings = []string{}
+					}
+					return map[string]any{"mission": mission, "warnings": warnings}, nil
+				},
+			},
+			"completeMission": &graphql.Field{
+				Type: graphql.NewNonNull(missionType),
+				Args: graphql.FieldConfigArgument{"id": idArg()},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return s.missionService.Complete(p.Context, id(p, "id"))
+				},
+			},
+			"deleteMission": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{"id": idArg()},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return done(s.missionService.Delete(p.Context, id(p, "id")))
+				},
+			},
+			"addTarget": &graphql.Field{
+				Type: graphql.NewNonNull(missionType),
+				Args: graphql.FieldConfigArgument{"missionId": idArg(), "input": inputArg(targetInput)},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					target, err := decode[models.Target](p.Args["input"])
+					if err != nil {
+						return nil, err
+					}
+					return s.missionService.AddTarget(p.Context, id(p, "missionId"), target)
+				},
+			},
+			"updateTarget": &graphql.Field{
+				Type: graphql.NewNonNull(targetType),
+				Args: graphql.FieldConfigArgument{"missionId": idArg(), "targetId": idArg(), "input": inputArg(targetUpdateInput)},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					update, err := decode[models.TargetUpdate](p.Args["input"])
+					if err != nil {
+						return nil, err
+					}
+					return s.missionService.UpdateTarget(p.Context, id(p, "missionId"), id(p, "targetId"), update)
+				},
+			},
+			"completeTarget": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: targetArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return done(s.missionService.CompleteTarget(p.Context, id(p, "missionId"), id(p, "targetId")))
+				},
+			},
+			"deleteTarget": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: targetArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return done(s.missionService.DeleteTarget(p.Context, id(p, "missionId"), id(p, "targetId")))
+				},
+			},
+		},
+	})
+}
+
+// decode converts arguments to the model and validates it like the body of REST requests
+func decode[T any](input any) (T, error) {
+	var value T
+	data, err := json.Marshal(input)
+	if err != nil {
+		return value, myerrors.NewBadRequestError(err.Error())
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, myerrors.NewValidationError(err)
+	}
+	if err := binding.Validator.ValidateStruct(value); err != nil {
+		return value, myerrors.NewValidationError(err)
+	}
+	return value, nil
+}
+
+// source returns the object of the field. Loaded objects are pointers
+func source[T any](p graphql.ResolveParams) T {
+	if value, ok := p.Source.(*T); ok {
+		return *value
+	}
+	value, _ := p.Source.(T)
+	return value
+}
+
+// thunk lets the executor resolve a value loaded in batch later
+func thunk[V any](load func() (V, error)) func() (any, error) {
+	return func() (any, error) {
+		return load()
+	}
+}
+
+func idArg() *graphql.ArgumentConfig {
+	return &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}
+}
+
+func inputArg(input *graphql.InputObject) *graphql.ArgumentConfig {
+	return &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)}
+}
+
+func id(p graphql.ResolveParams, name string) int64 {
+	return optionalInt(p.Args[name])
+}
+
+func optionalInt(value any) int64 {
+	n, _ := value.(int)
+	return int64(n)
+}
+
+// optionalId is null when the id is not set
+func optionalId(id int64) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// done is result of mutations that don't return an object
+func done(err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return true, nil
+}
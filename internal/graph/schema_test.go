@@ -0,0 +1,221 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCatService serves cats from memory and counts batch loads
+type fakeCatService struct {
+	services.CatService
+	cats    map[int64]models.Cat
+	batches [][]int64
+	singles int
+	added   models.Cat
+	patch   string
+}
+
+func (f *fakeCatService) GetById(ctx context.Context, id int64) (models.Cat, error) {
+	f.singles++
+	cat, ok := f.cats[id]
+	if !ok {
+		return models.Cat{}, myerrors.NewNotFoundError("cat not found")
+	}
+	return cat, nil
+}
+
+func (f *fakeCatService) GetByIds(ctx context.Context, ids []int64) (map[int64]models.Cat, error) {
+	f.batches = append(f.batches, ids)
+	result := make(map[int64]models.Cat)
+	for _, id := range ids {
+		if cat, ok := f.cats[id]; ok {
+			result[id] = cat
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeCatService) GetAll(ctx context.Context, query models.CatQuery) (models.PaginatedCats, error) {
+	page := models.PaginatedCats{Cats: []models.Cat{}}
+	for id := int64(1); id <= int64(len(f.cats)); id++ {
+		page.Cats = append(page.Cats, f.cats[id])
+	}
+	page.Meta = models.Pagination{Page: 1, PageSize: 10, Total: len(page.Cats), TotalPages: 1}
+	return page, nil
+}
+
+func (f *fakeCatService) Add(ctx context.Context, cat models.Cat) (models.Cat, error) {
+	cat.Id = int64(len(f.cats) + 1)
+	f.added = cat
+	return cat, nil
+}
+
+func (f *fakeCatService) Patch(ctx context.Context, id int64, patch []byte) (models.Cat, error) {
+	f.patch = string(patch)
+	return f.GetById(ctx, id)
+}
+
+// fakeMissionService serves missions from memory and counts batch loads
+type fakeMissionService struct {
+	services.MissionService
+	missions []models.Mission
+	batches  [][]int64
+}
+
+func (f *fakeMissionService) GetAll(ctx context.Context, query models.PaginationQuery, filter models.MissionFilter) (models.PaginatedMissions, error) {
+	return models.PaginatedMissions{Missions: f.missions, Meta: models.Pagination{Page: 1, PageSize: 10, Total: len(f.missions), TotalPages: 1}}, nil
+}
+
+func (f *fakeMissionService) GetByCatIds(ctx context.Context, catIds []int64) (map[int64][]models.Mission, error) {
+	f.batches = append(f.batches, catIds)
+	result := make(map[int64][]models.Mission)
+	for _, mission := range f.missions {
+		for _, id := range catIds {
+			if mission.CatId == id {
+				result[id] = append(result[id], mission)
+			}
+		}
+	}
+	return result, nil
+}
+
+func newTestSchema(t *testing.T) (*Schema, *fakeCatService, *fakeMissionService) {
+	cats := &fakeCatService{cats: map[int64]models.Cat{
+		1: {Id: 1, Name: "Tom", Breed: "abys", Status: "active", Rank: "junior"},
+		2: {Id: 2, Name: "Felix", Breed: "beng", Status: "active", Rank: "senior", HandlerId: 1},
+		3: {Id: 3, Name: "Garfield", Breed: "pers", Status: "recruit", Rank: "junior"},
+	}}
+	missions := &fakeMissionService{missions: []models.Mission{
+		{Id: 10, CatId: 1, Type: "standard", Targets: []models.Target{{Id: 100, Name: "Jerry", Country: "Spain"}}},
+		{Id: 11, CatId: 2, Type: "standard", Targets: []models.Target{{Id: 101, Name: "Spike", Country: "France"}}},
+		{Id: 12, CatId: 1, Type: "covert", Targets: []models.Target{{Id: 102, Name: "Tyke", Country: "Italy"}}},
+		{Id: 13, Type: "standard", Targets: []models.Target{{Id: 103, Name: "Butch", Country: "Greece"}}},
+	}}
+	schema, err := NewSchema(cats, missions)
+	require.NoError(t, err)
+	return schema, cats, missions
+}
+
+func TestNestedFieldsAreLoadedInBatches(t *testing.T) {
+	schema, cats, missions := newTestSchema(t)
+
+	result := schema.Execute(context.Background(), Request{Query: `{
+		cats { cats { id handlerId missions { id targets { name } cat { name } } } }
+		missions { missions { id catId cat { name missions { id } } } }
+	}`})
+	require.Empty(t, result.Errors)
+
+	// one batch of missions for cats of both lists, one batch of cats for every missions level
+	assert.Len(t, missions.batches, 1)
+	assert.ElementsMatch(t, []int64{1, 2, 3}, missions.batches[0])
+	assert.Len(t, cats.batches, 1)
+	assert.ElementsMatch(t, []int64{1, 2}, cats.batches[0])
+	assert.Zero(t, cats.singles)
+
+	var data struct {
+		Cats struct {
+			Cats []struct {
+				Id        int64
+				HandlerId *int64
+				Missions  []struct {
+					Id      int64
+					Targets []struct{ Name string }
+					Cat     struct{ Name string }
+				}
+			}
+		}
+		Missions struct {
+			Missions []struct {
+				Id    int64
+				CatId *int64
+				Cat   *struct{ Name string }
+			}
+		}
+	}
+	decodeData(t, result.Data, &data)
+	require.Len(t, data.Cats.Cats, 3)
+	assert.Nil(t, data.Cats.Cats[0].HandlerId)
+	assert.Equal(t, int64(1), *data.Cats.Cats[1].HandlerId)
+	require.Len(t, data.Cats.Cats[0].Missions, 2)
+	assert.Equal(t, "Jerry", data.Cats.Cats[0].Missions[0].Targets[0].Name)
+	assert.Equal(t, "Tom", data.Cats.Cats[0].Missions[0].Cat.Name)
+	assert.Empty(t, data.Cats.Cats[2].Missions)
+	require.Len(t, data.Missions.Missions, 4)
+	assert.Equal(t, "Felix", data.Missions.Missions[1].Cat.Name)
+	assert.Nil(t, data.Missions.Missions[3].CatId)
+	assert.Nil(t, data.Missions.Missions[3].Cat)
+}
+
+func TestMutationValidatesInput(t *testing.T) {
+	schema, cats, _ := newTestSchema(t)
+
+	result := schema.Execute(context.Background(), Request{
+		Query:     `mutation($cat: CatInput!) { addCat(input: $cat) { id name } }`,
+		Variables: map[string]any{"cat": map[string]any{"name": "Luna", "yearsOfExperience": 2, "breed": "abys", "salary": 1000}},
+	})
+	require.Empty(t, result.Errors)
+	assert.Equal(t, "Luna", cats.added.Name)
+
+	result = schema.Execute(context.Background(), Request{
+		Query: `mutation { addCat(input: {name: "Luna", yearsOfExperience: 2, breed: "abys", salary: 1000, status: "sleeping"}) { id } }`,
+	})
+	require.Len(t, result.Errors, 1)
+	appErr := AppError(result.Errors[0])
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	assert.NotEmpty(t, appErr.Violations)
+}
+
+func TestPatchCat(t *testing.T) {
+	schema, cats, _ := newTestSchema(t)
+
+	result := schema.Execute(context.Background(), Request{Query: `mutation { patchCat(id: 1, input: {salary: 2000}) { id } }`})
+	require.Empty(t, result.Errors)
+	assert.JSONEq(t, `{"salary": 2000}`, cats.patch)
+}
+
+func TestQueryDepthIsLimited(t *testing.T) {
+	schema, _, missions := newTestSchema(t)
+
+	result := schema.Execute(context.Background(), Request{Query: `
+		{ cats { cats { missions { cat { ...deeper } } } } }
+		fragment deeper on Cat { missions { cat { missions { cat { name } } } } }
+	`})
+	require.Len(t, result.Errors, 1)
+	assert.Nil(t, AppError(result.Errors[0]))
+	assert.Nil(t, result.Data)
+	assert.Empty(t, missions.batches)
+
+	// introspection is not limited
+	result = schema.Execute(context.Background(), Request{Query: `{ __schema { types { fields { type { ofType { ofType { ofType { ofType { name } } } } } } } } }`})
+	assert.Empty(t, result.Errors)
+}
+
+func TestAppError(t *testing.T) {
+	schema, _, _ := newTestSchema(t)
+
+	result := schema.Execute(context.Background(), Request{Query: `{ cat(id: 42) { name } }`})
+	require.Len(t, result.Errors, 1)
+	appErr := AppError(result.Errors[0])
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	// errors of the query itself are not caused by services
+	result = schema.Execute(context.Background(), Request{Query: `{ cat(id: 42) { nickname } }`})
+	require.Len(t, result.Errors, 1)
+	assert.Nil(t, AppError(result.Errors[0]))
+}
+
+func decodeData(t *testing.T, data any, v any) {
+	raw, err := json.Marshal(data)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, v))
+}
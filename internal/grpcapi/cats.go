@@ -0,0 +1,264 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/services"
+	spycatv1 "github.com/4oBuko/spy-cat-agency/pkg/pb/spycat/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type catServer struct {
+	spycatv1.UnimplementedCatServiceServer
+	catService services.CatService
+}
+
+func (c *catServer) AddCat(ctx context.Context, req *spycatv1.AddCatRequest) (*spycatv1.Cat, error) {
+	in := req.GetCat()
+	cat := models.Cat{
+		Name:              in.GetName(),
+		YearsOfExperience: int(in.GetYearsOfExperience()),
+		Breed:             in.GetBreed(),
+		Salary:            int(in.GetSalary()),
+		Status:            in.GetStatus(),
+		HandlerId:         in.GetHandlerId(),
+	}
+	if err := validate(cat); err != nil {
+		return nil, err
+	}
+	added, err := c.catService.Add(ctx, cat)
+	if err != nil {
+		return nil, err
+	}
+	return toCat(added), nil
+}
+
+func (c *catServer) GetCat(ctx context.Context, req *spycatv1.GetCatRequest) (*spycatv1.Cat, error) {
+	cat, err := c.catService.GetById(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return toCat(cat), nil
+}
+
+func (c *catServer) BatchGetCats(ctx context.Context, req *spycatv1.BatchGetCatsRequest) (*spycatv1.BatchGetCatsResponse, error) {
+	cats, err := c.catService.GetByIds(ctx, req.GetIds())
+	if err != nil {
+		return nil, err
+	}
+	resp := &spycatv1.BatchGetCatsResponse{}
+	for _, id := range req.GetIds() {
+		if cat, ok := cats[id]; ok {
+			resp.Cats = append(resp.Cats, toCat(cat))
+		}
+	}
+	return resp, nil
+}
+
+func (c *catServer) ListCats(ctx context.Context, req *spycatv1.ListCatsRequest) (*spycatv1.ListCatsResponse, error) {
+	query := models.CatQuery{
+		PaginationQuery: models.PaginationQuery{Page: int(req.GetPage()), Size: int(req.GetSize())},
+		Skill:           req.GetSkill(),
+		Status:          req.GetStatus(),
+		HandlerId:       req.GetHandlerId(),
+	}
+	if err := validate(query); err != nil {
+		return nil, err
+	}
+	page, err := c.catService.GetAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	resp := &spycatv1.ListCatsResponse{Meta: toPagination(page.Meta)}
+	for _, cat := range page.Cats {
+		resp.Cats = append(resp.Cats, toCat(cat))
+	}
+	return resp, nil
+}
+
+func (c *catServer) UpdateCatSalary(ctx context.Context, req *spycatv1.UpdateCatSalaryRequest) (*spycatv1.Cat, error) {
+	update := models.CatUpdate{
+		Salary:        int(req.GetSalary()),
+		EffectiveFrom: fromTimestamp(req.GetEffectiveFrom()),
+		Reason:        req.GetReason(),
+	}
+	if err := validate(update); err != nil {
+		return nil, err
+	}
+	cat, err := c.catService.Update(ctx, req.GetId(), update)
+	if err != nil {
+		return nil, err
+	}
+	return toCat(cat), nil
+}
+
+func (c *catServer) UpdateCatStatus(ctx context.Context, req *spycatv1.UpdateCatStatusRequest) (*spycatv1.Cat, error) {
+	update := models.StatusUpdate{
+		Status:        req.GetStatus(),
+		EffectiveFrom: fromTimestamp(req.GetEffectiveFrom()),
+		Reason:        req.GetReason(),
+	}
+	if err := validate(update); err != nil {
+		return nil, err
+	}
+	cat, err := c.catService.UpdateStatus(ctx, req.GetId(), update)
+	if err != nil {
+		return nil, err
+	}
+	return toCat(cat), nil
+}
+
+func (c *catServer) PatchCat(ctx context.Context, req *spycatv1.PatchCatRequest) (*spycatv1.Cat, error) {
+	fields := make(map[string]any)
+	if req.Name != nil {
+		fields["name"] = req.GetName()
+	}
+	if req.YearsOfExperience != nil {
+		fields["yearsOfExperience"] = req.GetYearsOfExperience()
+	}
+	if req.Breed != nil {
+		fields["breed"] = req.GetBreed()
+	}
+	if req.Salary != nil {
+		fields["salary"] = req.GetSalary()
+	}
+	patch, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	cat, err := c.catService.Patch(ctx, req.GetId(), patch)
+	if err != nil {
+		return nil, err
+	}
+	return toCat(cat), nil
+}
+
+func (c *catServer) DeleteCat(ctx context.Context, req *spycatv1.DeleteCatRequest) (*emptypb.Empty, error) {
+	if err := c.catService.DeleteById(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (c *catServer) GetSalaryHistory(ctx context.Context, req *spycatv1.GetSalaryHistoryRequest) (*spycatv1.GetSalaryHistoryResponse, error) {
+	changes, err := c.catService.GetSalaryHistory(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &spycatv1.GetSalaryHistoryResponse{}
+	for _, change := range changes {
+		resp.Changes = append(resp.Changes, &spycatv1.SalaryChange{
+			Id:            change.Id,
+			CatId:         change.CatId,
+			Salary:        int64(change.Salary),
+			EffectiveFrom: timestamppb.New(change.EffectiveFrom),
+			Reason:        change.Reason,
+			CreatedAt:     timestamppb.New(change.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+func (c *catServer) GetStatusHistory(ctx context.Context, req *spycatv1.GetStatusHistoryRequest) (*spycatv1.GetStatusHistoryResponse, error) {
+	changes, err := c.catService.GetStatusHistory(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &spycatv1.GetStatusHistoryResponse{}
+	for _, change := range changes {
+		resp.Changes = append(resp.Changes, &spycatv1.StatusChange{
+			Id:            change.Id,
+			CatId:         change.CatId,
+			Status:        change.Status,
+			EffectiveFrom: timestamppb.New(change.EffectiveFrom),
+			Reason:        change.Reason,
+			CreatedAt:     timestamppb.New(change.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+func (c *catServer) GetPromotions(ctx context.Context, req *spycatv1.GetPromotionsRequest) (*spycatv1.GetPromotionsResponse, error) {
+	promotions, err := c.catService.GetPromotions(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &spycatv1.GetPromotionsResponse{}
+	for _, promotion := range promotions {
+		resp.Promotions = append(resp.Promotions, &spycatv1.Promotion{
+			Id:          promotion.Id,
+			CatId:       promotion.CatId,
+			FromRank:    promotion.FromRank,
+			ToRank:      promotion.ToRank,
+			SalaryRaise: int64(promotion.SalaryRaise),
+			PromotedAt:  timestamppb.New(promotion.PromotedAt),
+		})
+	}
+	return resp, nil
+}
+
+func (c *catServer) ListRanks(ctx context.Context, req *spycatv1.ListRanksRequest) (*spycatv1.ListRanksResponse, error) {
+	resp := &spycatv1.ListRanksResponse{}
+	for _, rank := range c.catService.GetRanks(ctx) {
+		resp.Ranks = append(resp.Ranks, &spycatv1.Rank{
+			Name:                 rank.Name,
+			MinExperience:        int32(rank.MinExperience),
+			MinCompletedMissions: int32(rank.MinCompletedMissions),
+			SalaryRaise:          int64(rank.SalaryRaise),
+		})
+	}
+	return resp, nil
+}
+
+func toCat(cat models.Cat) *spycatv1.Cat {
+	result := &spycatv1.Cat{
+		Id:                cat.Id,
+		Name:              cat.Name,
+		YearsOfExperience: int32(cat.YearsOfExperience),
+		Breed:             cat.Breed,
+		Salary:            int64(cat.Salary),
+		Status:            cat.Status,
+		Rank:              cat.Rank,
+		HandlerId:         cat.HandlerId,
+		PhotoUrl:          cat.PhotoUrl,
+	}
+	if cat.BreedDetails != nil {
+		result.BreedDetails = &spycatv1.BreedDetails{
+			Name:        cat.BreedDetails.Name,
+			Origin:      cat.BreedDetails.Origin,
+			Temperament: cat.BreedDetails.Temperament,
+			LifeSpan:    cat.BreedDetails.LifeSpan,
+		}
+	}
+	return result
+}
+
+func toPagination(meta models.Pagination) *spycatv1.Pagination {
+	return &spycatv1.Pagination{
+		PageSize:   int32(meta.PageSize),
+		Page:       int32(meta.Page),
+		Total:      int32(meta.Total),
+		TotalPages: int32(meta.TotalPages),
+	}
+}
+
+// fromTimestamp returns nil when the timestamp is not set
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// toTimestamp leaves the timestamp unset when the time is nil
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
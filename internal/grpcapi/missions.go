@@ -0,0 +1,223 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/services"
+	spycatv1 "github.com/4oBuko/spy-cat-agency/pkg/pb/spycat/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type missionServer struct {
+	spycatv1.UnimplementedMissionServiceServer
+	missionService services.MissionService
+}
+
+func (m *missionServer) AddMission(ctx context.Context, req *spycatv1.AddMissionRequest) (*spycatv1.Mission, error) {
+	in := req.GetMission()
+	mission := models.Mission{
+		Type:     in.GetType(),
+		StartsAt: fromTimestamp(in.GetStartsAt()),
+		EndsAt:   fromTimestamp(in.GetEndsAt()),
+	}
+	if in.Bonus != nil {
+		bonus := int(in.GetBonus())
+		mission.Bonus = &bonus
+	}
+	for _, target := range in.GetTargets() {
+		mission.Targets = append(mission.Targets, fromTarget(target))
+	}
+	if err := validate(mission); err != nil {
+		return nil, err
+	}
+	added, err := m.missionService.Add(ctx, mission)
+	if err != nil {
+		return nil, err
+	}
+	return toMission(added), nil
+}
+
+func (m *missionServer) AddMissionFromTemplate(ctx context.Context, req *spycatv1.AddMissionFromTemplateRequest) (*spycatv1.Mission, error) {
+	mission, err := m.missionService.AddFromTemplate(ctx, req.GetTemplateId())
+	if err != nil {
+		return nil, err
+	}
+	return toMission(mission), nil
+}
+
+func (m *missionServer) CloneMission(ctx context.Context, req *spycatv1.CloneMissionRequest) (*spycatv1.Mission, error) {
+	mission, err := m.missionService.Clone(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return toMission(mission), nil
+}
+
+func (m *missionServer) GetMission(ctx context.Context, req *spycatv1.GetMissionRequest) (*spycatv1.Mission, error) {
+	mission, err := m.missionService.GetById(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return toMission(mission), nil
+}
+
+func (m *missionServer) ListMissions(ctx context.Context, req *spycatv1.ListMissionsRequest) (*spycatv1.ListMissionsResponse, error) {
+	query := models.PaginationQuery{Page: int(req.GetPage()), Size: int(req.GetSize())}
+	if err := validate(query); err != nil {
+		return nil, err
+	}
+	page, err := m.missionService.GetAll(ctx, query, models.MissionFilter{HandlerId: req.GetHandlerId()})
+	if err != nil {
+		return nil, err
+	}
+	return toMissionPage(page), nil
+}
+
+func (m *missionServer) ListCatMissions(ctx context.Context, req *spycatv1.ListCatMissionsRequest) (*spycatv1.ListMissionsResponse, error) {
+	query := models.CatMissionQuery{
+		PaginationQuery: models.PaginationQuery{Page: int(req.GetPage()), Size: int(req.GetSize())},
+		Status:          req.GetStatus(),
+		From:            fromTimestamp(req.GetFrom()),
+		To:              fromTimestamp(req.GetTo()),
+	}
+	if err := validate(query); err != nil {
+		return nil, err
+	}
+	page, err := m.missionService.GetByCatId(ctx, req.GetCatId(), query)
+	if err != nil {
+		return nil, err
+	}
+	return toMissionPage(page), nil
+}
+
+func (m *missionServer) AssignMission(ctx context.Context, req *spycatv1.AssignMissionRequest) (*spycatv1.AssignMissionResponse, error) {
+	result, err := m.missionService.Assign(ctx, req.GetMissionId(), req.GetCatId(), req.GetOverride())
+	if err != nil {
+		return nil, err
+	}
+	return &spycatv1.AssignMissionResponse{Warnings: result.Warnings}, nil
+}
+
+func (m *missionServer) CompleteMission(ctx context.Context, req *spycatv1.CompleteMissionRequest) (*spycatv1.Mission, error) {
+	mission, err := m.missionService.Complete(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return toMission(mission), nil
+}
+
+func (m *missionServer) DeleteMission(ctx context.Context, req *spycatv1.DeleteMissionRequest) (*emptypb.Empty, error) {
+	if err := m.missionService.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (m *missionServer) AddTarget(ctx context.Context, req *spycatv1.AddTargetRequest) (*spycatv1.Mission, error) {
+	target := fromTarget(req.GetTarget())
+	if err := validate(target); err != nil {
+		return nil, err
+	}
+	mission, err := m.missionService.AddTarget(ctx, req.GetMissionId(), target)
+	if err != nil {
+		return nil, err
+	}
+	return toMission(mission), nil
+}
+
+func (m *missionServer) UpdateTarget(ctx context.Context, req *spycatv1.UpdateTargetRequest) (*spycatv1.Target, error) {
+	target, err := m.missionService.UpdateTarget(ctx, req.GetMissionId(), req.GetTargetId(), models.TargetUpdate{Notes: req.GetNotes()})
+	if err != nil {
+		return nil, err
+	}
+	return toTarget(target), nil
+}
+
+func (m *missionServer) CompleteTarget(ctx context.Context, req *spycatv1.CompleteTargetRequest) (*emptypb.Empty, error) {
+	if err := m.missionService.CompleteTarget(ctx, req.GetMissionId(), req.GetTargetId()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (m *missionServer) DeleteTarget(ctx context.Context, req *spycatv1.DeleteTargetRequest) (*emptypb.Empty, error) {
+	if err := m.missionService.DeleteTarget(ctx, req.GetMissionId(), req.GetTargetId()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (m *missionServer) ListMissionTypes(ctx context.Context, req *spycatv1.ListMissionTypesRequest) (*spycatv1.ListMissionTypesResponse, error) {
+	resp := &spycatv1.ListMissionTypesResponse{}
+	for _, missionType := range m.missionService.GetTypes(ctx) {
+		resp.Types = append(resp.Types, &spycatv1.MissionType{
+			Name:          missionType.Name,
+			MinTargets:    int32(missionType.MinTargets),
+			MaxTargets:    int32(missionType.MaxTargets),
+			MinExperience: int32(missionType.MinExperience),
+			SalaryBonus:   int64(missionType.SalaryBonus),
+		})
+	}
+	return resp, nil
+}
+
+func toMission(mission models.Mission) *spycatv1.Mission {
+	result := &spycatv1.Mission{
+		Id:        mission.Id,
+		CatId:     mission.CatId,
+		Type:      mission.Type,
+		StartsAt:  toTimestamp(mission.StartsAt),
+		EndsAt:    toTimestamp(mission.EndsAt),
+		Completed: mission.Completed,
+	}
+	if mission.Bonus != nil {
+		bonus := int64(*mission.Bonus)
+		result.Bonus = &bonus
+	}
+	for _, target := range mission.Targets {
+		result.Targets = append(result.Targets, toTarget(target))
+	}
+	return result
+}
+
+func toMissionPage(page models.PaginatedMissions) *spycatv1.ListMissionsResponse {
+	resp := &spycatv1.ListMissionsResponse{Meta: toPagination(page.Meta)}
+	for _, mission := range page.Missions {
+		resp.Missions = append(resp.Missions, toMission(mission))
+	}
+	return resp
+}
+
+func toTarget(target models.Target) *spycatv1.Target {
+	result := &spycatv1.Target{
+		Id:          target.Id,
+		Name:        target.Name,
+		Country:     target.Country,
+		CountryCode: target.CountryCode,
+		Notes:       target.Notes,
+		Completed:   target.Completed,
+	}
+	for _, skill := range target.RequiredSkills {
+		result.RequiredSkills = append(result.RequiredSkills, &spycatv1.SkillRequirement{
+			Skill:          skill.Skill,
+			MinProficiency: int32(skill.MinProficiency),
+		})
+	}
+	return result
+}
+
+func fromTarget(target *spycatv1.Target) models.Target {
+	result := models.Target{
+		Name:    target.GetName(),
+		Country: target.GetCountry(),
+		Notes:   target.GetNotes(),
+	}
+	for _, skill := range target.GetRequiredSkills() {
+		result.RequiredSkills = append(result.RequiredSkills, models.SkillRequirement{
+			Skill:          skill.GetSkill(),
+			MinProficiency: int(skill.GetMinProficiency()),
+		})
+	}
+	return result
+}
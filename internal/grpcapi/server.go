@@ -0,0 +1,156 @@
+// Package grpcapi serves cats and missions over gRPC with the same services as the REST API.
+// Services are defined in proto/spycat/v1
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/services"
+	spycatv1 "github.com/4oBuko/spy-cat-agency/pkg/pb/spycat/v1"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is domain of google.rpc.ErrorInfo details of errors
+const ErrorDomain = "spy-cat-agency"
+
+type Server struct {
+	grpcServer *grpc.Server
+	addr       string
+}
+
+func NewServer(addr string, catService services.CatService, missionService services.MissionService) *Server {
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(errorInterceptor, recoveryInterceptor))
+	spycatv1.RegisterCatServiceServer(grpcServer, &catServer{catService: catService})
+	spycatv1.RegisterMissionServiceServer(grpcServer, &missionServer{missionService: missionService})
+	reflection.Register(grpcServer)
+	return &Server{grpcServer: grpcServer, addr: addr}
+}
+
+func (s *Server) Run() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve accepts connections on the listener until the server is shut down
+func (s *Server) Serve(listener net.Listener) error {
+	return s.grpcServer.Serve(listener)
+}
+
+// Shutdown stops accepting connections and waits for active calls to finish.
+// Calls that are still active when ctx is done are cancelled
+func (s *Server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
+
+// errorInterceptor converts errors of services to gRPC statuses
+func errorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(err, info.FullMethod)
+	}
+	return resp, nil
+}
+
+// recoveryInterceptor turns panics of handlers into internal errors, so a failing call doesn't stop the server.
+// It runs inside errorInterceptor that logs the panic with a correlation id
+func recoveryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			resp, err = nil, myerrors.NewServerError(fmt.Sprintf("panic: %v", r))
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// grpcCodes map status codes of application errors to gRPC codes
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.Aborted,
+	http.StatusRequestEntityTooLarge: codes.ResourceExhausted,
+	http.StatusUnsupportedMediaType:  codes.InvalidArgument,
+	http.StatusUnprocessableEntity:   codes.FailedPrecondition,
+	http.StatusInternalServerError:   codes.Internal,
+}
+
+// toStatus describes err like problems of the REST API. Code of the problem is reason of ErrorInfo details,
+// violations are reported as BadRequest details. Internal errors are logged and their cause is not shown
+func toStatus(err error, method string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var appErr *myerrors.AppError
+	if !errors.As(err, &appErr) {
+		appErr = myerrors.NewServerError(err.Error())
+	}
+	var correlationId string
+	if appErr.StatusCode >= http.StatusInternalServerError {
+		correlationId = myerrors.NewCorrelationId()
+		log.Printf("Internal error %s: %s", correlationId, appErr.Message)
+	}
+	problem := myerrors.NewProblem(appErr, method, correlationId)
+
+	code, ok := grpcCodes[problem.Status]
+	if !ok {
+		code = codes.Unknown
+		if problem.Status >= http.StatusInternalServerError {
+			code = codes.Internal
+		}
+	}
+	info := &errdetails.ErrorInfo{Reason: problem.Code, Domain: ErrorDomain}
+	if correlationId != "" {
+		info.Metadata = map[string]string{"correlationId": correlationId}
+	}
+	st := status.New(code, problem.Detail)
+	withInfo, err := st.WithDetails(info)
+	if err != nil {
+		return st.Err()
+	}
+	st = withInfo
+	if len(problem.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range problem.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations,
+				&errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Message, Reason: v.Rule})
+		}
+		if withViolations, err := st.WithDetails(badRequest); err == nil {
+			st = withViolations
+		}
+	}
+	return st.Err()
+}
+
+// validate checks request converted to the model like the body of REST requests
+func validate(value any) error {
+	if err := binding.Validator.ValidateStruct(value); err != nil {
+		return myerrors.NewValidationError(err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/services"
+	spycatv1 "github.com/4oBuko/spy-cat-agency/pkg/pb/spycat/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeCatService struct {
+	services.CatService
+	// started and release let tests hold GetById until they release it
+	started chan struct{}
+	release chan struct{}
+	patch   string
+}
+
+func (f *fakeCatService) GetById(ctx context.Context, id int64) (models.Cat, error) {
+	if f.started != nil {
+		f.started <- struct{}{}
+		<-f.release
+	}
+	switch id {
+	case 1:
+		return models.Cat{Id: 1, Name: "Tom", Breed: "abys", Salary: 1000, HandlerId: 7,
+			BreedDetails: &models.BreedDetails{Name: "Abyssinian"}}, nil
+	case 2:
+		return models.Cat{}, errors.New("connection refused")
+	case 3:
+		panic("nil map")
+	default:
+		return models.Cat{}, myerrors.NewNotFoundError("cat not found")
+	}
+}
+
+func (f *fakeCatService) Add(ctx context.Context, cat models.Cat) (models.Cat, error) {
+	cat.Id = 3
+	return cat, nil
+}
+
+func (f *fakeCatService) Patch(ctx context.Context, id int64, patch []byte) (models.Cat, error) {
+	f.patch = string(patch)
+	return f.GetById(ctx, id)
+}
+
+type fakeMissionService struct {
+	services.MissionService
+}
+
+func (f *fakeMissionService) GetById(ctx context.Context, id int64) (models.Mission, error) {
+	startsAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	return models.Mission{Id: id, Type: "standard", StartsAt: &startsAt,
+		Targets: []models.Target{{Id: 5, Name: "Jerry", Country: "Spain", RequiredSkills: []models.SkillRequirement{{Skill: "stealth", MinProficiency: 3}}}}}, nil
+}
+
+func startServer(t *testing.T, catService services.CatService) (*Server, *grpc.ClientConn) {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer("", catService, &fakeMissionService{})
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		server.Shutdown(context.Background())
+	})
+	return server, conn
+}
+
+func errorInfo(t *testing.T, err error) (*status.Status, *errdetails.ErrorInfo, *errdetails.BadRequest) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	var info *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+	require.NotNil(t, info)
+	assert.Equal(t, ErrorDomain, info.Domain)
+	return st, info, badRequest
+}
+
+func TestServer(t *testing.T) {
+	catService := &fakeCatService{}
+	_, conn := startServer(t, catService)
+	cats := spycatv1.NewCatServiceClient(conn)
+	missions := spycatv1.NewMissionServiceClient(conn)
+	ctx := context.Background()
+
+	t.Run("get cat", func(t *testing.T) {
+		cat, err := cats.GetCat(ctx, &spycatv1.GetCatRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "Tom", cat.Name)
+		assert.Equal(t, int64(7), cat.HandlerId)
+		assert.Equal(t, "Abyssinian", cat.BreedDetails.Name)
+	})
+
+	t.Run("get mission", func(t *testing.T) {
+		mission, err := missions.GetMission(ctx, &spycatv1.GetMissionRequest{Id: 4})
+		require.NoError(t, err)
+		assert.Equal(t, "2025-03-01T00:00:00Z", mission.StartsAt.AsTime().Format(time.RFC3339))
+		assert.Nil(t, mission.EndsAt)
+		require.Len(t, mission.Targets, 1)
+		assert.Equal(t, int32(3), mission.Targets[0].RequiredSkills[0].MinProficiency)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := cats.GetCat(ctx, &spycatv1.GetCatRequest{Id: 42})
+		st, info, _ := errorInfo(t, err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "cat not found", st.Message())
+		assert.Equal(t, myerrors.CodeNotFound, info.Reason)
+	})
+
+	t.Run("validation errors are reported per field", func(t *testing.T) {
+		_, err := cats.AddCat(ctx, &spycatv1.AddCatRequest{Cat: &spycatv1.Cat{Name: "Tom", Breed: "abys", Salary: 100, Status: "sleeping"}})
+		st, info, badRequest := errorInfo(t, err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Equal(t, myerrors.CodeValidationFailed, info.Reason)
+		require.NotNil(t, badRequest)
+		var fields []string
+		for _, v := range badRequest.FieldViolations {
+			fields = append(fields, v.Field)
+		}
+		assert.ElementsMatch(t, []string{"yearsOfExperience", "status"}, fields)
+	})
+
+	t.Run("internal errors hide their cause", func(t *testing.T) {
+		_, err := cats.GetCat(ctx, &spycatv1.GetCatRequest{Id: 2})
+		st, info, _ := errorInfo(t, err)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.NotContains(t, st.Message(), "connection refused")
+		assert.Contains(t, st.Message(), info.Metadata["correlationId"])
+	})
+
+	t.Run("patch sends only set fields", func(t *testing.T) {
+		salary := int64(2000)
+		cat, err := cats.PatchCat(ctx, &spycatv1.PatchCatRequest{Id: 1, Salary: &salary})
+		require.NoError(t, err)
+		assert.Equal(t, "Tom", cat.Name)
+		assert.JSONEq(t, `{"salary": 2000}`, catService.patch)
+	})
+
+	t.Run("panics are internal errors", func(t *testing.T) {
+		_, err := cats.GetCat(ctx, &spycatv1.GetCatRequest{Id: 3})
+		st, info, _ := errorInfo(t, err)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.NotContains(t, st.Message(), "nil map")
+		assert.NotEmpty(t, info.Metadata["correlationId"])
+
+		_, err = cats.GetCat(ctx, &spycatv1.GetCatRequest{Id: 1})
+		assert.NoError(t, err)
+	})
+}
+
+func TestShutdownWaitsForActiveCalls(t *testing.T) {
+	catService := &fakeCatService{started: make(chan struct{}), release: make(chan struct{})}
+	server, conn := startServer(t, catService)
+	cats := spycatv1.NewCatServiceClient(conn)
+
+	result := make(chan error)
+	go func() {
+		_, err := cats.GetCat(context.Background(), &spycatv1.GetCatRequest{Id: 1})
+		result <- err
+	}()
+	<-catService.started
+
+	shutdown := make(chan error)
+	go func() {
+		shutdown <- server.Shutdown(context.Background())
+	}()
+	select {
+	case <-shutdown:
+		t.Fatal("shutdown finished before the active call")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(catService.release)
+	assert.NoError(t, <-result)
+	assert.NoError(t, <-shutdown)
+}
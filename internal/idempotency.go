@@ -0,0 +1,132 @@
+package spycatagency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyCleanupTimeout = time.Minute
+)
+
+// Idempotency replays stored response when POST request is retried with the same Idempotency-Key header.
+// Reusing a key for a different request is rejected. Responses with server errors are not stored, so such
+// requests can be retried. It must run before ErrorHandler to store error responses too
+func Idempotency(repo repositories.IdempotencyRepository, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.Abort()
+			writeProblem(c, myerrors.NewBadRequestError("idempotency key must not be longer than 255 characters"))
+			return
+		}
+		body, err := readBody(c)
+		if err != nil {
+			c.Abort()
+			writeProblem(c, err)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record := models.IdempotencyRecord{
+			Key:         key,
+			Fingerprint: requestFingerprint(c.Request, body),
+			ExpiresAt:   time.Now().UTC().Add(ttl),
+		}
+		existing, reserved, err := repo.Reserve(c, record)
+		if err != nil {
+			c.Abort()
+			writeProblem(c, myerrors.NewServerError(err.Error()))
+			return
+		}
+		if !reserved {
+			c.Abort()
+			switch {
+			case existing.Fingerprint != record.Fingerprint:
+				writeProblem(c, myerrors.NewUnprocessableEntityError("idempotency key was used for a different request").
+					WithCode(myerrors.CodeIdempotencyKeyReused))
+			case !existing.Completed:
+				writeProblem(c, myerrors.NewConflictError("request with the same idempotency key is in progress").
+					WithCode(myerrors.CodeIdempotencyKeyInUse))
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+			}
+			return
+		}
+
+		// response must be saved even when client doesn't wait for it anymore
+		ctx := context.WithoutCancel(c.Request.Context())
+		completed := false
+		defer func() {
+			if !completed {
+				if err := repo.Release(ctx, key); err != nil {
+					log.Printf("Failed to release idempotency key %q: %v", key, err)
+				}
+			}
+		}()
+		writer := &responseWriter{
+			ResponseWriter: c.Writer,
+			body:           &bytes.Buffer{},
+		}
+		c.Writer = writer
+		c.Next()
+
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+		record.Completed = true
+		record.StatusCode = c.Writer.Status()
+		record.ContentType = c.Writer.Header().Get("Content-Type")
+		record.Body = writer.body.Bytes()
+		if err := repo.Complete(ctx, record); err != nil {
+			log.Printf("Failed to store response of idempotency key %q: %v", key, err)
+			return
+		}
+		completed = true
+	}
+}
+
+// requestFingerprint identifies method, path with query and body of the request
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// DeleteExpiredIdempotencyKeys deletes expired keys every interval until ctx is canceled
+func DeleteExpiredIdempotencyKeys(ctx context.Context, repo repositories.IdempotencyRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cleanupCtx, cancel := context.WithTimeout(ctx, idempotencyCleanupTimeout)
+			if _, err := repo.DeleteExpired(cleanupCtx); err != nil {
+				log.Printf("Failed to delete expired idempotency keys: %v", err)
+			}
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Absence is a period when the cat can't be assigned to missions. The period includes From and excludes To
+type Absence struct {
+	Id    int64     `json:"id" db:"id"`
+	CatId int64     `json:"catId" db:"cat_id"`
+	Kind  string    `json:"kind" db:"kind" binding:"required,oneof=leave training medical"`
+	From  time.Time `json:"from" db:"starts_at" binding:"required"`
+	To    time.Time `json:"to" db:"ends_at" binding:"required"`
+	Note  string    `json:"note" db:"note" binding:"max=255"`
+}
+
+type AvailabilityQuery struct {
+	From time.Time `form:"from" time_format:"2006-01-02" binding:"required"`
+	To   time.Time `form:"to" time_format:"2006-01-02" binding:"required"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "encoding/json"
+
+// BatchRequest is an ordered list of operations that run in a single transaction
+type BatchRequest struct {
+	Operations []BatchOperation `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+// BatchOperation is a request to a route of the same API version as the batch. Operations with Ref can be
+// referred to by later operations: "$ref" path segments and body values are replaced with id of the result
+type BatchOperation struct {
+	Ref    string          `json:"ref" binding:"omitempty,alphanum,max=50"`
+	Method string          `json:"method" binding:"required,oneof=GET POST PUT PATCH DELETE"`
+	Path   string          `json:"path" binding:"required,startswith=/"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// BatchResult is the response to a batch operation
+type BatchResult struct {
+	Ref    string          `json:"ref,omitempty"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
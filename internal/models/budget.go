@@ -0,0 +1,23 @@
+package models
+
+// Budget shows monthly payroll committed to cats against approved budgets.
+// Limit and Remaining are omitted when there is no cap
+type Budget struct {
+	Limit     *int            `json:"limit,omitempty"`
+	Used      int             `json:"used"`
+	Remaining *int            `json:"remaining,omitempty"`
+	Handlers  []HandlerBudget `json:"handlers"`
+}
+
+type HandlerBudget struct {
+	HandlerId int64  `json:"handlerId"`
+	Name      string `json:"name"`
+	Limit     *int   `json:"limit,omitempty"`
+	Used      int    `json:"used"`
+	Remaining *int   `json:"remaining,omitempty"`
+}
+
+type HandlerBudgetUpdate struct {
+	// Budget removes the cap of the handler when null
+	Budget *int `json:"budget" binding:"omitempty,gte=0"`
+}
@@ -1,13 +1,43 @@
 package models
 
+import "time"
+
 type Cat struct {
 	Id                int64  `json:"id" db:"id"`
 	Name              string `json:"name" db:"cat_name" binding:"required,min=1,max=50"`
 	YearsOfExperience int    `json:"yearsOfExperience" db:"years_of_experience" binding:"required,gte=0"`
 	Breed             string `json:"breed" db:"breed" binding:"required,max=120"`
 	Salary            int    `json:"salary" db:"salary" binding:"required,gte=0"`
+	Status            string `json:"status" db:"status" binding:"omitempty,oneof=recruit active suspended retired deceased"`
+	Rank              string `json:"rank" db:"cat_rank"`
+	HandlerId         int64  `json:"handlerId,omitempty" db:"handler_id"`
+	PhotoUrl          string `json:"photoUrl,omitempty" db:"photo_url"`
+	// BreedDetails are resolved from the breed id. Cats added before details were stored may have none
+	BreedDetails *BreedDetails `json:"breedDetails,omitempty"`
+}
+
+type BreedDetails struct {
+	Name        string `json:"name" db:"breed_name"`
+	Origin      string `json:"origin" db:"breed_origin"`
+	Temperament string `json:"temperament" db:"breed_temperament"`
+	LifeSpan    string `json:"lifeSpan" db:"breed_life_span"`
 }
 
 type CatUpdate struct {
-	Salary int `json:"salary" binding:"required,gte=0"`
+	Salary        int        `json:"salary" binding:"required,gte=0"`
+	EffectiveFrom *time.Time `json:"effectiveFrom"`
+	Reason        string     `json:"reason" binding:"max=255"`
+}
+
+type CatQuery struct {
+	PaginationQuery
+	Skill     string `form:"skill"`
+	Status    string `form:"status" binding:"omitempty,oneof=recruit active suspended retired deceased"`
+	HandlerId int64  `form:"handler"`
+}
+
+type CatFilter struct {
+	Skill     string
+	Status    string
+	HandlerId int64
 }
@@ -0,0 +1,36 @@
+package models
+
+import "github.com/4oBuko/spy-cat-agency/internal/myerrors"
+
+// Formats of imported files
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+)
+
+// Import modes. All-or-nothing adds cats only when every row is valid, best-effort adds all valid rows
+const (
+	ImportModeAllOrNothing = "all-or-nothing"
+	ImportModeBestEffort   = "best-effort"
+)
+
+type CatImportQuery struct {
+	Mode string `form:"mode" binding:"omitempty,oneof=all-or-nothing best-effort"`
+}
+
+type CatImportReport struct {
+	Mode    string         `json:"mode"`
+	Created int            `json:"created"`
+	Failed  int            `json:"failed"`
+	Rows    []CatImportRow `json:"rows"`
+}
+
+// CatImportRow is result of a single row. Rows have either id of the created cat or an error. Valid rows of a failed
+// all-or-nothing import have neither
+type CatImportRow struct {
+	// Line of the row in the imported file
+	Line       int                  `json:"line"`
+	Id         int64                `json:"id,omitempty"`
+	Error      string               `json:"error,omitempty"`
+	Violations []myerrors.Violation `json:"violations,omitempty"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Formats of exported files
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatXLSX   = "xlsx"
+)
+
+// CatExportQuery has the same filters as the list of cats. Cats are exported without pagination
+type CatExportQuery struct {
+	Format    string `form:"format" binding:"omitempty,oneof=csv ndjson xlsx"`
+	Skill     string `form:"skill"`
+	Status    string `form:"status" binding:"omitempty,oneof=recruit active suspended retired deceased"`
+	HandlerId int64  `form:"handler"`
+}
+
+// MissionExportQuery has the same filters as the list of missions. Missions are exported without pagination
+type MissionExportQuery struct {
+	Format    string `form:"format" binding:"omitempty,oneof=csv ndjson xlsx"`
+	HandlerId int64  `form:"handler"`
+}
+
+// MissionExportRow is a target flattened together with its mission. Missions are exported as a row per target
+type MissionExportRow struct {
+	MissionId       int64      `json:"missionId"`
+	CatId           int64      `json:"catId,omitempty"`
+	Type            string     `json:"type"`
+	StartsAt        *time.Time `json:"startsAt,omitempty"`
+	EndsAt          *time.Time `json:"endsAt,omitempty"`
+	Bonus           int        `json:"bonus"`
+	Completed       bool       `json:"completed"`
+	TargetId        int64      `json:"targetId"`
+	TargetName      string     `json:"targetName"`
+	Country         string     `json:"country"`
+	CountryCode     string     `json:"countryCode"`
+	Notes           string     `json:"notes"`
+	TargetCompleted bool       `json:"targetCompleted"`
+}
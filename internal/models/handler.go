@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Handler is a human who recruits and runs cats
+type Handler struct {
+	Id    int64  `json:"id" db:"id"`
+	Name  string `json:"name" db:"handler_name" binding:"required,min=1,max=100"`
+	Email string `json:"email" db:"email" binding:"omitempty,email,max=255"`
+	// Budget caps monthly payroll of cats run by the handler. Nil means no cap
+	Budget *int `json:"budget,omitempty" db:"budget" binding:"omitempty,gte=0"`
+}
+
+type CatTransfer struct {
+	HandlerId int64 `json:"handlerId" binding:"required"`
+}
+
+// Operation is an open mission run by one of the handler's cats
+type Operation struct {
+	MissionId        int64      `json:"missionId"`
+	Type             string     `json:"type"`
+	CatId            int64      `json:"catId"`
+	CatName          string     `json:"catName"`
+	TotalTargets     int        `json:"totalTargets"`
+	CompletedTargets int        `json:"completedTargets"`
+	StartsAt         *time.Time `json:"startsAt,omitempty"`
+	EndsAt           *time.Time `json:"endsAt,omitempty"`
+}
+
+type HandlerDashboard struct {
+	Handler      Handler     `json:"handler"`
+	Cats         int         `json:"cats"`
+	ActiveCats   int         `json:"activeCats"`
+	BusyCats     int         `json:"busyCats"`
+	OpenMissions int         `json:"openMissions"`
+	OpenTargets  int         `json:"openTargets"`
+	Operations   []Operation `json:"operations"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// IdempotencyRecord is a response stored by Idempotency-Key of the request that produced it
+type IdempotencyRecord struct {
+	Key string
+	// Fingerprint identifies method, path and body of the request
+	Fingerprint string
+	// Completed is false while the first request with the key is processed
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+const (
+	LedgerSalary     = "salary"
+	LedgerBonus      = "bonus"
+	LedgerAdjustment = "adjustment"
+)
+
+// LedgerEntry is a single compensation posting of the cat. Balance is the running total including the entry
+type LedgerEntry struct {
+	Id          int64     `json:"id" db:"id"`
+	CatId       int64     `json:"catId" db:"cat_id"`
+	Kind        string    `json:"kind" db:"kind" binding:"required,oneof=salary adjustment"`
+	Amount      int       `json:"amount" db:"amount" binding:"required"`
+	MissionId   int64     `json:"missionId,omitempty" db:"mission_id"`
+	Description string    `json:"description" db:"description" binding:"max=255"`
+	PostedAt    time.Time `json:"postedAt" db:"posted_at"`
+	Balance     int       `json:"balance"`
+}
+
+type Ledger struct {
+	CatId   int64         `json:"catId"`
+	Entries []LedgerEntry `json:"entries"`
+	Balance int           `json:"balance"`
+}
@@ -1,10 +1,41 @@
 package models
 
+import "time"
 
 type Mission struct {
-	Id        int64    `json:"id" db:"id"`
-	CatId     int64    `json:"catId" db:"cat_id"`
-	Targets   []Target `json:"targets" binding:"required,min=1,max=3"`
-	Completed bool     `json:"completed" db:"completed"`
+	Id        int64      `json:"id" db:"id"`
+	CatId     int64      `json:"catId" db:"cat_id"`
+	Type      string     `json:"type" db:"mission_type"`
+	StartsAt  *time.Time `json:"startsAt,omitempty" db:"starts_at"`
+	EndsAt    *time.Time `json:"endsAt,omitempty" db:"ends_at"`
+	Bonus     *int       `json:"bonus" db:"bonus" binding:"omitempty,gte=0"`
+	Targets   []Target   `json:"targets" binding:"required,min=1"`
+	Completed bool       `json:"completed" db:"completed"`
 }
 
+// MissionWindow is the period when the mission takes place. Mission without start date starts when a cat is assigned
+// and mission without end date lasts until it's completed
+type MissionWindow struct {
+	StartsAt *time.Time `json:"startsAt"`
+	EndsAt   *time.Time `json:"endsAt"`
+}
+
+type MissionFilter struct {
+	HandlerId int64 `form:"handler"`
+	// CatId selects history of the cat. Completed, From and To then apply to the assignments of the cat
+	CatId int64 `form:"-"`
+	// Completed filters missions by completion when set
+	Completed *bool `form:"-"`
+	// From and To are used only together with CatId
+	From *time.Time `form:"-"`
+	To   *time.Time `form:"-"`
+}
+
+// CatMissionQuery filters missions of the cat. Missions are matched by the date range
+// when the cat worked on them during the period that includes From and excludes To
+type CatMissionQuery struct {
+	PaginationQuery
+	Status string     `form:"status" binding:"omitempty,oneof=open completed"`
+	From   *time.Time `form:"from" time_format:"2006-01-02"`
+	To     *time.Time `form:"to" time_format:"2006-01-02"`
+}
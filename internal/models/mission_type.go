@@ -0,0 +1,9 @@
+package models
+
+type MissionType struct {
+	Name          string `json:"name"`
+	MinTargets    int    `json:"minTargets"`
+	MaxTargets    int    `json:"maxTargets"`
+	MinExperience int    `json:"minExperience"`
+	SalaryBonus   int    `json:"salaryBonus"`
+}
@@ -0,0 +1,12 @@
+package models
+
+type Thumbnail struct {
+	Size int    `json:"size"`
+	Url  string `json:"url"`
+}
+
+type CatPhoto struct {
+	CatId      int64       `json:"catId"`
+	PhotoUrl   string      `json:"photoUrl"`
+	Thumbnails []Thumbnail `json:"thumbnails"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Rank is a step of the promotion ladder. Cat holds the highest rank which requirements it meets.
+// SalaryRaise is added to the salary of the cat when it's promoted to the rank
+type Rank struct {
+	Name                 string `json:"name"`
+	MinExperience        int    `json:"minExperience"`
+	MinCompletedMissions int    `json:"minCompletedMissions"`
+	SalaryRaise          int    `json:"salaryRaise"`
+}
+
+// RankProgress is what rank of the cat depends on
+type RankProgress struct {
+	CatId             int64
+	YearsOfExperience int
+	CompletedMissions int
+}
+
+type Promotion struct {
+	Id          int64     `json:"id" db:"id"`
+	CatId       int64     `json:"catId" db:"cat_id"`
+	FromRank    string    `json:"fromRank" db:"from_rank"`
+	ToRank      string    `json:"toRank" db:"to_rank"`
+	SalaryRaise int       `json:"salaryRaise" db:"salary_raise"`
+	PromotedAt  time.Time `json:"promotedAt" db:"promoted_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type SalaryChange struct {
+	Id            int64     `json:"id" db:"id"`
+	CatId         int64     `json:"catId" db:"cat_id"`
+	Salary        int       `json:"salary" db:"salary"`
+	EffectiveFrom time.Time `json:"effectiveFrom" db:"effective_from"`
+	Reason        string    `json:"reason" db:"reason"`
+	CreatedAt     time.Time `json:"createdAt" db:"created_at"`
+}
+
+type PayrollQuery struct {
+	From time.Time `form:"from" time_format:"2006-01-02" binding:"required"`
+	To   time.Time `form:"to" time_format:"2006-01-02" binding:"required"`
+}
+
+type CatPayroll struct {
+	CatId  int64   `json:"catId"`
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+type Payroll struct {
+	From  string       `json:"from"`
+	To    string       `json:"to"`
+	Cats  []CatPayroll `json:"cats"`
+	Total float64      `json:"total"`
+}
@@ -0,0 +1,25 @@
+package models
+
+type Skill struct {
+	Id          int64  `json:"id" db:"id"`
+	Name        string `json:"name" db:"skill_name" binding:"required,min=1,max=50"`
+	Description string `json:"description" db:"description" binding:"max=255"`
+}
+
+type CatSkill struct {
+	Skill       string `json:"skill" binding:"required"`
+	Proficiency int    `json:"proficiency" db:"proficiency" binding:"required,min=1,max=5"`
+}
+
+type SkillRequirement struct {
+	Skill          string `json:"skill" binding:"required"`
+	MinProficiency int    `json:"minProficiency" db:"min_proficiency" binding:"omitempty,min=1,max=5"`
+}
+
+type AssignQuery struct {
+	Override bool `form:"override"`
+}
+
+type AssignResult struct {
+	Warnings []string `json:"warnings,omitempty"`
+}
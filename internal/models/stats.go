@@ -0,0 +1,20 @@
+package models
+
+// CatStats describes performance of the cat. Success rate is a share of finished missions that were completed,
+// missions are finished when they are completed or the cat is released from them.
+// Duration and salary cost are calculated only for missions completed after assignment and completion times were tracked
+type CatStats struct {
+	CatId                   int64   `json:"catId"`
+	Name                    string  `json:"name"`
+	Rank                    int     `json:"rank,omitempty"`
+	AssignedMissions        int     `json:"assignedMissions"`
+	CompletedMissions       int     `json:"completedMissions"`
+	CompletedTargets        int     `json:"completedTargets"`
+	SuccessRate             float64 `json:"successRate"`
+	AverageMissionHours     float64 `json:"averageMissionDurationHours"`
+	CostPerCompletedMission float64 `json:"salaryCostPerCompletedMission"`
+}
+
+type LeaderboardQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	CatStatusRecruit   = "recruit"
+	CatStatusActive    = "active"
+	CatStatusSuspended = "suspended"
+	CatStatusRetired   = "retired"
+	CatStatusDeceased  = "deceased"
+)
+
+type StatusChange struct {
+	Id            int64     `json:"id" db:"id"`
+	CatId         int64     `json:"catId" db:"cat_id"`
+	Status        string    `json:"status" db:"status"`
+	EffectiveFrom time.Time `json:"effectiveFrom" db:"effective_from"`
+	Reason        string    `json:"reason" db:"reason"`
+	CreatedAt     time.Time `json:"createdAt" db:"created_at"`
+}
+
+type StatusUpdate struct {
+	Status        string     `json:"status" binding:"required,oneof=recruit active suspended retired deceased"`
+	EffectiveFrom *time.Time `json:"effectiveFrom"`
+	Reason        string     `json:"reason" binding:"max=255"`
+}
@@ -0,0 +1,22 @@
+package models
+
+type MissionTemplate struct {
+	Id      int64            `json:"id" db:"id"`
+	Name    string           `json:"name" db:"template_name" binding:"required,min=1,max=100"`
+	Type    string           `json:"type" db:"mission_type"`
+	Targets []TemplateTarget `json:"targets" binding:"required,min=1,dive"`
+}
+
+type TemplateTarget struct {
+	Id          int64  `json:"id" db:"id"`
+	TemplateId  int64  `json:"-" db:"template_id"`
+	Name        string `json:"name" db:"target_name" binding:"required,min=1,max=50"`
+	Country     string `json:"country" db:"country" binding:"required,min=1,max=100"`
+	CountryCode string `json:"countryCode" db:"country_code"`
+	Notes       string `json:"notes" db:"notes" binding:"max=500"`
+}
+
+type PaginatedTemplates struct {
+	Meta      Pagination        `json:"meta"`
+	Templates []MissionTemplate `json:"templates"`
+}
@@ -5,9 +5,33 @@ import (
 	"net/http"
 )
 
+// Codes are stable identifiers of error kinds that clients can rely on
+const (
+	CodeBadRequest            = "bad_request"
+	CodeValidationFailed      = "validation_failed"
+	CodeNotFound              = "not_found"
+	CodeInternal              = "internal_error"
+	CodeRequestEntityTooLarge = "request_entity_too_large"
+	CodeUnsupportedMediaType  = "unsupported_media_type"
+	CodeConflict              = "conflict"
+	CodeUnprocessableEntity   = "unprocessable_entity"
+	CodeIdempotencyKeyInUse   = "idempotency_key_in_use"
+	CodeIdempotencyKeyReused  = "idempotency_key_reused"
+)
+
 type AppError struct {
 	Message    string
 	StatusCode int
+	Code       string
+	// Violations describe invalid fields of the request
+	Violations []Violation
+}
+
+// Violation is a single failed validation rule of a request field
+type Violation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
 }
 
 func (r *AppError) Error() string {
@@ -15,13 +39,36 @@ func (r *AppError) Error() string {
 }
 
 func NewBadRequestError(msg string) *AppError {
-	return &AppError{Message: msg, StatusCode: http.StatusBadRequest}
+	return &AppError{Message: msg, StatusCode: http.StatusBadRequest, Code: CodeBadRequest}
 }
 
 func NewNotFoundError(msg string) *AppError {
-	return &AppError{Message: msg, StatusCode: http.StatusNotFound}
+	return &AppError{Message: msg, StatusCode: http.StatusNotFound, Code: CodeNotFound}
 }
 
+// NewServerError creates error of unexpected failure. Message is logged and never shown to clients
 func NewServerError(msg string) *AppError {
-	return &AppError{Message: msg, StatusCode: http.StatusInternalServerError}
+	return &AppError{Message: msg, StatusCode: http.StatusInternalServerError, Code: CodeInternal}
+}
+
+func NewRequestEntityTooLargeError(msg string) *AppError {
+	return &AppError{Message: msg, StatusCode: http.StatusRequestEntityTooLarge, Code: CodeRequestEntityTooLarge}
+}
+
+func NewUnsupportedMediaTypeError(msg string) *AppError {
+	return &AppError{Message: msg, StatusCode: http.StatusUnsupportedMediaType, Code: CodeUnsupportedMediaType}
+}
+
+func NewConflictError(msg string) *AppError {
+	return &AppError{Message: msg, StatusCode: http.StatusConflict, Code: CodeConflict}
+}
+
+func NewUnprocessableEntityError(msg string) *AppError {
+	return &AppError{Message: msg, StatusCode: http.StatusUnprocessableEntity, Code: CodeUnprocessableEntity}
+}
+
+// WithCode replaces general code of the error with a more specific one
+func (r *AppError) WithCode(code string) *AppError {
+	r.Code = code
+	return r
 }
@@ -0,0 +1,57 @@
+package myerrors
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// ProblemContentType is media type of error responses defined by RFC 7807
+const ProblemContentType = "application/problem+json"
+
+const problemTypePrefix = "urn:spy-cat-agency:problem:"
+
+// Problem is body of error responses
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code is a stable identifier of the error kind, Type contains the same value as URI
+	Code       string      `json:"code"`
+	Violations []Violation `json:"violations,omitempty"`
+	// CorrelationId identifies internal error in server logs. Details of internal errors are not shown
+	CorrelationId string `json:"correlationId,omitempty"`
+	// Operation is index of the batch operation that failed
+	Operation *int `json:"operation,omitempty"`
+}
+
+// NewProblem describes err that happened while serving instance path. Message of internal errors is replaced with correlationId
+func NewProblem(err *AppError, instance, correlationId string) Problem {
+	code := err.Code
+	if code == "" {
+		code = CodeInternal
+	}
+	problem := Problem{
+		Type:       problemTypePrefix + code,
+		Title:      http.StatusText(err.StatusCode),
+		Status:     err.StatusCode,
+		Detail:     err.Message,
+		Instance:   instance,
+		Code:       code,
+		Violations: err.Violations,
+	}
+	if err.StatusCode >= http.StatusInternalServerError {
+		problem.Detail = "internal error, report correlation id " + correlationId + " to support"
+		problem.CorrelationId = correlationId
+	}
+	return problem
+}
+
+// NewCorrelationId generates id that links internal error reported to a client with server logs
+func NewCorrelationId() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,150 @@
+package myerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// fieldName makes violations refer to fields by their JSON or query parameter names
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// NewValidationError converts error of binding or validating request into bad request error.
+// Validation and type errors are reported as violations of separate fields
+func NewValidationError(err error) *AppError {
+	var violations []Violation
+	var validationErrors validator.ValidationErrors
+	var sliceErrors binding.SliceValidationError
+	var typeError *json.UnmarshalTypeError
+	var syntaxError *json.SyntaxError
+	switch {
+	case errors.As(err, &sliceErrors):
+		for i, elementErr := range sliceErrors {
+			if errors.As(elementErr, &validationErrors) {
+				violations = append(violations, fieldViolations(validationErrors, fmt.Sprintf("[%d]", i))...)
+			}
+		}
+	case errors.As(err, &validationErrors):
+		violations = fieldViolations(validationErrors, "")
+	case errors.As(err, &typeError):
+		violations = []Violation{{Field: typeError.Field, Rule: "type", Message: "must be " + jsonType(typeError.Type.Kind())}}
+	case errors.As(err, &syntaxError):
+		return NewBadRequestError(fmt.Sprintf("malformed JSON at offset %d", syntaxError.Offset))
+	case errors.Is(err, io.EOF):
+		return NewBadRequestError("request body is empty")
+	default:
+		return NewBadRequestError(err.Error())
+	}
+	if len(violations) == 0 {
+		return NewBadRequestError(err.Error())
+	}
+	return NewViolationsError(violations)
+}
+
+// NewViolationsError creates bad request error that lists invalid fields
+func NewViolationsError(violations []Violation) *AppError {
+	return &AppError{
+		Message:    "request has invalid fields",
+		StatusCode: http.StatusBadRequest,
+		Code:       CodeValidationFailed,
+		Violations: violations,
+	}
+}
+
+func fieldViolations(errs validator.ValidationErrors, prefix string) []Violation {
+	violations := make([]Violation, 0, len(errs))
+	for _, fieldErr := range errs {
+		// namespace starts with name of validated struct
+		field := fieldErr.Namespace()
+		if _, rest, ok := strings.Cut(field, "."); ok {
+			field = rest
+		}
+		if prefix != "" {
+			field = prefix + "." + field
+		}
+		violations = append(violations, Violation{Field: field, Rule: fieldErr.Tag(), Message: ruleMessage(fieldErr)})
+	}
+	return violations
+}
+
+func ruleMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min", "gte":
+		if isNumber(fieldErr) {
+			return "must be at least " + param
+		}
+		return "must have at least " + param + " " + elements(fieldErr)
+	case "max", "lte":
+		if isNumber(fieldErr) {
+			return "must be at most " + param
+		}
+		return "must have at most " + param + " " + elements(fieldErr)
+	case "gt":
+		return "must be greater than " + param
+	case "lt":
+		return "must be less than " + param
+	case "len":
+		return "must have exactly " + param + " " + elements(fieldErr)
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "email":
+		return "must be a valid email"
+	}
+	if param != "" {
+		return fmt.Sprintf("must satisfy %s=%s", fieldErr.Tag(), param)
+	}
+	return "must satisfy " + fieldErr.Tag()
+}
+
+func isNumber(fieldErr validator.FieldError) bool {
+	switch fieldErr.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return false
+	}
+	return true
+}
+
+func elements(fieldErr validator.FieldError) string {
+	if fieldErr.Kind() == reflect.String {
+		return "characters"
+	}
+	return "elements"
+}
+
+func jsonType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	}
+	return "a number"
+}
@@ -0,0 +1,199 @@
+package spycatagency
+
+import (
+	"net/http"
+
+	"github.com/4oBuko/spy-cat-agency/internal/graph"
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/pkg/mergepatch"
+	"github.com/4oBuko/spy-cat-agency/pkg/openapi"
+	"github.com/4oBuko/spy-cat-agency/pkg/xlsx"
+)
+
+var apiInfo = openapi.Info{
+	Title:       "Spy Cat Agency API",
+	Version:     "1.0.0",
+	Description: "Management of spy cats, their handlers, missions and payroll",
+}
+
+var apiErrorResponse = openapi.ErrorResponse{Body: myerrors.Problem{}, ContentType: myerrors.ProblemContentType}
+
+const exportResponseType = "text/csv, application/x-ndjson, " + xlsx.ContentType
+
+// operations documents routes registered by registerRoutes, paths are without version prefix.
+// TestOpenAPICoversRoutes fails when a route is missing
+var operations = []openapi.Operation{
+	{Name: "CatCreate", Method: http.MethodPost, Path: Endpoints.CatCreate, Tag: "cats", Summary: "Create a cat",
+		Body: models.Cat{}, Response: models.Cat{}, Status: http.StatusCreated},
+	{Name: "CatGet", Method: http.MethodGet, Path: Endpoints.CatGet, Tag: "cats", Summary: "Get a cat",
+		Response: models.Cat{}},
+	{Name: "CatGetAll", Method: http.MethodGet, Path: Endpoints.CatGetAll, Tag: "cats", Summary: "List cats",
+		Query: models.CatQuery{}, Response: models.PaginatedCats{}},
+	{Name: "CatUpdate", Method: http.MethodPut, Path: Endpoints.CatUpdate, Tag: "cats", Summary: "Update salary of a cat",
+		Body: models.CatUpdate{}, Response: models.Cat{}},
+	{Name: "CatDelete", Method: http.MethodDelete, Path: Endpoints.CatDelete, Tag: "cats", Summary: "Delete a cat"},
+	{Name: "CatPatch", Method: http.MethodPatch, Path: Endpoints.CatPatch, Tag: "cats", Summary: "Patch a cat with JSON merge patch",
+		Body: map[string]any{}, BodyType: mergepatch.ContentType, Response: models.Cat{}},
+	{Name: "CatSalaryHistory", Method: http.MethodGet, Path: Endpoints.CatSalaryHistory, Tag: "cats", Summary: "Get salary history of a cat",
+		Response: []models.SalaryChange{}},
+	{Name: "CatStatusUpdate", Method: http.MethodPut, Path: Endpoints.CatStatusUpdate, Tag: "cats", Summary: "Change status of a cat",
+		Body: models.StatusUpdate{}, Response: models.Cat{}},
+	{Name: "CatStatusHistory", Method: http.MethodGet, Path: Endpoints.CatStatusHistory, Tag: "cats", Summary: "Get status history of a cat",
+		Response: []models.StatusChange{}},
+	{Name: "CatStats", Method: http.MethodGet, Path: Endpoints.CatStats, Tag: "cats", Summary: "Get mission statistics of a cat",
+		Response: models.CatStats{}},
+	{Name: "CatLeaderboard", Method: http.MethodGet, Path: Endpoints.CatLeaderboard, Tag: "cats", Summary: "Get leaderboard of cats",
+		Query: models.LeaderboardQuery{}, Response: []models.CatStats{}},
+	{Name: "CatPromotions", Method: http.MethodGet, Path: Endpoints.CatPromotions, Tag: "cats", Summary: "Get promotions of a cat",
+		Response: []models.Promotion{}},
+	{Name: "CatLedgerGet", Method: http.MethodGet, Path: Endpoints.CatLedgerGet, Tag: "cats", Summary: "Get expense ledger of a cat",
+		Response: models.Ledger{}},
+	{Name: "CatLedgerCreate", Method: http.MethodPost, Path: Endpoints.CatLedgerCreate, Tag: "cats", Summary: "Add a ledger entry",
+		Body: models.LedgerEntry{}, Response: models.LedgerEntry{}, Status: http.StatusCreated},
+	{Name: "CatSkillsGet", Method: http.MethodGet, Path: Endpoints.CatSkillsGet, Tag: "cats", Summary: "Get skills of a cat",
+		Response: []models.CatSkill{}},
+	{Name: "CatSkillsUpdate", Method: http.MethodPut, Path: Endpoints.CatSkillsUpdate, Tag: "cats", Summary: "Replace skills of a cat",
+		Body: []models.CatSkill{}, Response: []models.CatSkill{}},
+	{Name: "CatAbsenceCreate", Method: http.MethodPost, Path: Endpoints.CatAbsenceCreate, Tag: "cats", Summary: "Add an absence",
+		Body: models.Absence{}, Response: models.Absence{}, Status: http.StatusCreated},
+	{Name: "CatAbsenceGetAll", Method: http.MethodGet, Path: Endpoints.CatAbsenceGetAll, Tag: "cats", Summary: "List absences of a cat",
+		Response: []models.Absence{}},
+	{Name: "CatAbsenceUpdate", Method: http.MethodPut, Path: Endpoints.CatAbsenceUpdate, Tag: "cats", Summary: "Update an absence",
+		Body: models.Absence{}, Response: models.Absence{}},
+	{Name: "CatAbsenceDelete", Method: http.MethodDelete, Path: Endpoints.CatAbsenceDelete, Tag: "cats", Summary: "Delete an absence"},
+	{Name: "CatAvailable", Method: http.MethodGet, Path: Endpoints.CatAvailable, Tag: "cats", Summary: "List cats available in a period",
+		Query: models.AvailabilityQuery{}, Response: []models.Cat{}},
+	{Name: "CatImport", Method: http.MethodPost, Path: Endpoints.CatImport, Tag: "cats", Summary: "Import cats from CSV or NDJSON",
+		Query: models.CatImportQuery{}, BodyType: "text/csv, application/x-ndjson", Response: models.CatImportReport{}, Status: http.StatusCreated},
+	{Name: "CatExport", Method: http.MethodGet, Path: Endpoints.CatExport, Tag: "cats", Summary: "Export cats as CSV, NDJSON or XLSX",
+		Query: models.CatExportQuery{}, ResponseType: exportResponseType},
+	{Name: "CatMissions", Method: http.MethodGet, Path: Endpoints.CatMissions, Tag: "cats", Summary: "Get mission history of a cat",
+		Query: models.CatMissionQuery{}, Response: models.PaginatedMissions{}},
+	{Name: "CatPhotoUpload", Method: http.MethodPut, Path: Endpoints.CatPhotoUpload, Tag: "cats", Summary: "Upload photo of a cat",
+		BodyType: "image/jpeg, image/png", Response: models.CatPhoto{}},
+	{Name: "CatPhotoGet", Method: http.MethodGet, Path: Endpoints.CatPhotoGet, Tag: "cats", Summary: "Get photo and thumbnails of a cat",
+		Response: models.CatPhoto{}},
+	{Name: "CatTransfer", Method: http.MethodPost, Path: Endpoints.CatTransfer, Tag: "cats", Summary: "Transfer a cat to another handler",
+		Body: models.CatTransfer{}, Response: models.Cat{}},
+	{Name: "RankGetAll", Method: http.MethodGet, Path: Endpoints.RankGetAll, Tag: "cats", Summary: "List ranks",
+		Response: []models.Rank{}},
+
+	{Name: "PayrollGet", Method: http.MethodGet, Path: Endpoints.PayrollGet, Tag: "payroll", Summary: "Calculate payroll for a period",
+		Query: models.PayrollQuery{}, Response: models.Payroll{}},
+	{Name: "BudgetGet", Method: http.MethodGet, Path: Endpoints.BudgetGet, Tag: "payroll", Summary: "Get payroll budget usage",
+		Response: models.Budget{}},
+
+	{Name: "HandlerCreate", Method: http.MethodPost, Path: Endpoints.HandlerCreate, Tag: "handlers", Summary: "Create a handler",
+		Body: models.Handler{}, Response: models.Handler{}, Status: http.StatusCreated},
+	{Name: "HandlerGet", Method: http.MethodGet, Path: Endpoints.HandlerGet, Tag: "handlers", Summary: "Get a handler",
+		Response: models.Handler{}},
+	{Name: "HandlerGetAll", Method: http.MethodGet, Path: Endpoints.HandlerGetAll, Tag: "handlers", Summary: "List handlers",
+		Response: []models.Handler{}},
+	{Name: "HandlerCats", Method: http.MethodGet, Path: Endpoints.HandlerCats, Tag: "handlers", Summary: "List cats of a handler",
+		Query: models.CatQuery{}, Response: models.PaginatedCats{}},
+	{Name: "HandlerMissions", Method: http.MethodGet, Path: Endpoints.HandlerMissions, Tag: "handlers", Summary: "List missions of cats of a handler",
+		Query: models.PaginationQuery{}, Response: models.PaginatedMissions{}},
+	{Name: "HandlerDashboard", Method: http.MethodGet, Path: Endpoints.HandlerDashboard, Tag: "handlers", Summary: "Get dashboard of a handler",
+		Response: models.HandlerDashboard{}},
+	{Name: "HandlerBudget", Method: http.MethodPut, Path: Endpoints.HandlerBudget, Tag: "handlers", Summary: "Set payroll budget of a handler",
+		Body: models.HandlerBudgetUpdate{}, Response: models.Handler{}},
+
+	{Name: "SkillCreate", Method: http.MethodPost, Path: Endpoints.SkillCreate, Tag: "skills", Summary: "Create a skill",
+		Body: models.Skill{}, Response: models.Skill{}, Status: http.StatusCreated},
+	{Name: "SkillGetAll", Method: http.MethodGet, Path: Endpoints.SkillGetAll, Tag: "skills", Summary: "List skills",
+		Response: []models.Skill{}},
+
+	{Name: "MissionCreate", Method: http.MethodPost, Path: Endpoints.MissionCreate, Tag: "missions", Summary: "Create a mission",
+		Body: models.Mission{}, Response: models.Mission{}, Status: http.StatusCreated},
+	{Name: "MissionGet", Method: http.MethodGet, Path: Endpoints.MissionGet, Tag: "missions", Summary: "Get a mission",
+		Response: models.Mission{}},
+	{Name: "MissionGetAll", Method: http.MethodGet, Path: Endpoints.MissionGetAll, Tag: "missions", Summary: "List missions",
+		Query: struct {
+			models.PaginationQuery
+			models.MissionFilter
+		}{}, Response: models.PaginatedMissions{}},
+	{Name: "MissionExport", Method: http.MethodGet, Path: Endpoints.MissionExport, Tag: "missions", Summary: "Export missions with a row per target",
+		Query: models.MissionExportQuery{}, ResponseType: exportResponseType},
+	{Name: "MissionAssign", Method: http.MethodPost, Path: Endpoints.MissionAssign, Tag: "missions", Summary: "Assign a cat to a mission",
+		Query: models.AssignQuery{}, Response: models.AssignResult{}},
+	{Name: "MissionWindow", Method: http.MethodPut, Path: Endpoints.MissionWindow, Tag: "missions", Summary: "Move a mission to another period",
+		Body: models.MissionWindow{}, Response: models.Mission{}},
+	{Name: "MissionComplete", Method: http.MethodPost, Path: Endpoints.MissionComplete, Tag: "missions", Summary: "Complete a mission",
+		Response: models.Mission{}},
+	{Name: "MissionDelete", Method: http.MethodDelete, Path: Endpoints.MissionDelete, Tag: "missions", Summary: "Delete a mission"},
+	{Name: "MissionClone", Method: http.MethodPost, Path: Endpoints.MissionClone, Tag: "missions", Summary: "Clone a mission",
+		Response: models.Mission{}, Status: http.StatusCreated},
+	{Name: "MissionFromTemplate", Method: http.MethodPost, Path: Endpoints.MissionFromTemplate, Tag: "missions", Summary: "Create a mission from a template",
+		Response: models.Mission{}, Status: http.StatusCreated},
+	{Name: "MissionTypeGetAll", Method: http.MethodGet, Path: Endpoints.MissionTypeGetAll, Tag: "missions", Summary: "List mission types",
+		Response: []models.MissionType{}},
+
+	{Name: "TargetComplete", Method: http.MethodPost, Path: Endpoints.TargetComplete, Tag: "missions", Summary: "Complete a target"},
+	{Name: "TargetUpdate", Method: http.MethodPost, Path: Endpoints.TargetUpdate, Tag: "missions", Summary: "Update notes of a target",
+		Body: models.TargetUpdate{}, Response: models.Target{}},
+	{Name: "TargetDelete", Method: http.MethodDelete, Path: Endpoints.TargetDelete, Tag: "missions", Summary: "Delete a target"},
+	{Name: "TargetAdd", Method: http.MethodPost, Path: Endpoints.TargetAdd, Tag: "missions", Summary: "Add a target to a mission",
+		Body: models.Target{}, Response: models.Mission{}},
+
+	{Name: "TemplateCreate", Method: http.MethodPost, Path: Endpoints.TemplateCreate, Tag: "templates", Summary: "Create a mission template",
+		Body: models.MissionTemplate{}, Response: models.MissionTemplate{}, Status: http.StatusCreated},
+	{Name: "TemplateGet", Method: http.MethodGet, Path: Endpoints.TemplateGet, Tag: "templates", Summary: "Get a mission template",
+		Response: models.MissionTemplate{}},
+	{Name: "TemplateGetAll", Method: http.MethodGet, Path: Endpoints.TemplateGetAll, Tag: "templates", Summary: "List mission templates",
+		Query: models.PaginationQuery{}, Response: models.PaginatedTemplates{}},
+	{Name: "TemplateUpdate", Method: http.MethodPut, Path: Endpoints.TemplateUpdate, Tag: "templates", Summary: "Update a mission template",
+		Body: models.MissionTemplate{}, Response: models.MissionTemplate{}},
+	{Name: "TemplateDelete", Method: http.MethodDelete, Path: Endpoints.TemplateDelete, Tag: "templates", Summary: "Delete a mission template"},
+
+	{Name: "Batch", Method: http.MethodPost, Path: Endpoints.Batch, Tag: "batch", Summary: "Run operations in a single transaction",
+		Body: models.BatchRequest{}, Response: models.BatchResponse{}},
+}
+
+// unversionedOperations documents routes registered outside of API versions
+var unversionedOperations = []openapi.Operation{
+	{Name: "PhotoFile", Method: http.MethodGet, Path: Endpoints.PhotoFile, Tag: "photos", Summary: "Download a stored photo",
+		ResponseType: "image/jpeg, image/png"},
+	{Name: "OpenAPI", Method: http.MethodGet, Path: Endpoints.OpenAPI, Tag: "docs", Summary: "Get OpenAPI specification",
+		Response: map[string]any{}},
+	{Name: "Docs", Method: http.MethodGet, Path: Endpoints.Docs, Tag: "docs", Summary: "API explorer",
+		ResponseType: "text/html"},
+	{Name: "GraphQL", Method: http.MethodPost, Path: Endpoints.GraphQL, Tag: "graphql", Summary: "Execute a GraphQL query or mutation",
+		Body: graph.Request{}, Response: map[string]any{}},
+}
+
+// apiOperations documents operations of every API version and their deprecated aliases
+func apiOperations() []openapi.Operation {
+	maxKeyLength := maxIdempotencyKeyLength
+	idempotencyKey := openapi.Parameter{Name: IdempotencyKeyHeader, In: "header", Schema: &openapi.Schema{Type: "string", MaxLength: &maxKeyLength}}
+	var result []openapi.Operation
+	for _, op := range operations {
+		if op.Method == http.MethodPost {
+			op.Parameters = append(op.Parameters, idempotencyKey)
+		}
+		v1 := op
+		v1.Name = "v1" + op.Name
+		v1.Path = APIV1 + op.Path
+		result = append(result, v1)
+
+		// v2 responds with 204 No Content when there is no result
+		v2 := op
+		v2.Name = "v2" + op.Name
+		v2.Path = APIV2 + op.Path
+		if op.Response == nil && op.ResponseType == "" {
+			v2.Status = http.StatusNoContent
+		}
+		result = append(result, v2)
+
+		deprecated := op
+		deprecated.Deprecated = true
+		result = append(result, deprecated)
+	}
+	for _, op := range unversionedOperations {
+		if op.Method == http.MethodPost {
+			op.Parameters = append(op.Parameters, idempotencyKey)
+		}
+		result = append(result, op)
+	}
+	return result
+}
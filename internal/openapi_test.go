@@ -0,0 +1,70 @@
+package spycatagency
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+	"github.com/4oBuko/spy-cat-agency/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDocsTestServer() *Server {
+	return NewServer(&MockCatService{}, &MockCatAPI{}, &MockMissionService{}, &MockTemplateService{}, &MockPayrollService{}, &MockSkillService{}, &MockAbsenceService{}, &MockStatsService{}, &MockLedgerService{},
+		&MockHandlerService{}, &MockPhotoService{}, &MockBudgetService{}, &MockImportService{}, &MockExportService{}, &MockTransactor{}, repositories.NewMemoryIdempotencyRepository(), time.Hour)
+}
+
+func TestOpenAPICoversRoutes(t *testing.T) {
+	server := newDocsTestServer()
+	routes := server.router.Routes()
+	require.NotEmpty(t, routes)
+	for _, route := range routes {
+		assert.True(t, server.spec.Has(route.Method, route.Path), "%s %s is not documented in operations", route.Method, route.Path)
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range routes {
+		registered[route.Method+" "+route.Path] = true
+	}
+	names := make(map[string]bool)
+	for _, op := range apiOperations() {
+		assert.True(t, registered[op.Method+" "+op.Path], "%s %s is documented but not registered", op.Method, op.Path)
+		assert.False(t, names[op.Name], "operation name %s is used twice", op.Name)
+		names[op.Name] = true
+	}
+}
+
+func TestOpenAPIEndpoints(t *testing.T) {
+	server := newDocsTestServer()
+
+	t.Run("specification", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, Endpoints.OpenAPI, nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var doc openapi.Document
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, openapi.Version, doc.OpenAPI)
+		assert.Equal(t, apiInfo.Title, doc.Info.Title)
+		assert.Contains(t, doc.Paths, "/v1/cats/{id}")
+		assert.Contains(t, doc.Paths, "/v2/cats/{id}")
+		assert.True(t, doc.Paths["/cats/{id}"]["get"].Deprecated)
+		assert.Contains(t, doc.Paths["/v2/cats/{id}"]["delete"].Responses, "204")
+		assert.Equal(t, IdempotencyKeyHeader, doc.Paths["/v1/cats"]["post"].Parameters[0].Name)
+		assert.Contains(t, doc.Components.Schemas, "Cat")
+	})
+
+	t.Run("explorer", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, Endpoints.Docs, nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/html"))
+		assert.Contains(t, w.Body.String(), Endpoints.OpenAPI)
+	})
+}
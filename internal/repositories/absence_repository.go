@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+var ErrAbsenceNotFound = errors.New("absence not found")
+
+type AbsenceRepository interface {
+	Add(ctx context.Context, absence models.Absence) (models.Absence, error)
+	GetById(ctx context.Context, id int64) (models.Absence, error)
+	GetByCatId(ctx context.Context, catId int64) ([]models.Absence, error)
+	GetOverlapping(ctx context.Context, catId int64, from, to time.Time) ([]models.Absence, error)
+	Update(ctx context.Context, absence models.Absence) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type MySQLAbsenceRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLAbsenceRepository(db *sql.DB) *MySQLAbsenceRepository {
+	return &MySQLAbsenceRepository{
+		db: db,
+	}
+}
+
+func (m *MySQLAbsenceRepository) Add(ctx context.Context, absence models.Absence) (models.Absence, error) {
+	newAbsenceQuery := `INSERT INTO cat_absences (cat_id, kind, starts_at, ends_at, note) VALUES (?, ?, ?, ?, ?)`
+	result, err := conn(ctx, m.db).ExecContext(ctx, newAbsenceQuery, absence.CatId, absence.Kind, absence.From, absence.To, absence.Note)
+	if err != nil {
+		return models.Absence{}, fmt.Errorf("failed to add new absence: %w", err)
+	}
+	absence.Id, err = result.LastInsertId()
+	if err != nil {
+		return models.Absence{}, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return absence, nil
+}
+
+func (m *MySQLAbsenceRepository) GetById(ctx context.Context, id int64) (models.Absence, error) {
+	var a models.Absence
+	getByIdQuery := `SELECT id, cat_id, kind, starts_at, ends_at, note FROM cat_absences WHERE id = ?`
+	err := conn(ctx, m.db).QueryRowContext(ctx, getByIdQuery, id).Scan(&a.Id, &a.CatId, &a.Kind, &a.From, &a.To, &a.Note)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Absence{}, ErrAbsenceNotFound
+		}
+		return models.Absence{}, fmt.Errorf("failed to get absence by id: %w", err)
+	}
+	return a, nil
+}
+
+func (m *MySQLAbsenceRepository) GetByCatId(ctx context.Context, catId int64) ([]models.Absence, error) {
+	getByCatIdQuery := `SELECT id, cat_id, kind, starts_at, ends_at, note FROM cat_absences WHERE cat_id = ? ORDER BY starts_at, id`
+	return m.query(ctx, getByCatIdQuery, catId)
+}
+
+// GetOverlapping returns absences of the cat that intersect with the period [from, to)
+func (m *MySQLAbsenceRepository) GetOverlapping(ctx context.Context, catId int64, from, to time.Time) ([]models.Absence, error) {
+	overlappingQuery := `SELECT id, cat_id, kind, starts_at, ends_at, note FROM cat_absences
+		WHERE cat_id = ? AND starts_at < ? AND ends_at > ? ORDER BY starts_at, id`
+	return m.query(ctx, overlappingQuery, catId, to, from)
+}
+
+func (m *MySQLAbsenceRepository) query(ctx context.Context, query string, args ...any) ([]models.Absence, error) {
+	var absences []models.Absence
+	rows, err := conn(ctx, m.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absences: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		a := new(models.Absence)
+		if err := rows.Scan(&a.Id, &a.CatId, &a.Kind, &a.From, &a.To, &a.Note); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		absences = append(absences, *a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return absences, nil
+}
+
+func (m *MySQLAbsenceRepository) Update(ctx context.Context, absence models.Absence) error {
+	updateQuery := `UPDATE cat_absences SET kind = ?, starts_at = ?, ends_at = ?, note = ? WHERE id = ?`
+	_, err := conn(ctx, m.db).ExecContext(ctx, updateQuery, absence.Kind, absence.From, absence.To, absence.Note, absence.Id)
+	if err != nil {
+		return fmt.Errorf("failed to update absence: %w", err)
+	}
+	return nil
+}
+
+func (m *MySQLAbsenceRepository) Delete(ctx context.Context, id int64) error {
+	deleteQuery := `DELETE FROM cat_absences WHERE id = ?`
+	result, err := conn(ctx, m.db).ExecContext(ctx, deleteQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete absence: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if deleted == 0 {
+		return ErrAbsenceNotFound
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+// committedSalaryColumn is the latest salary of the cat including raises scheduled for the future
+const committedSalaryColumn = `COALESCE((SELECT s.salary FROM salary_changes s
+	WHERE s.cat_id = cats.id ORDER BY s.effective_from DESC, s.id DESC LIMIT 1), cats.salary)`
+
+// onPayrollCondition matches cats that are paid. Retired and deceased cats are not
+const onPayrollCondition = "cats.status IN ('recruit', 'active', 'suspended')"
+
+type BudgetRepository interface {
+	GetCommittedPayroll(ctx context.Context) (int, error)
+	GetCommittedSalary(ctx context.Context, catId int64) (int, error)
+	GetHandlerBudget(ctx context.Context, handlerId int64) (models.HandlerBudget, error)
+	GetHandlerBudgets(ctx context.Context) ([]models.HandlerBudget, error)
+	// Lock locks the agency budget when agency is true and budgets of the handlers until the end of the transaction of ctx.
+	// The agency budget is locked first and handlers in the order of ids, so concurrent checks don't deadlock
+	Lock(ctx context.Context, agency bool, handlerIds []int64) error
+}
+
+type MySQLBudgetRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLBudgetRepository(db *sql.DB) *MySQLBudgetRepository {
+	return &MySQLBudgetRepository{
+		db: db,
+	}
+}
+
+// GetCommittedPayroll returns monthly salaries of all cats on payroll
+func (m *MySQLBudgetRepository) GetCommittedPayroll(ctx context.Context) (int, error) {
+	var used int
+	payrollQuery := "SELECT COALESCE(SUM(" + committedSalaryColumn + "), 0) FROM cats WHERE " + onPayrollCondition
+	err := conn(ctx, m.db).QueryRowContext(ctx, payrollQuery).Scan(&used)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get committed payroll: %w", err)
+	}
+	return used, nil
+}
+
+func (m *MySQLBudgetRepository) GetCommittedSalary(ctx context.Context, catId int64) (int, error) {
+	var salary int
+	salaryQuery := "SELECT " + committedSalaryColumn + " FROM cats WHERE id = ?"
+	err := conn(ctx, m.db).QueryRowContext(ctx, salaryQuery, catId).Scan(&salary)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrCatNotFound
+		}
+		return 0, fmt.Errorf("failed to get committed salary: %w", err)
+	}
+	return salary, nil
+}
+
+func (m *MySQLBudgetRepository) GetHandlerBudget(ctx context.Context, handlerId int64) (models.HandlerBudget, error) {
+	budgets, err := m.getHandlerBudgets(ctx, " WHERE h.id = ?", handlerId)
+	if err != nil {
+		return models.HandlerBudget{}, err
+	}
+	if len(budgets) == 0 {
+		return models.HandlerBudget{}, ErrHandlerNotFound
+	}
+	return budgets[0], nil
+}
+
+func (m *MySQLBudgetRepository) GetHandlerBudgets(ctx context.Context) ([]models.HandlerBudget, error) {
+	return m.getHandlerBudgets(ctx, "")
+}
+
+func (m *MySQLBudgetRepository) getHandlerBudgets(ctx context.Context, where string, args ...any) ([]models.HandlerBudget, error) {
+	var budgets []models.HandlerBudget
+	budgetsQuery := `SELECT h.id, h.handler_name, h.budget,
+		COALESCE(SUM(CASE WHEN ` + onPayrollCondition + ` THEN ` + committedSalaryColumn + ` END), 0)
+		FROM handlers h LEFT JOIN cats ON cats.handler_id = h.id` + where + `
+		GROUP BY h.id, h.handler_name, h.budget ORDER BY h.id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, budgetsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get handler budgets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var limit sql.NullInt64
+		b := new(models.HandlerBudget)
+		if err := rows.Scan(&b.HandlerId, &b.Name, &limit, &b.Used); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		if limit.Valid {
+			l := int(limit.Int64)
+			b.Limit = &l
+		}
+		budgets = append(budgets, *b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return budgets, nil
+}
+
+func (m *MySQLBudgetRepository) Lock(ctx context.Context, agency bool, handlerIds []int64) error {
+	if agency {
+		var id int
+		agencyQuery := "SELECT id FROM budget_lock FOR UPDATE"
+		if err := conn(ctx, m.db).QueryRowContext(ctx, agencyQuery).Scan(&id); err != nil {
+			return fmt.Errorf("failed to lock agency budget: %w", err)
+		}
+	}
+	var args []any
+	for _, id := range slices.Sorted(slices.Values(handlerIds)) {
+		if id != 0 {
+			args = append(args, id)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	handlersQuery := "SELECT id FROM handlers WHERE id IN (" + placeholders(len(args)) + ") ORDER BY id FOR UPDATE"
+	rows, err := conn(ctx, m.db).QueryContext(ctx, handlersQuery, args...)
+	if err != nil {
+		return fmt.Errorf("failed to lock handler budgets: %w", err)
+	}
+	return rows.Close()
+}
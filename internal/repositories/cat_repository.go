@@ -5,21 +5,66 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/go-sql-driver/mysql"
 )
 
 var ErrCatNotFound = errors.New("cat not found")
+var ErrCatHasHistory = errors.New("cat has mission history")
+
+// BatchError tells which item of a batch caused the failure
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// currentSalaryColumn selects the latest salary that is already effective.
+// Salary changes with a future effective date are ignored until that date
+const currentSalaryColumn = `COALESCE((SELECT s.salary FROM salary_changes s
+	WHERE s.cat_id = cats.id AND s.effective_from <= UTC_TIMESTAMP()
+	ORDER BY s.effective_from DESC, s.id DESC LIMIT 1), cats.salary)`
+
+const catColumns = "id, cat_name, breed, years_of_experience, " + currentSalaryColumn + ", status, cat_rank, handler_id, photo_url, " + breedDetailsColumns
+
+const breedDetailsColumns = "breed_name, breed_origin, breed_temperament, breed_life_span"
 
 type CatRepository interface {
 	GetById(ctx context.Context, id int64) (models.Cat, error)
-	GetAll(ctx context.Context, limit, offset int) ([]models.Cat, error)
+	// GetByIdForUpdate returns the cat and locks it until the end of the transaction of ctx
+	GetByIdForUpdate(ctx context.Context, id int64) (models.Cat, error)
+	GetByIds(ctx context.Context, ids []int64) (map[int64]models.Cat, error)
+	GetAll(ctx context.Context, filter models.CatFilter, limit, offset int) ([]models.Cat, error)
+	// Export calls fn for every cat matching the filter while the cats are read from the database
+	Export(ctx context.Context, filter models.CatFilter, fn func(models.Cat) error) error
 	DeleteById(ctx context.Context, d int64) error
-	Update(ctx context.Context, id int64, update models.CatUpdate) error
+	UpdateSalary(ctx context.Context, id int64, change models.SalaryChange) error
+	UpdateProfile(ctx context.Context, cat models.Cat, salaryChange *models.SalaryChange) error
+	UpdateStatus(ctx context.Context, id int64, change models.StatusChange, releaseMissions bool) error
+	UpdateHandler(ctx context.Context, id, handlerId int64) error
+	UpdatePhoto(ctx context.Context, id int64, photoUrl string) error
+	GetBreedsWithoutDetails(ctx context.Context) (map[string]int, error)
+	UpdateBreedDetails(ctx context.Context, breed string, details models.BreedDetails) (int64, error)
 	Add(ctx context.Context, cat models.Cat) (models.Cat, error)
+	// AddAll adds all cats in one transaction. Error of a single cat is returned as *BatchError
+	AddAll(ctx context.Context, cats []models.Cat) ([]models.Cat, error)
 	IsBusy(ctx context.Context, catId int64) (bool, error)
+	// IsBusyDuring checks whether open missions of the cat except exceptMissionId intersect with the period [from, to).
+	// Period without to never ends
+	IsBusyDuring(ctx context.Context, catId, exceptMissionId int64, from time.Time, to *time.Time) (bool, error)
 	Exists(ctx context.Context, id int64) error
-	GetCount(ctx context.Context) (int, error)
+	GetCount(ctx context.Context, filter models.CatFilter) (int, error)
+	GetAvailable(ctx context.Context, from, to time.Time) ([]models.Cat, error)
 }
 
 type MySQLCatRepository struct {
@@ -31,10 +76,17 @@ func NewMySQLCatRepository(db *sql.DB) *MySQLCatRepository {
 }
 
 func (m *MySQLCatRepository) GetById(ctx context.Context, id int64) (models.Cat, error) {
-	var c models.Cat
-	getByIdQuery := "SELECT id, cat_name, breed, years_of_experience, salary FROM cats where id = ?"
-	err := m.db.QueryRowContext(ctx, getByIdQuery, id).
-		Scan(&c.Id, &c.Name, &c.Breed, &c.YearsOfExperience, &c.Salary)
+	getByIdQuery := "SELECT " + catColumns + " FROM cats where id = ?"
+	return m.getById(ctx, getByIdQuery, id)
+}
+
+func (m *MySQLCatRepository) GetByIdForUpdate(ctx context.Context, id int64) (models.Cat, error) {
+	getByIdQuery := "SELECT " + catColumns + " FROM cats where id = ? FOR UPDATE"
+	return m.getById(ctx, getByIdQuery, id)
+}
+
+func (m *MySQLCatRepository) getById(ctx context.Context, getByIdQuery string, id int64) (models.Cat, error) {
+	c, err := scanCat(conn(ctx, m.db).QueryRowContext(ctx, getByIdQuery, id))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -45,21 +97,53 @@ func (m *MySQLCatRepository) GetById(ctx context.Context, id int64) (models.Cat,
 	return c, nil
 }
 
-func (m *MySQLCatRepository) GetAll(ctx context.Context, limit, offset int) ([]models.Cat, error) {
+// GetByIds loads several cats with one query. Cats that don't exist are missing in the result
+func (m *MySQLCatRepository) GetByIds(ctx context.Context, ids []int64) (map[int64]models.Cat, error) {
+	cats := make(map[int64]models.Cat, len(ids))
+	if len(ids) == 0 {
+		return cats, nil
+	}
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	getByIdsQuery := "SELECT " + catColumns + " FROM cats WHERE id IN (" + placeholders(len(args)) + ")"
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getByIdsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cats by ids: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		cat, err := scanCat(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed :%w", err)
+		}
+		cats[cat.Id] = cat
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return cats, nil
+}
+
+func (m *MySQLCatRepository) GetAll(ctx context.Context, filter models.CatFilter, limit, offset int) ([]models.Cat, error) {
 	var cats []models.Cat
-	getAllQuery := "SELECT id, cat_name, breed, years_of_experience, salary FROM cats ORDER BY id LIMIT ? OFFSET ?"
-	rows, err := m.db.QueryContext(ctx, getAllQuery, limit, offset)
+	where, args := catFilterClause(filter)
+	getAllQuery := "SELECT " + catColumns + " FROM cats" + where + " ORDER BY id LIMIT ? OFFSET ?"
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getAllQuery, append(args, limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all cats: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		cat := new(models.Cat)
-		if err := rows.Scan(&cat.Id, &cat.Name, &cat.Breed, &cat.YearsOfExperience, &cat.Salary); err != nil {
+		cat, err := scanCat(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan failed :%w", err)
 		}
-		cats = append(cats, *cat)
+		cats = append(cats, cat)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -68,38 +152,343 @@ func (m *MySQLCatRepository) GetAll(ctx context.Context, limit, offset int) ([]m
 	return cats, nil
 }
 
+func (m *MySQLCatRepository) Export(ctx context.Context, filter models.CatFilter, fn func(models.Cat) error) error {
+	where, args := catFilterClause(filter)
+	exportQuery := "SELECT " + catColumns + " FROM cats" + where + " ORDER BY id"
+	rows, err := conn(ctx, m.db).QueryContext(ctx, exportQuery, args...)
+	if err != nil {
+		return fmt.Errorf("failed to export cats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		cat, err := scanCat(rows)
+		if err != nil {
+			return fmt.Errorf("scan failed :%w", err)
+		}
+		if err := fn(cat); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return nil
+}
+
 func (m *MySQLCatRepository) DeleteById(ctx context.Context, id int64) error {
 	err := m.Exists(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// completed missions are deleted with the cat. Missions released by the cat keep it in their history,
+	// so such cat can't be deleted. Completed missions that other cats were released from are in their history
+	sharedQuery := `SELECT EXISTS (SELECT 1 FROM mission_assignments a JOIN missions m ON m.id = a.mission_id
+		WHERE m.cat_id = ? AND m.completed = true AND a.cat_id <> ?)`
+	var shared bool
+	if err := tx.QueryRowContext(ctx, sharedQuery, id, id).Scan(&shared); err != nil {
+		return fmt.Errorf("failed to check shared missions: %w", err)
+	}
+	if shared {
+		return ErrCatHasHistory
+	}
+	deleteAssignmentsQuery := `DELETE FROM mission_assignments
+		WHERE cat_id = ? AND mission_id IN (SELECT id FROM missions WHERE cat_id = ? AND completed = true)`
+	_, err = tx.ExecContext(ctx, deleteAssignmentsQuery, id, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete assignments: %w", err)
+	}
+	deleteMissionsQuery := "DELETE FROM missions WHERE cat_id = ? AND completed = true"
+	_, err = tx.ExecContext(ctx, deleteMissionsQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete missions: %w", err)
+	}
 	deleteCatQuery := "DELETE FROM cats where id = ?"
-	_, err = m.db.ExecContext(ctx, deleteCatQuery, id)
+	_, err = tx.ExecContext(ctx, deleteCatQuery, id)
 	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == errRowIsReferenced {
+			return ErrCatHasHistory
+		}
 		return fmt.Errorf("failed to delete cat: %w", err)
 	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateSalary records salary change of the cat. Salary of the cat is changed immediately
+// only when the change is already effective
+func (m *MySQLCatRepository) UpdateSalary(ctx context.Context, id int64, change models.SalaryChange) error {
+	err := m.Exists(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	change.CatId = id
+	err = addSalaryChange(ctx, tx, change)
+	if err != nil {
+		return err
+	}
+	if !change.EffectiveFrom.After(change.CreatedAt) {
+		updateCatQuery := "UPDATE cats SET salary = ? where id = ?"
+		_, err = tx.ExecContext(ctx, updateCatQuery, change.Salary, id)
+		if err != nil {
+			return fmt.Errorf("failed to update cat: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateProfile updates name, experience and breed of the cat. Salary change is recorded in the same transaction when it's provided
+func (m *MySQLCatRepository) UpdateProfile(ctx context.Context, cat models.Cat, salaryChange *models.SalaryChange) error {
+	err := m.Exists(ctx, cat.Id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	updateCatQuery := `UPDATE cats SET cat_name = ?, years_of_experience = ?, breed = ?,
+		breed_name = ?, breed_origin = ?, breed_temperament = ?, breed_life_span = ? where id = ?`
+	args := append([]any{cat.Name, cat.YearsOfExperience, cat.Breed}, breedDetailsArgs(cat.BreedDetails)...)
+	_, err = tx.ExecContext(ctx, updateCatQuery, append(args, cat.Id)...)
+	if err != nil {
+		return fmt.Errorf("failed to update cat: %w", err)
+	}
+	if salaryChange != nil {
+		salaryChange.CatId = cat.Id
+		err = addSalaryChange(ctx, tx, *salaryChange)
+		if err != nil {
+			return err
+		}
+		updateSalaryQuery := "UPDATE cats SET salary = ? where id = ?"
+		_, err = tx.ExecContext(ctx, updateSalaryQuery, salaryChange.Salary, cat.Id)
+		if err != nil {
+			return fmt.Errorf("failed to update cat: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
-func (m *MySQLCatRepository) Update(ctx context.Context, id int64, update models.CatUpdate) error {
+// UpdateStatus records status change of the cat. Open missions of the cat are unassigned
+// in the same transaction when releaseMissions is true
+func (m *MySQLCatRepository) UpdateStatus(ctx context.Context, id int64, change models.StatusChange, releaseMissions bool) error {
 	err := m.Exists(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	updateCatQuery := "UPDATE cats SET salary = ? where id = ?"
-	_, err = m.db.ExecContext(ctx, updateCatQuery, update.Salary, id)
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	change.CatId = id
+	err = addStatusChange(ctx, tx, change)
+	if err != nil {
+		return err
+	}
+	updateCatQuery := "UPDATE cats SET status = ? where id = ?"
+	_, err = tx.ExecContext(ctx, updateCatQuery, change.Status, id)
 	if err != nil {
 		return fmt.Errorf("failed to update cat: %w", err)
 	}
+	if releaseMissions {
+		// released missions keep the cat in their assignments
+		releaseAssignmentsQuery := `UPDATE mission_assignments SET released_at = UTC_TIMESTAMP()
+			WHERE cat_id = ? AND released_at IS NULL AND mission_id IN (SELECT id FROM missions WHERE cat_id = ? AND completed = false)`
+		_, err = tx.ExecContext(ctx, releaseAssignmentsQuery, id, id)
+		if err != nil {
+			return fmt.Errorf("failed to release assignments: %w", err)
+		}
+		releaseQuery := "UPDATE missions SET cat_id = NULL, assigned_at = NULL where cat_id = ? and completed = false"
+		_, err = tx.ExecContext(ctx, releaseQuery, id)
+		if err != nil {
+			return fmt.Errorf("failed to release missions: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (m *MySQLCatRepository) UpdateHandler(ctx context.Context, id, handlerId int64) error {
+	err := m.Exists(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	updateHandlerQuery := "UPDATE cats SET handler_id = ? where id = ?"
+	_, err = tx.ExecContext(ctx, updateHandlerQuery, handlerId, id)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == errNoReferencedRow {
+			return ErrHandlerNotFound
+		}
+		return fmt.Errorf("failed to update handler: %w", err)
+	}
+	// the new handler runs open missions of the cat, finished ones stay in the history of the previous handler
+	moveAssignmentsQuery := `UPDATE mission_assignments SET handler_id = ?
+		WHERE cat_id = ? AND released_at IS NULL AND mission_id IN (SELECT id FROM missions WHERE completed = false)`
+	_, err = tx.ExecContext(ctx, moveAssignmentsQuery, handlerId, id)
+	if err != nil {
+		return fmt.Errorf("failed to move open assignments: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (m *MySQLCatRepository) UpdatePhoto(ctx context.Context, id int64, photoUrl string) error {
+	err := m.Exists(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	updatePhotoQuery := "UPDATE cats SET photo_url = ? where id = ?"
+	_, err = conn(ctx, m.db).ExecContext(ctx, updatePhotoQuery, photoUrl, id)
+	if err != nil {
+		return fmt.Errorf("failed to update photo: %w", err)
+	}
 	return nil
 }
 
+// GetBreedsWithoutDetails returns every breed of cats without stored breed details with the number of such cats
+func (m *MySQLCatRepository) GetBreedsWithoutDetails(ctx context.Context) (map[string]int, error) {
+	breeds := make(map[string]int)
+	breedsQuery := `SELECT breed, COUNT(*) FROM cats WHERE breed_name = '' GROUP BY breed`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, breedsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get breeds without details: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var breed string
+		var count int
+		if err := rows.Scan(&breed, &count); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		breeds[breed] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return breeds, nil
+}
+
+// UpdateBreedDetails stores details of the breed for all cats of the breed and returns number of updated rows
+func (m *MySQLCatRepository) UpdateBreedDetails(ctx context.Context, breed string, details models.BreedDetails) (int64, error) {
+	updateQuery := `UPDATE cats SET breed_name = ?, breed_origin = ?, breed_temperament = ?, breed_life_span = ? WHERE breed = ?`
+	args := append(breedDetailsArgs(&details), breed)
+	result, err := conn(ctx, m.db).ExecContext(ctx, updateQuery, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update breed details: %w", err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return updated, nil
+}
+
 func (m *MySQLCatRepository) Add(ctx context.Context, cat models.Cat) (models.Cat, error) {
-	newCatQuery := `INSERT INTO cats(cat_name, years_of_experience, salary, breed) VALUES(?,?,?,?)`
-	result, err := m.db.ExecContext(ctx, newCatQuery, cat.Name, cat.YearsOfExperience, cat.Salary, cat.Breed)
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return models.Cat{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	cat, err = addCat(ctx, tx.Tx, cat)
+	if err != nil {
+		return models.Cat{}, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return models.Cat{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return cat, nil
+}
+
+func (m *MySQLCatRepository) AddAll(ctx context.Context, cats []models.Cat) ([]models.Cat, error) {
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	added := make([]models.Cat, 0, len(cats))
+	for i, cat := range cats {
+		cat, err = addCat(ctx, tx.Tx, cat)
+		if err != nil {
+			return nil, &BatchError{Index: i, Err: err}
+		}
+		added = append(added, cat)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return added, nil
+}
+
+// addCat inserts the cat together with its initial salary and status
+func addCat(ctx context.Context, tx *sql.Tx, cat models.Cat) (models.Cat, error) {
+	newCatQuery := `INSERT INTO cats(cat_name, years_of_experience, salary, breed, status, cat_rank, handler_id, ` + breedDetailsColumns + `)
+		VALUES(?,?,?,?,?,?,?,?,?,?,?)`
+	args := append([]any{cat.Name, cat.YearsOfExperience, cat.Salary, cat.Breed, cat.Status, cat.Rank, cat.HandlerId}, breedDetailsArgs(cat.BreedDetails)...)
+	result, err := tx.ExecContext(ctx, newCatQuery, args...)
 	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == errNoReferencedRow {
+			return models.Cat{}, ErrHandlerNotFound
+		}
 		return models.Cat{}, fmt.Errorf("failed to add new cat: %w", err)
 	}
 
@@ -107,13 +496,35 @@ func (m *MySQLCatRepository) Add(ctx context.Context, cat models.Cat) (models.Ca
 	if err != nil {
 		return models.Cat{}, fmt.Errorf("failed to get last insert id: %w", err)
 	}
+
+	now := time.Now().UTC().Truncate(time.Second) // DATETIME column keeps only seconds
+	err = addSalaryChange(ctx, tx, models.SalaryChange{
+		CatId:         cat.Id,
+		Salary:        cat.Salary,
+		EffectiveFrom: now,
+		Reason:        "initial salary",
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return models.Cat{}, err
+	}
+	err = addStatusChange(ctx, tx, models.StatusChange{
+		CatId:         cat.Id,
+		Status:        cat.Status,
+		EffectiveFrom: now,
+		Reason:        "initial status",
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return models.Cat{}, err
+	}
 	return cat, nil
 }
 
 func (m *MySQLCatRepository) IsBusy(ctx context.Context, id int64) (bool, error) {
 	var busy bool
 	isBusyRequest := "SELECT EXISTS (SELECT id, cat_id FROM missions where cat_id = ? and completed = false)"
-	err := m.db.QueryRowContext(ctx, isBusyRequest, id).Scan(&busy)
+	err := conn(ctx, m.db).QueryRowContext(ctx, isBusyRequest, id).Scan(&busy)
 	if err != nil {
 		return false, fmt.Errorf("failed to do busy check: %w", err)
 	}
@@ -121,10 +532,29 @@ func (m *MySQLCatRepository) IsBusy(ctx context.Context, id int64) (bool, error)
 	return busy, nil
 }
 
+// openMissionWindowCondition matches open missions which windows intersect with the period of two arguments [from, to).
+// Mission without start date has already started and mission without end date lasts until it's completed
+const openMissionWindowCondition = `ms.completed = false AND (ms.starts_at IS NULL OR ms.starts_at < ?) AND (ms.ends_at IS NULL OR ms.ends_at > ?)`
+
+func (m *MySQLCatRepository) IsBusyDuring(ctx context.Context, catId, exceptMissionId int64, from time.Time, to *time.Time) (bool, error) {
+	var busy bool
+	// the period without end intersects with every mission that is still going on at from
+	end := time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if to != nil {
+		end = *to
+	}
+	isBusyQuery := "SELECT EXISTS (SELECT 1 FROM missions ms WHERE ms.cat_id = ? AND ms.id <> ? AND " + openMissionWindowCondition + ")"
+	err := conn(ctx, m.db).QueryRowContext(ctx, isBusyQuery, catId, exceptMissionId, end, from).Scan(&busy)
+	if err != nil {
+		return false, fmt.Errorf("failed to do busy check: %w", err)
+	}
+	return busy, nil
+}
+
 func (m *MySQLCatRepository) Exists(ctx context.Context, id int64) error {
 	var exists bool
 	catExistsQuery := "SELECT EXISTS (SELECT 1 FROM cats WHERE id = ?)"
-	err := m.db.QueryRowContext(ctx, catExistsQuery, id).Scan(&exists)
+	err := conn(ctx, m.db).QueryRowContext(ctx, catExistsQuery, id).Scan(&exists)
 
 	if err != nil {
 		return fmt.Errorf("existence check failed: %w", err)
@@ -135,12 +565,91 @@ func (m *MySQLCatRepository) Exists(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (m *MySQLCatRepository) GetCount(ctx context.Context) (int, error) {
+func (m *MySQLCatRepository) GetCount(ctx context.Context, filter models.CatFilter) (int, error) {
 	var count int
-	countQuery := "SELECT COUNT(*) FROM cats"
-	err := m.db.QueryRowContext(ctx, countQuery).Scan(&count)
+	where, args := catFilterClause(filter)
+	countQuery := "SELECT COUNT(*) FROM cats" + where
+	err := conn(ctx, m.db).QueryRowContext(ctx, countQuery, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count cats: %w", err)
 	}
 	return count, nil
 }
+
+// GetAvailable returns active cats that have no open missions and no absences intersecting with the period [from, to)
+func (m *MySQLCatRepository) GetAvailable(ctx context.Context, from, to time.Time) ([]models.Cat, error) {
+	var cats []models.Cat
+	getAvailableQuery := "SELECT " + catColumns + ` FROM cats
+		WHERE status = 'active'
+		AND NOT EXISTS (SELECT 1 FROM missions ms WHERE ms.cat_id = cats.id AND ` + openMissionWindowCondition + `)
+		AND NOT EXISTS (SELECT 1 FROM cat_absences a WHERE a.cat_id = cats.id AND a.starts_at < ? AND a.ends_at > ?)
+		ORDER BY id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getAvailableQuery, to, from, to, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available cats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		cat, err := scanCat(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed :%w", err)
+		}
+		cats = append(cats, cat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return cats, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanCat reads a cat selected with catColumns
+func scanCat(row rowScanner) (models.Cat, error) {
+	var c models.Cat
+	var breed models.BreedDetails
+	err := row.Scan(&c.Id, &c.Name, &c.Breed, &c.YearsOfExperience, &c.Salary, &c.Status, &c.Rank, &c.HandlerId, &c.PhotoUrl,
+		&breed.Name, &breed.Origin, &breed.Temperament, &breed.LifeSpan)
+	if err != nil {
+		return models.Cat{}, err
+	}
+	// empty name means details were not stored yet
+	if breed.Name != "" {
+		c.BreedDetails = &breed
+	}
+	return c, nil
+}
+
+func breedDetailsArgs(details *models.BreedDetails) []any {
+	if details == nil {
+		return []any{"", "", "", ""}
+	}
+	return []any{details.Name, details.Origin, details.Temperament, details.LifeSpan}
+}
+
+// catFilterClause builds WHERE clause and its arguments for the filter
+func catFilterClause(filter models.CatFilter) (string, []any) {
+	var conditions []string
+	var args []any
+	if filter.Skill != "" {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM cat_skills cs JOIN skills s ON s.id = cs.skill_id
+			WHERE cs.cat_id = cats.id AND s.skill_name = ?)`)
+		args = append(args, filter.Skill)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "cats.status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.HandlerId != 0 {
+		conditions = append(conditions, "cats.handler_id = ?")
+		args = append(args, filter.HandlerId)
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
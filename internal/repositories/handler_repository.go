@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+var ErrHandlerNotFound = errors.New("handler not found")
+
+// foreign key violation error codes of MySQL
+const (
+	errRowIsReferenced = 1451
+	errNoReferencedRow = 1452
+)
+
+type HandlerRepository interface {
+	Add(ctx context.Context, handler models.Handler) (models.Handler, error)
+	GetById(ctx context.Context, id int64) (models.Handler, error)
+	GetAll(ctx context.Context) ([]models.Handler, error)
+	UpdateBudget(ctx context.Context, id int64, budget *int) error
+	GetDashboard(ctx context.Context, id int64) (models.HandlerDashboard, error)
+}
+
+type MySQLHandlerRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLHandlerRepository(db *sql.DB) *MySQLHandlerRepository {
+	return &MySQLHandlerRepository{
+		db: db,
+	}
+}
+
+func (m *MySQLHandlerRepository) Add(ctx context.Context, handler models.Handler) (models.Handler, error) {
+	newHandlerQuery := `INSERT INTO handlers (handler_name, email, budget) VALUES (?, ?, ?)`
+	result, err := conn(ctx, m.db).ExecContext(ctx, newHandlerQuery, handler.Name, handler.Email, handler.Budget)
+	if err != nil {
+		return models.Handler{}, fmt.Errorf("failed to add new handler: %w", err)
+	}
+	handler.Id, err = result.LastInsertId()
+	if err != nil {
+		return models.Handler{}, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return handler, nil
+}
+
+func (m *MySQLHandlerRepository) GetById(ctx context.Context, id int64) (models.Handler, error) {
+	getByIdQuery := `SELECT id, handler_name, email, budget FROM handlers WHERE id = ?`
+	h, err := scanHandler(conn(ctx, m.db).QueryRowContext(ctx, getByIdQuery, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Handler{}, ErrHandlerNotFound
+		}
+		return models.Handler{}, fmt.Errorf("failed to get handler by id: %w", err)
+	}
+	return h, nil
+}
+
+func (m *MySQLHandlerRepository) GetAll(ctx context.Context) ([]models.Handler, error) {
+	var handlers []models.Handler
+	getAllQuery := `SELECT id, handler_name, email, budget FROM handlers ORDER BY id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getAllQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all handlers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		h, err := scanHandler(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		handlers = append(handlers, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return handlers, nil
+}
+
+func (m *MySQLHandlerRepository) UpdateBudget(ctx context.Context, id int64, budget *int) error {
+	_, err := m.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	updateBudgetQuery := `UPDATE handlers SET budget = ? WHERE id = ?`
+	_, err = conn(ctx, m.db).ExecContext(ctx, updateBudgetQuery, budget, id)
+	if err != nil {
+		return fmt.Errorf("failed to update handler budget: %w", err)
+	}
+	return nil
+}
+
+// GetDashboard summarises cats of the handler and their open missions
+func (m *MySQLHandlerRepository) GetDashboard(ctx context.Context, id int64) (models.HandlerDashboard, error) {
+	handler, err := m.GetById(ctx, id)
+	if err != nil {
+		return models.HandlerDashboard{}, err
+	}
+	dashboard := models.HandlerDashboard{Handler: handler}
+
+	summaryQuery := `SELECT COUNT(*),
+		COALESCE(SUM(c.status = 'active'), 0),
+		COALESCE(SUM(EXISTS (SELECT 1 FROM missions ms WHERE ms.cat_id = c.id AND ms.completed = false)), 0)
+		FROM cats c WHERE c.handler_id = ?`
+	err = conn(ctx, m.db).QueryRowContext(ctx, summaryQuery, id).Scan(&dashboard.Cats, &dashboard.ActiveCats, &dashboard.BusyCats)
+	if err != nil {
+		return models.HandlerDashboard{}, fmt.Errorf("failed to summarise handler cats: %w", err)
+	}
+
+	operationsQuery := `SELECT ms.id, ms.mission_type, c.id, c.cat_name, COUNT(t.id), COALESCE(SUM(t.completed), 0),
+		ms.starts_at, ms.ends_at
+		FROM missions ms
+		JOIN cats c ON c.id = ms.cat_id
+		LEFT JOIN targets t ON t.mission_id = ms.id
+		WHERE c.handler_id = ? AND ms.completed = false
+		GROUP BY ms.id, ms.mission_type, c.id, c.cat_name, ms.starts_at, ms.ends_at
+		ORDER BY ms.id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, operationsQuery, id)
+	if err != nil {
+		return models.HandlerDashboard{}, fmt.Errorf("failed to get handler operations: %w", err)
+	}
+	defer rows.Close()
+
+	dashboard.Operations = []models.Operation{}
+	for rows.Next() {
+		var startsAt, endsAt sql.NullTime
+		o := new(models.Operation)
+		if err := rows.Scan(&o.MissionId, &o.Type, &o.CatId, &o.CatName, &o.TotalTargets, &o.CompletedTargets,
+			&startsAt, &endsAt); err != nil {
+			return models.HandlerDashboard{}, fmt.Errorf("scan failed: %w", err)
+		}
+		o.StartsAt = nullTimePtr(startsAt)
+		o.EndsAt = nullTimePtr(endsAt)
+		dashboard.Operations = append(dashboard.Operations, *o)
+		dashboard.OpenTargets += o.TotalTargets - o.CompletedTargets
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.HandlerDashboard{}, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	dashboard.OpenMissions = len(dashboard.Operations)
+	return dashboard, nil
+}
+
+func scanHandler(row rowScanner) (models.Handler, error) {
+	var h models.Handler
+	var budget sql.NullInt64
+	err := row.Scan(&h.Id, &h.Name, &h.Email, &budget)
+	if err != nil {
+		return models.Handler{}, err
+	}
+	if budget.Valid {
+		b := int(budget.Int64)
+		h.Budget = &b
+	}
+	return h, nil
+}
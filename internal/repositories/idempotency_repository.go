@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/go-sql-driver/mysql"
+)
+
+var ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+
+// IdempotencyRepository stores responses by idempotency keys. Expired records are treated as missing
+type IdempotencyRepository interface {
+	// Reserve saves incomplete record unless an unexpired record with the same key exists.
+	// In that case the existing record is returned and reserved is false
+	Reserve(ctx context.Context, record models.IdempotencyRecord) (existing models.IdempotencyRecord, reserved bool, err error)
+	// Complete saves response of reserved record
+	Complete(ctx context.Context, record models.IdempotencyRecord) error
+	// Release deletes the record, so the request can be retried with the same key
+	Release(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type MySQLIdempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLIdempotencyRepository(db *sql.DB) *MySQLIdempotencyRepository {
+	return &MySQLIdempotencyRepository{
+		db: db,
+	}
+}
+
+func (m *MySQLIdempotencyRepository) Reserve(ctx context.Context, record models.IdempotencyRecord) (models.IdempotencyRecord, bool, error) {
+	insertQuery := `INSERT INTO idempotency_keys (idempotency_key, fingerprint, expires_at) VALUES (?, ?, ?)`
+	// expired record is taken over by the new request
+	replaceQuery := `UPDATE idempotency_keys
+		SET fingerprint = ?, completed = FALSE, status_code = 0, content_type = '', body = NULL, expires_at = ?
+		WHERE idempotency_key = ? AND expires_at <= ?`
+	// the record can expire or be released between queries, then reservation is tried again
+	for range 3 {
+		_, err := m.db.ExecContext(ctx, insertQuery, record.Key, record.Fingerprint, record.ExpiresAt)
+		if err == nil {
+			return models.IdempotencyRecord{}, true, nil
+		}
+		var mysqlErr *mysql.MySQLError
+		if !errors.As(err, &mysqlErr) || mysqlErr.Number != errDuplicateEntry {
+			return models.IdempotencyRecord{}, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+
+		result, err := m.db.ExecContext(ctx, replaceQuery, record.Fingerprint, record.ExpiresAt, record.Key, time.Now().UTC())
+		if err != nil {
+			return models.IdempotencyRecord{}, false, fmt.Errorf("failed to replace expired idempotency key: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return models.IdempotencyRecord{}, false, fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if affected == 1 {
+			return models.IdempotencyRecord{}, true, nil
+		}
+
+		existing, err := m.get(ctx, record.Key)
+		if errors.Is(err, ErrIdempotencyKeyNotFound) {
+			continue
+		}
+		return existing, false, err
+	}
+	return models.IdempotencyRecord{}, false, fmt.Errorf("failed to reserve idempotency key %q: too much contention", record.Key)
+}
+
+func (m *MySQLIdempotencyRepository) get(ctx context.Context, key string) (models.IdempotencyRecord, error) {
+	getQuery := `SELECT idempotency_key, fingerprint, completed, status_code, content_type, body, expires_at
+		FROM idempotency_keys WHERE idempotency_key = ? AND expires_at > ?`
+	var record models.IdempotencyRecord
+	err := m.db.QueryRowContext(ctx, getQuery, key, time.Now().UTC()).Scan(&record.Key, &record.Fingerprint, &record.Completed,
+		&record.StatusCode, &record.ContentType, &record.Body, &record.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.IdempotencyRecord{}, ErrIdempotencyKeyNotFound
+		}
+		return models.IdempotencyRecord{}, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return record, nil
+}
+
+func (m *MySQLIdempotencyRepository) Complete(ctx context.Context, record models.IdempotencyRecord) error {
+	completeQuery := `UPDATE idempotency_keys SET completed = TRUE, status_code = ?, content_type = ?, body = ?
+		WHERE idempotency_key = ? AND fingerprint = ?`
+	result, err := m.db.ExecContext(ctx, completeQuery, record.StatusCode, record.ContentType, record.Body, record.Key, record.Fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return ErrIdempotencyKeyNotFound
+	}
+	return nil
+}
+
+func (m *MySQLIdempotencyRepository) Release(ctx context.Context, key string) error {
+	if _, err := m.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE idempotency_key = ?`, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (m *MySQLIdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	result, err := m.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= ?`, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// MemoryIdempotencyRepository keeps records in memory of the process. Retries must reach the same instance
+type MemoryIdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[string]models.IdempotencyRecord
+}
+
+func NewMemoryIdempotencyRepository() *MemoryIdempotencyRepository {
+	return &MemoryIdempotencyRepository{
+		records: make(map[string]models.IdempotencyRecord),
+	}
+}
+
+func (m *MemoryIdempotencyRepository) Reserve(ctx context.Context, record models.IdempotencyRecord) (models.IdempotencyRecord, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.records[record.Key]; ok && existing.ExpiresAt.After(time.Now()) {
+		return existing, false, nil
+	}
+	m.records[record.Key] = models.IdempotencyRecord{Key: record.Key, Fingerprint: record.Fingerprint, ExpiresAt: record.ExpiresAt}
+	return models.IdempotencyRecord{}, true, nil
+}
+
+func (m *MemoryIdempotencyRepository) Complete(ctx context.Context, record models.IdempotencyRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	existing, ok := m.records[record.Key]
+	if !ok || existing.Fingerprint != record.Fingerprint {
+		return ErrIdempotencyKeyNotFound
+	}
+	existing.Completed = true
+	existing.StatusCode = record.StatusCode
+	existing.ContentType = record.ContentType
+	existing.Body = record.Body
+	m.records[record.Key] = existing
+	return nil
+}
+
+func (m *MemoryIdempotencyRepository) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, key)
+	return nil
+}
+
+func (m *MemoryIdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deleted int64
+	now := time.Now()
+	for key, record := range m.records {
+		if !record.ExpiresAt.After(now) {
+			delete(m.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+type LedgerRepository interface {
+	Add(ctx context.Context, entry models.LedgerEntry) (models.LedgerEntry, error)
+	GetByCatId(ctx context.Context, catId int64) ([]models.LedgerEntry, error)
+}
+
+type MySQLLedgerRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLLedgerRepository(db *sql.DB) *MySQLLedgerRepository {
+	return &MySQLLedgerRepository{
+		db: db,
+	}
+}
+
+func (m *MySQLLedgerRepository) Add(ctx context.Context, entry models.LedgerEntry) (models.LedgerEntry, error) {
+	var missionId sql.NullInt64
+	if entry.MissionId != 0 {
+		missionId = sql.NullInt64{Int64: entry.MissionId, Valid: true}
+	}
+	newEntryQuery := `INSERT INTO ledger_entries (cat_id, kind, amount, mission_id, description, posted_at) VALUES (?, ?, ?, ?, ?, ?)`
+	result, err := conn(ctx, m.db).ExecContext(ctx, newEntryQuery, entry.CatId, entry.Kind, entry.Amount, missionId,
+		entry.Description, entry.PostedAt)
+	if err != nil {
+		return models.LedgerEntry{}, fmt.Errorf("failed to add ledger entry: %w", err)
+	}
+	entry.Id, err = result.LastInsertId()
+	if err != nil {
+		return models.LedgerEntry{}, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return entry, nil
+}
+
+// GetByCatId returns ledger of the cat in posting order. Running balance is calculated by the database
+func (m *MySQLLedgerRepository) GetByCatId(ctx context.Context, catId int64) ([]models.LedgerEntry, error) {
+	var entries []models.LedgerEntry
+	getByCatIdQuery := `SELECT id, cat_id, kind, amount, mission_id, description, posted_at,
+		SUM(amount) OVER (ORDER BY posted_at, id) AS balance
+		FROM ledger_entries WHERE cat_id = ? ORDER BY posted_at, id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getByCatIdQuery, catId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var missionId sql.NullInt64
+		e := new(models.LedgerEntry)
+		if err := rows.Scan(&e.Id, &e.CatId, &e.Kind, &e.Amount, &missionId, &e.Description, &e.PostedAt, &e.Balance); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		if missionId.Valid {
+			e.MissionId = missionId.Int64
+		}
+		entries = append(entries, *e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return entries, nil
+}
@@ -5,21 +5,35 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/go-sql-driver/mysql"
 )
 
 var ErrMissionNotFound = errors.New("mission not found")
+var ErrMissionHasHistory = errors.New("mission has assignment history")
+var ErrMissionCompleted = errors.New("mission is already completed")
+var ErrMissionNotAssignable = errors.New("mission is already assigned or completed")
 
 type MissionRepository interface {
 	Add(ctx context.Context, mission models.Mission) (models.Mission, error)
 	GetById(ctx context.Context, id int64) (models.Mission, error)
-	GetAll(ctx context.Context, limit, offset int) ([]models.Mission, error)
+	// GetByIdForUpdate returns the mission and locks it until the end of the transaction of ctx
+	GetByIdForUpdate(ctx context.Context, id int64) (models.Mission, error)
+	GetAll(ctx context.Context, filter models.MissionFilter, limit, offset int) ([]models.Mission, error)
+	GetByCatIds(ctx context.Context, catIds []int64) (map[int64][]models.Mission, error)
+	// ExportTargets calls fn for every target of missions matching the filter while the rows are read from the database
+	ExportTargets(ctx context.Context, filter models.MissionFilter, fn func(models.MissionExportRow) error) error
+	// Assign returns ErrMissionNotAssignable when the mission is assigned or completed
 	Assign(ctx context.Context, missionId, catId int64) error
+	UpdateWindow(ctx context.Context, id int64, startsAt, endsAt *time.Time) error
+	// Complete returns ErrMissionCompleted when the mission was completed before
 	Complete(ctx context.Context, id int64) error
 	Delete(ctx context.Context, id int64) error
 	Exists(ctx context.Context, id int64) error
-	GetCount(ctx context.Context) (int, error)
+	GetCount(ctx context.Context, filter models.MissionFilter) (int, error)
 }
 
 type TxMissionRepository interface {
@@ -39,7 +53,7 @@ func NewMySQLMissionRepository(db *sql.DB) *MySQLMissionRepository {
 }
 
 func (m *MySQLMissionRepository) Add(ctx context.Context, mission models.Mission) (models.Mission, error) {
-	return m.add(ctx, m.db, mission)
+	return m.add(ctx, conn(ctx, m.db), mission)
 }
 
 func (m *MySQLMissionRepository) AddWithTx(ctx context.Context, tx *sql.Tx, mission models.Mission) (models.Mission, error) {
@@ -47,8 +61,8 @@ func (m *MySQLMissionRepository) AddWithTx(ctx context.Context, tx *sql.Tx, miss
 }
 
 func (m *MySQLMissionRepository) add(ctx context.Context, querier Querier, mission models.Mission) (models.Mission, error) {
-	newMissionQuery := `INSERT INTO missions () VALUES ()`
-	result, err := querier.ExecContext(ctx, newMissionQuery)
+	newMissionQuery := `INSERT INTO missions (mission_type, starts_at, ends_at, bonus) VALUES (?, ?, ?, ?)`
+	result, err := querier.ExecContext(ctx, newMissionQuery, mission.Type, mission.StartsAt, mission.EndsAt, mission.Bonus)
 
 	if err != nil {
 		return models.Mission{}, fmt.Errorf("mission insert failed: %w", err)
@@ -61,13 +75,13 @@ func (m *MySQLMissionRepository) add(ctx context.Context, querier Querier, missi
 }
 
 func (m *MySQLMissionRepository) WithTransaction(ctx context.Context, fn func(*sql.Tx) (models.Mission, error)) (models.Mission, error) {
-	tx, err := m.db.BeginTx(ctx, nil)
+	tx, err := beginTx(ctx, m.db)
 	if err != nil {
 		return models.Mission{}, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	mission, err := fn(tx)
+	mission, err := fn(tx.Tx)
 	if err != nil {
 		// don't format error because fn should return formated error
 		return models.Mission{}, err
@@ -80,11 +94,21 @@ func (m *MySQLMissionRepository) WithTransaction(ctx context.Context, fn func(*s
 }
 
 func (m *MySQLMissionRepository) GetById(ctx context.Context, id int64) (models.Mission, error) {
+	getByIdQuery := `SELECT id, cat_id, mission_type, starts_at, ends_at, bonus, completed FROM missions WHERE id = ? ORDER BY id`
+	return m.getById(ctx, getByIdQuery, id)
+}
+
+func (m *MySQLMissionRepository) GetByIdForUpdate(ctx context.Context, id int64) (models.Mission, error) {
+	getByIdQuery := `SELECT id, cat_id, mission_type, starts_at, ends_at, bonus, completed FROM missions WHERE id = ? FOR UPDATE`
+	return m.getById(ctx, getByIdQuery, id)
+}
+
+func (m *MySQLMissionRepository) getById(ctx context.Context, getByIdQuery string, id int64) (models.Mission, error) {
 	var mission models.Mission
 	var tpCatId sql.NullInt64
-	getByIdQuery := `SELECT id, cat_id, completed FROM missions WHERE id = ? ORDER BY id`
-	err := m.db.QueryRowContext(ctx, getByIdQuery, id).
-		Scan(&mission.Id, &tpCatId, &mission.Completed)
+	var startsAt, endsAt sql.NullTime
+	err := conn(ctx, m.db).QueryRowContext(ctx, getByIdQuery, id).
+		Scan(&mission.Id, &tpCatId, &mission.Type, &startsAt, &endsAt, &mission.Bonus, &mission.Completed)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.Mission{}, ErrMissionNotFound
@@ -94,27 +118,80 @@ func (m *MySQLMissionRepository) GetById(ctx context.Context, id int64) (models.
 	if tpCatId.Valid {
 		mission.CatId = tpCatId.Int64
 	}
+	mission.StartsAt = nullTimePtr(startsAt)
+	mission.EndsAt = nullTimePtr(endsAt)
 	return mission, nil
 }
 
-func (m *MySQLMissionRepository) GetAll(ctx context.Context, limit, offset int) ([]models.Mission, error) {
-	var missions []models.Mission
-	getAllQuery := `SELECT id, cat_id, completed FROM missions ORDER BY id LIMIT ? OFFSET ?`
-	rows, err := m.db.QueryContext(ctx, getAllQuery, limit, offset)
+func (m *MySQLMissionRepository) GetAll(ctx context.Context, filter models.MissionFilter, limit, offset int) ([]models.Mission, error) {
+	where, args := missionFilterClause(filter)
+	getAllQuery := `SELECT id, cat_id, mission_type, starts_at, ends_at, bonus, completed FROM missions` + where + ` ORDER BY id LIMIT ? OFFSET ?`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getAllQuery, append(args, limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all missions: %w", err)
 	}
+	return scanMissions(rows)
+}
+
+// GetByCatIds loads missions of several cats with one query and groups them by cat. Like the mission history
+// of a cat, it includes missions the cat was released from
+func (m *MySQLMissionRepository) GetByCatIds(ctx context.Context, catIds []int64) (map[int64][]models.Mission, error) {
+	byCat := make(map[int64][]models.Mission, len(catIds))
+	if len(catIds) == 0 {
+		return byCat, nil
+	}
+	args := make([]any, 0, len(catIds))
+	for _, id := range catIds {
+		args = append(args, id)
+	}
+	getByCatIdsQuery := `SELECT DISTINCT a.cat_id, missions.id, missions.cat_id, missions.mission_type, missions.starts_at,
+		missions.ends_at, missions.bonus, missions.completed
+		FROM missions JOIN mission_assignments a ON a.mission_id = missions.id
+		WHERE a.cat_id IN (` + placeholders(len(args)) + `) ORDER BY missions.id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getByCatIdsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missions by cats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var assignedCatId int64
+		var tpCatId sql.NullInt64
+		var startsAt, endsAt sql.NullTime
+		var ms models.Mission
+		if err := rows.Scan(&assignedCatId, &ms.Id, &tpCatId, &ms.Type, &startsAt, &endsAt, &ms.Bonus, &ms.Completed); err != nil {
+			return nil, fmt.Errorf("scan failed :%w", err)
+		}
+		if tpCatId.Valid {
+			ms.CatId = tpCatId.Int64
+		}
+		ms.StartsAt = nullTimePtr(startsAt)
+		ms.EndsAt = nullTimePtr(endsAt)
+		byCat[assignedCatId] = append(byCat[assignedCatId], ms)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return byCat, nil
+}
+
+// scanMissions reads missions selected with id, cat_id, mission_type, starts_at, ends_at, bonus and completed columns
+func scanMissions(rows *sql.Rows) ([]models.Mission, error) {
+	var missions []models.Mission
 	defer rows.Close()
 
 	for rows.Next() {
 		var tpCatId sql.NullInt64
+		var startsAt, endsAt sql.NullTime
 		ms := new(models.Mission)
-		if err := rows.Scan(&ms.Id, &tpCatId, &ms.Completed); err != nil {
+		if err := rows.Scan(&ms.Id, &tpCatId, &ms.Type, &startsAt, &endsAt, &ms.Bonus, &ms.Completed); err != nil {
 			return nil, fmt.Errorf("scan failed :%w", err)
 		}
 		if tpCatId.Valid {
 			ms.CatId = tpCatId.Int64
 		}
+		ms.StartsAt = nullTimePtr(startsAt)
+		ms.EndsAt = nullTimePtr(endsAt)
 		missions = append(missions, *ms)
 	}
 	if err := rows.Err(); err != nil {
@@ -123,17 +200,90 @@ func (m *MySQLMissionRepository) GetAll(ctx context.Context, limit, offset int)
 	return missions, nil
 }
 
+func (m *MySQLMissionRepository) ExportTargets(ctx context.Context, filter models.MissionFilter, fn func(models.MissionExportRow) error) error {
+	where, args := missionFilterClause(filter)
+	exportQuery := `SELECT missions.id, missions.cat_id, missions.mission_type, missions.starts_at, missions.ends_at, missions.bonus, missions.completed,
+		COALESCE(t.id, 0), COALESCE(t.target_name, ''), COALESCE(t.country, ''), COALESCE(t.country_code, ''), COALESCE(t.notes, ''), COALESCE(t.completed, false)
+		FROM missions LEFT JOIN targets t ON t.mission_id = missions.id` + where + ` ORDER BY missions.id, t.id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, exportQuery, args...)
+	if err != nil {
+		return fmt.Errorf("failed to export missions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tpCatId sql.NullInt64
+		var startsAt, endsAt sql.NullTime
+		var row models.MissionExportRow
+		if err := rows.Scan(&row.MissionId, &tpCatId, &row.Type, &startsAt, &endsAt, &row.Bonus, &row.Completed,
+			&row.TargetId, &row.TargetName, &row.Country, &row.CountryCode, &row.Notes, &row.TargetCompleted); err != nil {
+			return fmt.Errorf("scan failed :%w", err)
+		}
+		if tpCatId.Valid {
+			row.CatId = tpCatId.Int64
+		}
+		row.StartsAt = nullTimePtr(startsAt)
+		row.EndsAt = nullTimePtr(endsAt)
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return nil
+}
+
 func (m *MySQLMissionRepository) Assign(ctx context.Context, missionId, catId int64) error {
 	err := m.Exists(ctx, missionId)
 	if err != nil {
 		return err
 	}
 
-	assignMissionQuery := `UPDATE missions SET cat_id = ? WHERE id = ?`
-	_, err = m.db.ExecContext(ctx, assignMissionQuery, catId, missionId)
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	assignedAt := time.Now().UTC().Truncate(time.Second) // DATETIME column keeps only seconds
+	assignMissionQuery := `UPDATE missions SET cat_id = ?, assigned_at = ? WHERE id = ? AND cat_id IS NULL AND completed = false`
+	result, err := tx.ExecContext(ctx, assignMissionQuery, catId, assignedAt, missionId)
 	if err != nil {
 		return fmt.Errorf("failed to assign mission to a cat: %w", err)
 	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to assign mission to a cat: %w", err)
+	}
+	if affected != 1 {
+		return ErrMissionNotAssignable
+	}
+	newAssignmentQuery := `INSERT INTO mission_assignments (mission_id, cat_id, handler_id, assigned_at)
+		SELECT ?, id, handler_id, ? FROM cats WHERE id = ?`
+	_, err = tx.ExecContext(ctx, newAssignmentQuery, missionId, assignedAt, catId)
+	if err != nil {
+		return fmt.Errorf("failed to add assignment: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (m *MySQLMissionRepository) UpdateWindow(ctx context.Context, id int64, startsAt, endsAt *time.Time) error {
+	err := m.Exists(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	updateWindowQuery := `UPDATE missions SET starts_at = ?, ends_at = ? WHERE id = ?`
+	_, err = conn(ctx, m.db).ExecContext(ctx, updateWindowQuery, startsAt, endsAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to update mission window: %w", err)
+	}
 	return nil
 }
 
@@ -143,13 +293,19 @@ func (m *MySQLMissionRepository) Complete(ctx context.Context, id int64) error {
 		return err
 	}
 
-	completeQuery := `UPDATE missions SET completed = ? where id = ?`
-	_, err = m.db.ExecContext(ctx, completeQuery, true, id)
+	completeQuery := `UPDATE missions SET completed = ?, completed_at = UTC_TIMESTAMP() WHERE id = ? AND completed = false`
+	result, err := conn(ctx, m.db).ExecContext(ctx, completeQuery, true, id)
+	if err != nil {
+		return fmt.Errorf("failed to complete mission: %w", err)
+	}
+	affected, err := result.RowsAffected()
 	if err != nil {
 		return fmt.Errorf("failed to complete mission: %w", err)
 	}
+	if affected != 1 {
+		return ErrMissionCompleted
+	}
 	return nil
-
 }
 
 func (m *MySQLMissionRepository) Delete(ctx context.Context, id int64) error {
@@ -159,8 +315,12 @@ func (m *MySQLMissionRepository) Delete(ctx context.Context, id int64) error {
 	}
 
 	deleteQuery := `DELETE FROM missions WHERE id = ?`
-	_, err = m.db.ExecContext(ctx, deleteQuery, id)
+	_, err = conn(ctx, m.db).ExecContext(ctx, deleteQuery, id)
 	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == errRowIsReferenced {
+			return ErrMissionHasHistory
+		}
 		return fmt.Errorf("failed to delete mission: %w", err)
 	}
 	return nil
@@ -169,7 +329,7 @@ func (m *MySQLMissionRepository) Delete(ctx context.Context, id int64) error {
 func (m *MySQLMissionRepository) Exists(ctx context.Context, id int64) error {
 	var exists bool
 	ExistsQuery := `SELECT EXISTS(SELECT 1 FROM missions WHERE id = ?)`
-	err := m.db.QueryRowContext(ctx, ExistsQuery, id).Scan(&exists)
+	err := conn(ctx, m.db).QueryRowContext(ctx, ExistsQuery, id).Scan(&exists)
 
 	if err != nil {
 		return fmt.Errorf("existence check failed: %w", err)
@@ -180,12 +340,56 @@ func (m *MySQLMissionRepository) Exists(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (m *MySQLMissionRepository) GetCount(ctx context.Context) (int, error) {
+func (m *MySQLMissionRepository) GetCount(ctx context.Context, filter models.MissionFilter) (int, error) {
 	var count int
-	countQuery := "SELECT COUNT(*) FROM missions"
-	err := m.db.QueryRowContext(ctx, countQuery).Scan(&count)
+	where, args := missionFilterClause(filter)
+	countQuery := "SELECT COUNT(*) FROM missions" + where
+	err := conn(ctx, m.db).QueryRowContext(ctx, countQuery, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count cats: %w", err)
 	}
 	return count, nil
 }
+
+// catAssignmentCondition matches missions the cat was ever assigned to, including the ones it was released from.
+// The cat worked on the mission from its assignment until it was released or the mission was completed
+func catAssignmentCondition(filter models.MissionFilter) (string, []any) {
+	conditions := []string{"ca.mission_id = missions.id", "ca.cat_id = ?"}
+	args := []any{filter.CatId}
+	if filter.Completed != nil {
+		// missions the cat was released from are neither open nor completed by the cat
+		conditions = append(conditions, "ca.released_at IS NULL", "missions.completed = ?")
+		args = append(args, *filter.Completed)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "(COALESCE(ca.released_at, missions.completed_at) IS NULL OR COALESCE(ca.released_at, missions.completed_at) >= ?)")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "ca.assigned_at < ?")
+		args = append(args, *filter.To)
+	}
+	return "EXISTS (SELECT 1 FROM mission_assignments ca WHERE " + strings.Join(conditions, " AND ") + ")", args
+}
+
+// missionFilterClause builds WHERE clause and its arguments for the filter
+func missionFilterClause(filter models.MissionFilter) (string, []any) {
+	var conditions []string
+	var args []any
+	if filter.HandlerId != 0 {
+		conditions = append(conditions, "missions.id IN (SELECT a.mission_id FROM mission_assignments a WHERE a.handler_id = ?)")
+		args = append(args, filter.HandlerId)
+	}
+	if filter.CatId != 0 {
+		condition, catArgs := catAssignmentCondition(filter)
+		conditions = append(conditions, condition)
+		args = append(args, catArgs...)
+	} else if filter.Completed != nil {
+		conditions = append(conditions, "missions.completed = ?")
+		args = append(args, *filter.Completed)
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
 )
 
 // wrapper that implements common functions from sql.DB and sql.Tx
@@ -10,3 +12,15 @@ import (
 type Querier interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 }
+
+// placeholders returns n comma separated placeholders for IN clause
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
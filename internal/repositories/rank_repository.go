@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+type RankRepository interface {
+	GetPromotions(ctx context.Context, catId int64) ([]models.Promotion, error)
+	CountCompletedMissions(ctx context.Context, catId int64) (int, error)
+	Promote(ctx context.Context, promotion models.Promotion) error
+	// GetUnranked returns progress of cats that have no rank
+	GetUnranked(ctx context.Context) ([]models.RankProgress, error)
+	SetRank(ctx context.Context, catId int64, rank string) error
+}
+
+type MySQLRankRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLRankRepository(db *sql.DB) *MySQLRankRepository {
+	return &MySQLRankRepository{
+		db: db,
+	}
+}
+
+func (m *MySQLRankRepository) GetPromotions(ctx context.Context, catId int64) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	getPromotionsQuery := `SELECT id, cat_id, from_rank, to_rank, salary_raise, promoted_at FROM promotions
+		WHERE cat_id = ? ORDER BY promoted_at, id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getPromotionsQuery, catId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := new(models.Promotion)
+		if err := rows.Scan(&p.Id, &p.CatId, &p.FromRank, &p.ToRank, &p.SalaryRaise, &p.PromotedAt); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		promotions = append(promotions, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return promotions, nil
+}
+
+func (m *MySQLRankRepository) CountCompletedMissions(ctx context.Context, catId int64) (int, error) {
+	var count int
+	countQuery := "SELECT COUNT(*) FROM missions WHERE cat_id = ? AND completed = true"
+	err := conn(ctx, m.db).QueryRowContext(ctx, countQuery, catId).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count completed missions: %w", err)
+	}
+	return count, nil
+}
+
+// Promote changes rank of the cat and records the promotion. Salary raise of the promotion
+// is recorded as a salary change effective since the promotion
+func (m *MySQLRankRepository) Promote(ctx context.Context, promotion models.Promotion) error {
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	newPromotionQuery := `INSERT INTO promotions (cat_id, from_rank, to_rank, salary_raise, promoted_at) VALUES (?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, newPromotionQuery, promotion.CatId, promotion.FromRank, promotion.ToRank,
+		promotion.SalaryRaise, promotion.PromotedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add promotion: %w", err)
+	}
+	updateRankQuery := "UPDATE cats SET cat_rank = ? where id = ?"
+	_, err = tx.ExecContext(ctx, updateRankQuery, promotion.ToRank, promotion.CatId)
+	if err != nil {
+		return fmt.Errorf("failed to update rank: %w", err)
+	}
+	if promotion.SalaryRaise != 0 {
+		err = raiseSalary(ctx, tx.Tx, promotion)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// raiseSalary adds salary raise of the promotion to the current salary and salary changes scheduled after it
+func raiseSalary(ctx context.Context, tx *sql.Tx, promotion models.Promotion) error {
+	var salary int
+	salaryQuery := "SELECT " + currentSalaryColumn + " FROM cats WHERE id = ? FOR UPDATE"
+	err := tx.QueryRowContext(ctx, salaryQuery, promotion.CatId).Scan(&salary)
+	if err != nil {
+		return fmt.Errorf("failed to get salary: %w", err)
+	}
+	err = addSalaryChange(ctx, tx, models.SalaryChange{
+		CatId:         promotion.CatId,
+		Salary:        salary + promotion.SalaryRaise,
+		EffectiveFrom: promotion.PromotedAt,
+		Reason:        "promotion to " + promotion.ToRank,
+		CreatedAt:     promotion.PromotedAt,
+	})
+	if err != nil {
+		return err
+	}
+	updateSalaryQuery := "UPDATE cats SET salary = ? where id = ?"
+	_, err = tx.ExecContext(ctx, updateSalaryQuery, salary+promotion.SalaryRaise, promotion.CatId)
+	if err != nil {
+		return fmt.Errorf("failed to update salary: %w", err)
+	}
+	// scheduled salary would otherwise cancel the raise when it becomes effective
+	raiseScheduledQuery := "UPDATE salary_changes SET salary = salary + ? WHERE cat_id = ? AND effective_from > ?"
+	_, err = tx.ExecContext(ctx, raiseScheduledQuery, promotion.SalaryRaise, promotion.CatId, promotion.PromotedAt)
+	if err != nil {
+		return fmt.Errorf("failed to raise scheduled salary: %w", err)
+	}
+	return nil
+}
+
+func (m *MySQLRankRepository) GetUnranked(ctx context.Context) ([]models.RankProgress, error) {
+	var unranked []models.RankProgress
+	getUnrankedQuery := `SELECT c.id, c.years_of_experience,
+		(SELECT COUNT(*) FROM missions m WHERE m.cat_id = c.id AND m.completed = true)
+		FROM cats c WHERE c.cat_rank = '' ORDER BY c.id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getUnrankedQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unranked cats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := new(models.RankProgress)
+		if err := rows.Scan(&p.CatId, &p.YearsOfExperience, &p.CompletedMissions); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		unranked = append(unranked, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return unranked, nil
+}
+
+func (m *MySQLRankRepository) SetRank(ctx context.Context, catId int64, rank string) error {
+	setRankQuery := "UPDATE cats SET cat_rank = ? WHERE id = ?"
+	_, err := conn(ctx, m.db).ExecContext(ctx, setRankQuery, rank, catId)
+	if err != nil {
+		return fmt.Errorf("failed to set rank: %w", err)
+	}
+	return nil
+}
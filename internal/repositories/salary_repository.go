@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+type SalaryRepository interface {
+	GetByCatId(ctx context.Context, catId int64) ([]models.SalaryChange, error)
+	GetPayroll(ctx context.Context, from, to time.Time) ([]models.CatPayroll, error)
+}
+
+// salaryCTEs defines salary_segments with periods of a constant salary of every cat and months
+// which starts go from firstMonth until end. Both are SQL expressions and firstMonth must be the start of a month.
+// The CTEs are recursive, so they follow WITH RECURSIVE
+func salaryCTEs(firstMonth, end string) string {
+	return `
+	months (starts_at) AS (
+		SELECT ` + firstMonth + `
+		UNION ALL SELECT starts_at + INTERVAL 1 MONTH FROM months WHERE starts_at + INTERVAL 1 MONTH < ` + end + `),
+	salary_segments AS (
+		SELECT cat_id, salary, effective_from AS starts_at,
+			LEAD(effective_from) OVER (PARTITION BY cat_id ORDER BY effective_from, id) AS ends_at
+		FROM salary_changes)`
+}
+
+// salaryCostQuery sums salary paid to cats during periods selected by periodsQuery with cat_id, starts_at and ends_at columns.
+// Salary is monthly, so every second of a period is paid as a share of the month it belongs to.
+// The query uses CTEs of salaryCTEs, which months must cover the periods
+func salaryCostQuery(periodsQuery string) string {
+	start := "GREATEST(s.starts_at, p.starts_at, mo.starts_at)"
+	end := "LEAST(COALESCE(s.ends_at, p.ends_at), p.ends_at, mo.starts_at + INTERVAL 1 MONTH)"
+	return `SELECT p.cat_id, SUM(s.salary * TIMESTAMPDIFF(SECOND, ` + start + `, ` + end + `)
+			/ TIMESTAMPDIFF(SECOND, mo.starts_at, mo.starts_at + INTERVAL 1 MONTH)) AS amount
+		FROM (` + periodsQuery + `) p
+		JOIN salary_segments s ON s.cat_id = p.cat_id AND s.starts_at < p.ends_at AND (s.ends_at IS NULL OR s.ends_at > p.starts_at)
+		JOIN months mo ON mo.starts_at < p.ends_at AND mo.starts_at + INTERVAL 1 MONTH > p.starts_at
+		WHERE ` + start + ` < ` + end + `
+		GROUP BY p.cat_id`
+}
+
+type MySQLSalaryRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLSalaryRepository(db *sql.DB) *MySQLSalaryRepository {
+	return &MySQLSalaryRepository{
+		db: db,
+	}
+}
+
+func addSalaryChange(ctx context.Context, querier Querier, change models.SalaryChange) error {
+	newChangeQuery := `INSERT INTO salary_changes (cat_id, salary, effective_from, reason, created_at) VALUES (?, ?, ?, ?, ?)`
+	_, err := querier.ExecContext(ctx, newChangeQuery, change.CatId, change.Salary, change.EffectiveFrom, change.Reason, change.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add salary change: %w", err)
+	}
+	return nil
+}
+
+// GetByCatId returns all salary changes of the cat including future ones ordered by effective date
+func (m *MySQLSalaryRepository) GetByCatId(ctx context.Context, catId int64) ([]models.SalaryChange, error) {
+	getByCatIdQuery := `SELECT id, cat_id, salary, effective_from, reason, created_at FROM salary_changes
+		WHERE cat_id = ? ORDER BY effective_from, id`
+	return m.query(ctx, getByCatIdQuery, catId)
+}
+
+// GetPayroll returns salary paid to every cat during the period [from, to) ordered by cat
+func (m *MySQLSalaryRepository) GetPayroll(ctx context.Context, from, to time.Time) ([]models.CatPayroll, error) {
+	var payroll []models.CatPayroll
+	firstMonth := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	payrollQuery := "WITH RECURSIVE" + salaryCTEs("CAST(? AS DATETIME)", "?") + `
+	SELECT cats.id, cats.cat_name, COALESCE(cost.amount, 0) FROM cats
+	LEFT JOIN (` + salaryCostQuery("SELECT id AS cat_id, CAST(? AS DATETIME) AS starts_at, CAST(? AS DATETIME) AS ends_at FROM cats") + `) cost
+	ON cost.cat_id = cats.id ORDER BY cats.id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, payrollQuery, firstMonth, to, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payroll: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := new(models.CatPayroll)
+		if err := rows.Scan(&p.CatId, &p.Name, &p.Amount); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		payroll = append(payroll, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return payroll, nil
+}
+
+func (m *MySQLSalaryRepository) query(ctx context.Context, query string, args ...any) ([]models.SalaryChange, error) {
+	var changes []models.SalaryChange
+	rows, err := conn(ctx, m.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get salary changes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		c := new(models.SalaryChange)
+		if err := rows.Scan(&c.Id, &c.CatId, &c.Salary, &c.EffectiveFrom, &c.Reason, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		changes = append(changes, *c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return changes, nil
+}
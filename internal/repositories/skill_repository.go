@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/go-sql-driver/mysql"
+)
+
+var ErrSkillNotFound = errors.New("skill not found")
+var ErrSkillExists = errors.New("skill already exists")
+
+// duplicate entry error code of MySQL
+const errDuplicateEntry = 1062
+
+type SkillRepository interface {
+	Add(ctx context.Context, skill models.Skill) (models.Skill, error)
+	GetAll(ctx context.Context) ([]models.Skill, error)
+	GetCatSkills(ctx context.Context, catId int64) ([]models.CatSkill, error)
+	SetCatSkills(ctx context.Context, catId int64, skills []models.CatSkill) error
+}
+
+type MySQLSkillRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLSkillRepository(db *sql.DB) *MySQLSkillRepository {
+	return &MySQLSkillRepository{
+		db: db,
+	}
+}
+
+func (m *MySQLSkillRepository) Add(ctx context.Context, skill models.Skill) (models.Skill, error) {
+	newSkillQuery := `INSERT INTO skills (skill_name, description) VALUES (?, ?)`
+	result, err := conn(ctx, m.db).ExecContext(ctx, newSkillQuery, skill.Name, skill.Description)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry {
+			return models.Skill{}, ErrSkillExists
+		}
+		return models.Skill{}, fmt.Errorf("failed to add new skill: %w", err)
+	}
+	skill.Id, err = result.LastInsertId()
+	if err != nil {
+		return models.Skill{}, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return skill, nil
+}
+
+func (m *MySQLSkillRepository) GetAll(ctx context.Context) ([]models.Skill, error) {
+	var skills []models.Skill
+	getAllQuery := `SELECT id, skill_name, description FROM skills ORDER BY skill_name`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getAllQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all skills: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s := new(models.Skill)
+		if err := rows.Scan(&s.Id, &s.Name, &s.Description); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		skills = append(skills, *s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return skills, nil
+}
+
+func (m *MySQLSkillRepository) GetCatSkills(ctx context.Context, catId int64) ([]models.CatSkill, error) {
+	var skills []models.CatSkill
+	getCatSkillsQuery := `SELECT s.skill_name, cs.proficiency FROM cat_skills cs
+		JOIN skills s ON s.id = cs.skill_id WHERE cs.cat_id = ? ORDER BY s.skill_name`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getCatSkillsQuery, catId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cat skills: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s := new(models.CatSkill)
+		if err := rows.Scan(&s.Skill, &s.Proficiency); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		skills = append(skills, *s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return skills, nil
+}
+
+// SetCatSkills replaces all skills of the cat
+func (m *MySQLSkillRepository) SetCatSkills(ctx context.Context, catId int64, skills []models.CatSkill) error {
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleteQuery := `DELETE FROM cat_skills WHERE cat_id = ?`
+	_, err = tx.ExecContext(ctx, deleteQuery, catId)
+	if err != nil {
+		return fmt.Errorf("failed to delete cat skills: %w", err)
+	}
+	addSkillQuery := `INSERT INTO cat_skills (cat_id, skill_id, proficiency) SELECT ?, id, ? FROM skills WHERE skill_name = ?`
+	for _, s := range skills {
+		result, err := tx.ExecContext(ctx, addSkillQuery, catId, s.Proficiency, s.Skill)
+		if err != nil {
+			return fmt.Errorf("failed to add cat skill: %w", err)
+		}
+		added, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if added == 0 {
+			return fmt.Errorf("%w: %s", ErrSkillNotFound, s.Skill)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// addTargetRequirements stores skills required by the target. Skills are referenced by name
+func addTargetRequirements(ctx context.Context, querier Querier, targetId int64, requirements []models.SkillRequirement) error {
+	addRequirementQuery := `INSERT INTO target_skills (target_id, skill_id, min_proficiency) SELECT ?, id, ? FROM skills WHERE skill_name = ?`
+	for _, r := range requirements {
+		result, err := querier.ExecContext(ctx, addRequirementQuery, targetId, r.MinProficiency, r.Skill)
+		if err != nil {
+			return fmt.Errorf("failed to add target skill: %w", err)
+		}
+		added, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if added == 0 {
+			return fmt.Errorf("%w: %s", ErrSkillNotFound, r.Skill)
+		}
+	}
+	return nil
+}
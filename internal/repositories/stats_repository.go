@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+// catStatsQuery aggregates missions and targets of every cat. Rate and duration are calculated in the database
+// to not load missions into memory. Missions the cat was released from are finished but not completed
+const catStatsQuery = `SELECT c.id, c.cat_name,
+		COUNT(m.id) + COALESCE(MAX(ra.released_missions), 0) AS assigned_missions,
+		COALESCE(SUM(m.completed), 0) AS completed_missions,
+		COALESCE(MAX(ts.completed_targets), 0) AS completed_targets,
+		COALESCE(SUM(m.completed) / NULLIF(COALESCE(SUM(m.completed), 0) + COALESCE(MAX(ra.released_missions), 0), 0), 0) AS success_rate,
+		COALESCE(AVG(CASE WHEN m.completed THEN TIMESTAMPDIFF(SECOND, m.assigned_at, m.completed_at) END), 0) / 3600 AS average_hours
+	FROM cats c
+	LEFT JOIN missions m ON m.cat_id = c.id
+	LEFT JOIN (SELECT cat_id, COUNT(*) AS released_missions FROM mission_assignments
+		WHERE released_at IS NOT NULL GROUP BY cat_id) ra ON ra.cat_id = c.id
+	LEFT JOIN (SELECT ms.cat_id, COUNT(*) AS completed_targets FROM targets t
+		JOIN missions ms ON ms.id = t.mission_id WHERE t.completed = true GROUP BY ms.cat_id) ts ON ts.cat_id = c.id`
+
+type StatsRepository interface {
+	GetCatStats(ctx context.Context, catId int64) (models.CatStats, error)
+	GetLeaderboard(ctx context.Context, limit int) ([]models.CatStats, error)
+	// GetSalaryCosts returns salary cost per completed mission of the cats that have such missions
+	GetSalaryCosts(ctx context.Context, catIds []int64) (map[int64]float64, error)
+}
+
+type MySQLStatsRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLStatsRepository(db *sql.DB) *MySQLStatsRepository {
+	return &MySQLStatsRepository{
+		db: db,
+	}
+}
+
+func (m *MySQLStatsRepository) GetCatStats(ctx context.Context, catId int64) (models.CatStats, error) {
+	var s models.CatStats
+	getCatStatsQuery := catStatsQuery + " WHERE c.id = ? GROUP BY c.id, c.cat_name"
+	err := conn(ctx, m.db).QueryRowContext(ctx, getCatStatsQuery, catId).Scan(&s.CatId, &s.Name, &s.AssignedMissions,
+		&s.CompletedMissions, &s.CompletedTargets, &s.SuccessRate, &s.AverageMissionHours)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.CatStats{}, ErrCatNotFound
+		}
+		return models.CatStats{}, fmt.Errorf("failed to get cat stats: %w", err)
+	}
+	return s, nil
+}
+
+// GetLeaderboard returns stats of the best cats ordered by completed missions, success rate and completed targets
+func (m *MySQLStatsRepository) GetLeaderboard(ctx context.Context, limit int) ([]models.CatStats, error) {
+	var stats []models.CatStats
+	leaderboardQuery := catStatsQuery + ` GROUP BY c.id, c.cat_name
+		ORDER BY completed_missions DESC, success_rate DESC, completed_targets DESC, c.id LIMIT ?`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, leaderboardQuery, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s := new(models.CatStats)
+		if err := rows.Scan(&s.CatId, &s.Name, &s.AssignedMissions, &s.CompletedMissions, &s.CompletedTargets,
+			&s.SuccessRate, &s.AverageMissionHours); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		stats = append(stats, *s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return stats, nil
+}
+
+// GetSalaryCosts returns average salary paid to every cat while it was working on a completed mission.
+// Missions completed before assignment and completion times were tracked are skipped
+func (m *MySQLStatsRepository) GetSalaryCosts(ctx context.Context, catIds []int64) (map[int64]float64, error) {
+	if len(catIds) == 0 {
+		return nil, nil
+	}
+	args := make([]any, 0, len(catIds))
+	for _, id := range catIds {
+		args = append(args, id)
+	}
+	costsQuery := `WITH RECURSIVE
+	mission_periods AS (
+		SELECT cat_id, assigned_at AS starts_at, completed_at AS ends_at FROM missions
+		WHERE completed = true AND assigned_at IS NOT NULL AND completed_at IS NOT NULL
+		AND cat_id IN (` + placeholders(len(catIds)) + `)),` +
+		salaryCTEs("(SELECT CAST(DATE_FORMAT(MIN(starts_at), '%Y-%m-01') AS DATETIME) FROM mission_periods)",
+			"(SELECT MAX(ends_at) FROM mission_periods)") + `
+	SELECT periods.cat_id, COALESCE(cost.amount, 0) / periods.missions
+	FROM (SELECT cat_id, COUNT(*) AS missions FROM mission_periods GROUP BY cat_id) periods
+	LEFT JOIN (` + salaryCostQuery("SELECT cat_id, starts_at, ends_at FROM mission_periods") + `) cost ON cost.cat_id = periods.cat_id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, costsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get salary costs: %w", err)
+	}
+	defer rows.Close()
+
+	costs := make(map[int64]float64, len(catIds))
+	for rows.Next() {
+		var catId int64
+		var cost float64
+		if err := rows.Scan(&catId, &cost); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		costs[catId] = cost
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return costs, nil
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+type StatusRepository interface {
+	GetByCatId(ctx context.Context, catId int64) ([]models.StatusChange, error)
+}
+
+type MySQLStatusRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLStatusRepository(db *sql.DB) *MySQLStatusRepository {
+	return &MySQLStatusRepository{
+		db: db,
+	}
+}
+
+func addStatusChange(ctx context.Context, querier Querier, change models.StatusChange) error {
+	newChangeQuery := `INSERT INTO status_changes (cat_id, status, effective_from, reason, created_at) VALUES (?, ?, ?, ?, ?)`
+	_, err := querier.ExecContext(ctx, newChangeQuery, change.CatId, change.Status, change.EffectiveFrom, change.Reason, change.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add status change: %w", err)
+	}
+	return nil
+}
+
+// GetByCatId returns all status changes of the cat ordered by effective date
+func (m *MySQLStatusRepository) GetByCatId(ctx context.Context, catId int64) ([]models.StatusChange, error) {
+	var changes []models.StatusChange
+	getByCatIdQuery := `SELECT id, cat_id, status, effective_from, reason, created_at FROM status_changes
+		WHERE cat_id = ? ORDER BY effective_from, id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getByCatIdQuery, catId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status changes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		c := new(models.StatusChange)
+		if err := rows.Scan(&c.Id, &c.CatId, &c.Status, &c.EffectiveFrom, &c.Reason, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		changes = append(changes, *c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return changes, nil
+}
@@ -14,11 +14,14 @@ var ErrTargetNotFound = errors.New("target not found")
 type TargetRepository interface {
 	Add(ctx context.Context, target models.Target) (models.Target, error)
 	GetByMissionId(ctx context.Context, id int64) ([]models.Target, error)
+	GetByMissionIds(ctx context.Context, ids []int64) (map[int64][]models.Target, error)
 	GetById(ctx context.Context, id int64) (models.Target, error)
 	Complete(ctx context.Context, id int64) error
 	Update(ctx context.Context, id int64, update models.TargetUpdate) error
 	Delete(ctx context.Context, id int64) error
 	Exists(ctx context.Context, id int64) error
+	GetCountryUsage(ctx context.Context) (map[string]int, error)
+	UpdateCountry(ctx context.Context, from string, country, countryCode string) (int64, error)
 }
 
 type TxTargetRepository interface {
@@ -37,7 +40,7 @@ func NewMySQLTargetRepository(db *sql.DB) *MySQLTargetRepository {
 }
 
 func (m *MySQLTargetRepository) Add(ctx context.Context, target models.Target) (models.Target, error) {
-	return m.add(ctx, m.db, target)
+	return m.add(ctx, conn(ctx, m.db), target)
 }
 
 func (m *MySQLTargetRepository) AddWithTx(ctx context.Context, tx *sql.Tx, target models.Target) (models.Target, error) {
@@ -45,8 +48,8 @@ func (m *MySQLTargetRepository) AddWithTx(ctx context.Context, tx *sql.Tx, targe
 }
 
 func (m *MySQLTargetRepository) add(ctx context.Context, querier Querier, target models.Target) (models.Target, error) {
-	createTargetQuery := `INSERT INTO targets (mission_id, target_name, country, notes) VALUES (?, ?, ?, ?)`
-	result, err := querier.ExecContext(ctx, createTargetQuery, target.MissionId, target.Name, target.Country, target.Notes)
+	createTargetQuery := `INSERT INTO targets (mission_id, target_name, country, country_code, notes) VALUES (?, ?, ?, ?, ?)`
+	result, err := querier.ExecContext(ctx, createTargetQuery, target.MissionId, target.Name, target.Country, target.CountryCode, target.Notes)
 	if err != nil {
 		return models.Target{}, fmt.Errorf("failed to add new target: %w", err)
 	}
@@ -55,13 +58,17 @@ func (m *MySQLTargetRepository) add(ctx context.Context, querier Querier, target
 	if err != nil {
 		return models.Target{}, fmt.Errorf("failed to get last insert id: %w", err)
 	}
+	err = addTargetRequirements(ctx, querier, target.Id, target.RequiredSkills)
+	if err != nil {
+		return models.Target{}, err
+	}
 	return target, nil
 }
 
 func (m *MySQLTargetRepository) GetByMissionId(ctx context.Context, id int64) ([]models.Target, error) {
 	var targets []models.Target
-	getByMissionIdQuery := `SELECT id, mission_id, target_name, country, notes, completed FROM targets WHERE mission_id = ? ORDER BY id`
-	rows, err := m.db.QueryContext(ctx, getByMissionIdQuery, id)
+	getByMissionIdQuery := `SELECT id, mission_id, target_name, country, COALESCE(country_code, ''), notes, completed FROM targets WHERE mission_id = ? ORDER BY id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getByMissionIdQuery, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get targets by mission: %w", err)
 	}
@@ -69,7 +76,7 @@ func (m *MySQLTargetRepository) GetByMissionId(ctx context.Context, id int64) ([
 
 	for rows.Next() {
 		t := new(models.Target)
-		if err := rows.Scan(&t.Id, &t.MissionId, &t.Name, &t.Country, &t.Notes, &t.Completed); err != nil {
+		if err := rows.Scan(&t.Id, &t.MissionId, &t.Name, &t.Country, &t.CountryCode, &t.Notes, &t.Completed); err != nil {
 			return nil, fmt.Errorf("scan failed: %w", err)
 		}
 		targets = append(targets, *t)
@@ -78,21 +85,104 @@ func (m *MySQLTargetRepository) GetByMissionId(ctx context.Context, id int64) ([
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration failed: %w", err)
 	}
+	err = m.loadRequirements(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
 	return targets, nil
 }
 
+// GetByMissionIds loads targets of several missions with one query and groups them by mission
+func (m *MySQLTargetRepository) GetByMissionIds(ctx context.Context, ids []int64) (map[int64][]models.Target, error) {
+	byMission := make(map[int64][]models.Target, len(ids))
+	if len(ids) == 0 {
+		return byMission, nil
+	}
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	var targets []models.Target
+	getByMissionIdsQuery := `SELECT id, mission_id, target_name, country, COALESCE(country_code, ''), notes, completed FROM targets
+		WHERE mission_id IN (` + placeholders(len(args)) + `) ORDER BY id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getByMissionIdsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get targets by missions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t := new(models.Target)
+		if err := rows.Scan(&t.Id, &t.MissionId, &t.Name, &t.Country, &t.CountryCode, &t.Notes, &t.Completed); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		targets = append(targets, *t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	err = m.loadRequirements(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range targets {
+		byMission[t.MissionId] = append(byMission[t.MissionId], t)
+	}
+	return byMission, nil
+}
+
 func (m *MySQLTargetRepository) GetById(ctx context.Context, id int64) (models.Target, error) {
 	var t models.Target
-	getByIdQuery := `SELECT id, mission_id, target_name, country, notes, completed FROM targets WHERE id = ?`
-	err := m.db.QueryRowContext(ctx, getByIdQuery, id).
-		Scan(&t.Id, &t.MissionId, &t.Name, &t.Country, &t.Notes, &t.Completed)
+	getByIdQuery := `SELECT id, mission_id, target_name, country, COALESCE(country_code, ''), notes, completed FROM targets WHERE id = ?`
+	err := conn(ctx, m.db).QueryRowContext(ctx, getByIdQuery, id).
+		Scan(&t.Id, &t.MissionId, &t.Name, &t.Country, &t.CountryCode, &t.Notes, &t.Completed)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.Target{}, ErrTargetNotFound
 		}
 		return models.Target{}, fmt.Errorf("failed to get target by id: %w", err)
 	}
-	return t, nil
+	targets := []models.Target{t}
+	err = m.loadRequirements(ctx, targets)
+	if err != nil {
+		return models.Target{}, err
+	}
+	return targets[0], nil
+}
+
+// loadRequirements sets required skills of all targets with a single query
+func (m *MySQLTargetRepository) loadRequirements(ctx context.Context, targets []models.Target) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	byId := make(map[int64]*models.Target, len(targets))
+	args := make([]any, 0, len(targets))
+	for i := range targets {
+		byId[targets[i].Id] = &targets[i]
+		args = append(args, targets[i].Id)
+	}
+	requirementsQuery := `SELECT ts.target_id, s.skill_name, ts.min_proficiency FROM target_skills ts
+		JOIN skills s ON s.id = ts.skill_id WHERE ts.target_id IN (` + placeholders(len(args)) + `) ORDER BY s.skill_name`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, requirementsQuery, args...)
+	if err != nil {
+		return fmt.Errorf("failed to get target skills: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var targetId int64
+		var r models.SkillRequirement
+		if err := rows.Scan(&targetId, &r.Skill, &r.MinProficiency); err != nil {
+			return fmt.Errorf("scan failed: %w", err)
+		}
+		byId[targetId].RequiredSkills = append(byId[targetId].RequiredSkills, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return nil
 }
 
 func (m *MySQLTargetRepository) Complete(ctx context.Context, id int64) error {
@@ -102,7 +192,7 @@ func (m *MySQLTargetRepository) Complete(ctx context.Context, id int64) error {
 	}
 
 	completeQuery := `UPDATE targets SET completed = 1 WHERE id = ?`
-	_, err = m.db.ExecContext(ctx, completeQuery, id)
+	_, err = conn(ctx, m.db).ExecContext(ctx, completeQuery, id)
 	if err != nil {
 		return fmt.Errorf("failed to complete target: %w", err)
 	}
@@ -117,7 +207,7 @@ func (m *MySQLTargetRepository) Update(ctx context.Context, id int64, update mod
 	}
 
 	updateQuery := `UPDATE targets SET notes = ? where id = ?`
-	_, err = m.db.ExecContext(ctx, updateQuery, update.Notes, id)
+	_, err = conn(ctx, m.db).ExecContext(ctx, updateQuery, update.Notes, id)
 	if err != nil {
 		return fmt.Errorf("failed to update target: %w", err)
 	}
@@ -131,7 +221,7 @@ func (m *MySQLTargetRepository) Delete(ctx context.Context, id int64) error {
 	}
 
 	deleteQuery := `DELETE FROM targets WHERE id = ?`
-	_, err = m.db.ExecContext(ctx, deleteQuery, id)
+	_, err = conn(ctx, m.db).ExecContext(ctx, deleteQuery, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete target: %w", err)
 	}
@@ -142,7 +232,7 @@ func (m *MySQLTargetRepository) Delete(ctx context.Context, id int64) error {
 func (m *MySQLTargetRepository) Exists(ctx context.Context, id int64) error {
 	var exists bool
 	catExistsQuery := "SELECT EXISTS (SELECT 1 FROM targets WHERE id = ?)"
-	err := m.db.QueryRowContext(ctx, catExistsQuery, id).Scan(&exists)
+	err := conn(ctx, m.db).QueryRowContext(ctx, catExistsQuery, id).Scan(&exists)
 
 	if err != nil {
 		return fmt.Errorf("existence check failed: %w", err)
@@ -154,3 +244,42 @@ func (m *MySQLTargetRepository) Exists(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+// GetCountryUsage returns every distinct country value stored in targets with the number of targets using it
+func (m *MySQLTargetRepository) GetCountryUsage(ctx context.Context) (map[string]int, error) {
+	usage := make(map[string]int)
+	usageQuery := `SELECT country, COUNT(*) FROM targets GROUP BY country`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, usageQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get country usage: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var country string
+		var count int
+		if err := rows.Scan(&country, &count); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		usage[country] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return usage, nil
+}
+
+// UpdateCountry replaces country value in all targets and returns number of updated rows
+func (m *MySQLTargetRepository) UpdateCountry(ctx context.Context, from string, country, countryCode string) (int64, error) {
+	updateQuery := `UPDATE targets SET country = ?, country_code = ? WHERE country = ?`
+	result, err := conn(ctx, m.db).ExecContext(ctx, updateQuery, country, countryCode, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update country: %w", err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return updated, nil
+}
@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+var ErrTemplateNotFound = errors.New("template not found")
+
+type TemplateRepository interface {
+	Add(ctx context.Context, template models.MissionTemplate) (models.MissionTemplate, error)
+	GetById(ctx context.Context, id int64) (models.MissionTemplate, error)
+	GetAll(ctx context.Context, limit, offset int) ([]models.MissionTemplate, error)
+	Update(ctx context.Context, template models.MissionTemplate) (models.MissionTemplate, error)
+	Delete(ctx context.Context, id int64) error
+	Exists(ctx context.Context, id int64) error
+	GetCount(ctx context.Context) (int, error)
+}
+
+type MySQLTemplateRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLTemplateRepository(db *sql.DB) *MySQLTemplateRepository {
+	return &MySQLTemplateRepository{
+		db: db,
+	}
+}
+
+func (m *MySQLTemplateRepository) Add(ctx context.Context, template models.MissionTemplate) (models.MissionTemplate, error) {
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return models.MissionTemplate{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	newTemplateQuery := `INSERT INTO mission_templates (template_name, mission_type) VALUES (?, ?)`
+	result, err := tx.ExecContext(ctx, newTemplateQuery, template.Name, template.Type)
+	if err != nil {
+		return models.MissionTemplate{}, fmt.Errorf("template insert failed: %w", err)
+	}
+	template.Id, err = result.LastInsertId()
+	if err != nil {
+		return models.MissionTemplate{}, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	template.Targets, err = m.addTargets(ctx, tx, template.Id, template.Targets)
+	if err != nil {
+		return models.MissionTemplate{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.MissionTemplate{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return template, nil
+}
+
+func (m *MySQLTemplateRepository) addTargets(ctx context.Context, querier Querier, templateId int64, targets []models.TemplateTarget) ([]models.TemplateTarget, error) {
+	newTargetQuery := `INSERT INTO template_targets (template_id, target_name, country, country_code, notes) VALUES (?, ?, ?, ?, ?)`
+	saved := make([]models.TemplateTarget, 0, len(targets))
+	for _, t := range targets {
+		result, err := querier.ExecContext(ctx, newTargetQuery, templateId, t.Name, t.Country, t.CountryCode, t.Notes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add template target: %w", err)
+		}
+		t.Id, err = result.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get last insert id: %w", err)
+		}
+		t.TemplateId = templateId
+		saved = append(saved, t)
+	}
+	return saved, nil
+}
+
+func (m *MySQLTemplateRepository) GetById(ctx context.Context, id int64) (models.MissionTemplate, error) {
+	var template models.MissionTemplate
+	getByIdQuery := `SELECT id, template_name, mission_type FROM mission_templates WHERE id = ?`
+	err := conn(ctx, m.db).QueryRowContext(ctx, getByIdQuery, id).
+		Scan(&template.Id, &template.Name, &template.Type)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.MissionTemplate{}, ErrTemplateNotFound
+		}
+		return models.MissionTemplate{}, fmt.Errorf("failed to get template by id: %w", err)
+	}
+
+	template.Targets, err = m.getTargets(ctx, id)
+	if err != nil {
+		return models.MissionTemplate{}, err
+	}
+	return template, nil
+}
+
+func (m *MySQLTemplateRepository) getTargets(ctx context.Context, templateId int64) ([]models.TemplateTarget, error) {
+	var targets []models.TemplateTarget
+	getTargetsQuery := `SELECT id, template_id, target_name, country, country_code, notes FROM template_targets WHERE template_id = ? ORDER BY id`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getTargetsQuery, templateId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template targets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t := new(models.TemplateTarget)
+		if err := rows.Scan(&t.Id, &t.TemplateId, &t.Name, &t.Country, &t.CountryCode, &t.Notes); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		targets = append(targets, *t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+	return targets, nil
+}
+
+func (m *MySQLTemplateRepository) GetAll(ctx context.Context, limit, offset int) ([]models.MissionTemplate, error) {
+	var templates []models.MissionTemplate
+	getAllQuery := `SELECT id, template_name, mission_type FROM mission_templates ORDER BY id LIMIT ? OFFSET ?`
+	rows, err := conn(ctx, m.db).QueryContext(ctx, getAllQuery, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all templates: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t := new(models.MissionTemplate)
+		if err := rows.Scan(&t.Id, &t.Name, &t.Type); err != nil {
+			return nil, fmt.Errorf("scan failed :%w", err)
+		}
+		templates = append(templates, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration failed: %w", err)
+	}
+
+	for i := range templates {
+		templates[i].Targets, err = m.getTargets(ctx, templates[i].Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return templates, nil
+}
+
+// Update replaces name, type and all targets of the template
+func (m *MySQLTemplateRepository) Update(ctx context.Context, template models.MissionTemplate) (models.MissionTemplate, error) {
+	err := m.Exists(ctx, template.Id)
+	if err != nil {
+		return models.MissionTemplate{}, err
+	}
+
+	tx, err := beginTx(ctx, m.db)
+	if err != nil {
+		return models.MissionTemplate{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	updateQuery := `UPDATE mission_templates SET template_name = ?, mission_type = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, updateQuery, template.Name, template.Type, template.Id)
+	if err != nil {
+		return models.MissionTemplate{}, fmt.Errorf("failed to update template: %w", err)
+	}
+	deleteTargetsQuery := `DELETE FROM template_targets WHERE template_id = ?`
+	_, err = tx.ExecContext(ctx, deleteTargetsQuery, template.Id)
+	if err != nil {
+		return models.MissionTemplate{}, fmt.Errorf("failed to delete template targets: %w", err)
+	}
+	template.Targets, err = m.addTargets(ctx, tx, template.Id, template.Targets)
+	if err != nil {
+		return models.MissionTemplate{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.MissionTemplate{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return template, nil
+}
+
+func (m *MySQLTemplateRepository) Delete(ctx context.Context, id int64) error {
+	err := m.Exists(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	deleteQuery := `DELETE FROM mission_templates WHERE id = ?`
+	_, err = conn(ctx, m.db).ExecContext(ctx, deleteQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	return nil
+}
+
+func (m *MySQLTemplateRepository) Exists(ctx context.Context, id int64) error {
+	var exists bool
+	existsQuery := `SELECT EXISTS(SELECT 1 FROM mission_templates WHERE id = ?)`
+	err := conn(ctx, m.db).QueryRowContext(ctx, existsQuery, id).Scan(&exists)
+
+	if err != nil {
+		return fmt.Errorf("existence check failed: %w", err)
+	}
+	if !exists {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+func (m *MySQLTemplateRepository) GetCount(ctx context.Context) (int, error) {
+	var count int
+	countQuery := "SELECT COUNT(*) FROM mission_templates"
+	err := conn(ctx, m.db).QueryRowContext(ctx, countQuery).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count templates: %w", err)
+	}
+	return count, nil
+}
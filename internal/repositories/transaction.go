@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Transactor runs several calls of repositories as one unit. Repositories called with the context passed
+// to fn run their queries in a single database transaction, which is committed only when fn succeeds
+type Transactor interface {
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type MySQLTransactor struct {
+	db *sql.DB
+}
+
+func NewMySQLTransactor(db *sql.DB) *MySQLTransactor {
+	return &MySQLTransactor{
+		db: db,
+	}
+}
+
+// InTransaction joins the transaction of ctx when there is one. Transactions read committed data,
+// so rows read after a lock is acquired include changes of the transaction that held the lock
+func (m *MySQLTransactor) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		// don't format error because fn returns errors of services
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+type txKey struct{}
+
+// dbConn is implemented by sql.DB and sql.Tx
+type dbConn interface {
+	Querier
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction that ctx runs in or db when there is none
+func conn(ctx context.Context, db *sql.DB) dbConn {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// repoTx is a transaction of a single repository method. Inside a transaction of Transactor the method joins it,
+// so Commit and Rollback are left to the outer transaction that fails together with the method
+type repoTx struct {
+	*sql.Tx
+	joined bool
+}
+
+func beginTx(ctx context.Context, db *sql.DB) (*repoTx, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return &repoTx{Tx: tx, joined: true}, nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &repoTx{Tx: tx}, nil
+}
+
+func (r *repoTx) Commit() error {
+	if r.joined {
+		return nil
+	}
+	return r.Tx.Commit()
+}
+
+func (r *repoTx) Rollback() error {
+	if r.joined {
+		return nil
+	}
+	return r.Tx.Rollback()
+}
@@ -1,184 +1,1007 @@
 package spycatagency
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
+	"mime"
 	"net/http"
+	"path"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/4oBuko/spy-cat-agency/internal/graph"
 	"github.com/4oBuko/spy-cat-agency/internal/models"
 	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
 	"github.com/4oBuko/spy-cat-agency/internal/services"
 	"github.com/4oBuko/spy-cat-agency/pkg/catapi"
+	"github.com/4oBuko/spy-cat-agency/pkg/mergepatch"
+	"github.com/4oBuko/spy-cat-agency/pkg/openapi"
+	"github.com/4oBuko/spy-cat-agency/pkg/xlsx"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
+// PhotoBaseURL is the path under which stored photos and thumbnails are served
+const PhotoBaseURL = "/photos"
+
+// API routes are served under version prefixes. Endpoints hold paths without the prefix
+const (
+	APIV1 = "/v1"
+	APIV2 = "/v2"
+)
+
+// Routes without version prefix are deprecated aliases of v1 routes and will be removed after UnversionedSunset
+var (
+	UnversionedDeprecation = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	UnversionedSunset      = time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+)
+
+const apiVersionKey = "apiVersion"
+
+// maxBodySize caps request bodies that middlewares read into memory. Files are not read by middlewares and are limited by their handlers
+const maxBodySize = 1 << 20
+
 var Endpoints = struct {
 	CatCreate string
 	CatGet    string
 	CatGetAll string
 	CatUpdate string
 	CatDelete string
+	CatPatch  string
+
+	CatSalaryHistory string
+	PayrollGet       string
+
+	CatStatusUpdate  string
+	CatStatusHistory string
+
+	CatStats       string
+	CatLeaderboard string
+
+	CatPromotions string
+	RankGetAll    string
+
+	CatLedgerGet    string
+	CatLedgerCreate string
+
+	CatSkillsGet    string
+	CatSkillsUpdate string
+	SkillCreate     string
+	SkillGetAll     string
+
+	CatAbsenceCreate string
+	CatAbsenceGetAll string
+	CatAbsenceUpdate string
+	CatAbsenceDelete string
+	CatAvailable     string
+	CatImport        string
+	CatExport        string
+
+	CatMissions string
+
+	CatPhotoUpload string
+	CatPhotoGet    string
+	PhotoFile      string
+
+	CatTransfer      string
+	HandlerCreate    string
+	HandlerGet       string
+	HandlerGetAll    string
+	HandlerCats      string
+	HandlerMissions  string
+	HandlerDashboard string
+	HandlerBudget    string
+
+	BudgetGet string
 
 	MissionCreate   string
 	MissionGet      string
 	MissionGetAll   string
+	MissionExport   string
 	MissionUpdate   string
 	MissionDelete   string
 	MissionAssign   string
+	MissionWindow   string
 	MissionComplete string
+	MissionClone    string
+
+	MissionFromTemplate string
+	MissionTypeGetAll   string
 
 	TargetComplete string
 	TargetUpdate   string
 	TargetDelete   string
 	TargetAdd      string
+
+	TemplateCreate string
+	TemplateGet    string
+	TemplateGetAll string
+	TemplateUpdate string
+	TemplateDelete string
+
+	Batch string
+
+	OpenAPI string
+	Docs    string
+	GraphQL string
 }{
 	CatCreate: "/cats",
 	CatGet:    "/cats/:id",
 	CatUpdate: "/cats/:id",
 	CatDelete: "/cats/:id",
+	CatPatch:  "/cats/:id",
 	CatGetAll: "/cats",
 
+	CatSalaryHistory: "/cats/:id/salary-history",
+	PayrollGet:       "/payroll",
+
+	CatStatusUpdate:  "/cats/:id/status",
+	CatStatusHistory: "/cats/:id/status-history",
+
+	CatStats:       "/cats/:id/stats",
+	CatLeaderboard: "/cats/leaderboard",
+
+	CatPromotions: "/cats/:id/promotions",
+	RankGetAll:    "/ranks",
+
+	CatLedgerGet:    "/cats/:id/ledger",
+	CatLedgerCreate: "/cats/:id/ledger",
+
+	CatSkillsGet:    "/cats/:id/skills",
+	CatSkillsUpdate: "/cats/:id/skills",
+	SkillCreate:     "/skills",
+	SkillGetAll:     "/skills",
+
+	CatAbsenceCreate: "/cats/:id/absences",
+	CatAbsenceGetAll: "/cats/:id/absences",
+	CatAbsenceUpdate: "/cats/:id/absences/:absenceId",
+	CatAbsenceDelete: "/cats/:id/absences/:absenceId",
+	CatAvailable:     "/cats/available",
+	CatImport:        "/cats/import",
+	CatExport:        "/cats/export",
+
+	CatMissions: "/cats/:id/missions",
+
+	CatPhotoUpload: "/cats/:id/photo",
+	CatPhotoGet:    "/cats/:id/photo",
+	PhotoFile:      PhotoBaseURL + "/*key",
+
+	CatTransfer:      "/cats/:id/transfer",
+	HandlerCreate:    "/handlers",
+	HandlerGet:       "/handlers/:id",
+	HandlerGetAll:    "/handlers",
+	HandlerCats:      "/handlers/:id/cats",
+	HandlerMissions:  "/handlers/:id/missions",
+	HandlerDashboard: "/handlers/:id/dashboard",
+	HandlerBudget:    "/handlers/:id/budget",
+
+	BudgetGet: "/budget",
+
 	MissionCreate:   "/missions",
 	MissionGet:      "/missions/:id",
 	MissionGetAll:   "/missions",
+	MissionExport:   "/missions/export",
 	MissionUpdate:   "/missions/:id",
 	MissionAssign:   "/missions/:id/assign/:catId",
+	MissionWindow:   "/missions/:id/window",
 	MissionComplete: "/missions/:id/complete",
 	MissionDelete:   "/missions/:id",
+	MissionClone:    "/missions/:id/clone",
+
+	MissionFromTemplate: "/missions/from-template/:templateId",
+	MissionTypeGetAll:   "/mission-types",
 
 	TargetComplete: "/missions/:id/targets/:targetId/complete",
 	TargetUpdate:   "/missions/:id/targets/:targetId",
 	TargetDelete:   "/missions/:id/targets/:targetId",
 	TargetAdd:      "/missions/:id/targets",
+
+	TemplateCreate: "/templates",
+	TemplateGet:    "/templates/:id",
+	TemplateGetAll: "/templates",
+	TemplateUpdate: "/templates/:id",
+	TemplateDelete: "/templates/:id",
+
+	Batch: "/batch",
+
+	OpenAPI: "/openapi.json",
+	Docs:    "/docs",
+	GraphQL: "/graphql",
+}
+
+type Server struct {
+	httpServer      *http.Server
+	router          *gin.Engine
+	catService      services.CatService
+	catAPI          catapi.CatAPI
+	missionService  services.MissionService
+	templateService services.TemplateService
+	payrollService  services.PayrollService
+	skillService    services.SkillService
+	absenceService  services.AbsenceService
+	statsService    services.StatsService
+	ledgerService   services.LedgerService
+	handlerService  services.HandlerService
+	photoService    services.PhotoService
+	budgetService   services.BudgetService
+	importService   services.ImportService
+	exportService   services.ExportService
+	transactor      repositories.Transactor
+	graph           *graph.Schema
+	spec            openapi.Document
+}
+
+func NewServer(catService services.CatService, catAPI catapi.CatAPI, missionService services.MissionService,
+	templateService services.TemplateService, payrollService services.PayrollService, skillService services.SkillService,
+	absenceService services.AbsenceService, statsService services.StatsService, ledgerService services.LedgerService,
+	handlerService services.HandlerService, photoService services.PhotoService,
+	budgetService services.BudgetService, importService services.ImportService,
+	exportService services.ExportService, transactor repositories.Transactor, idempotencyRepo repositories.IdempotencyRepository, idempotencyTTL time.Duration) *Server {
+	graphSchema, err := graph.NewSchema(catService, missionService)
+	if err != nil {
+		panic("invalid GraphQL schema: " + err.Error())
+	}
+
+	router := gin.Default()
+	// services get request context through gin context, batch operations pass their transaction in it
+	router.ContextWithFallback = true
+
+	router.Use(SimpleLoggingMiddleware())
+	router.Use(Idempotency(idempotencyRepo, idempotencyTTL))
+	router.Use(ErrorHandler())
+
+	server := &Server{
+		router:          router,
+		catService:      catService,
+		catAPI:          catAPI,
+		missionService:  missionService,
+		templateService: templateService,
+		payrollService:  payrollService,
+		skillService:    skillService,
+		absenceService:  absenceService,
+		statsService:    statsService,
+		ledgerService:   ledgerService,
+		handlerService:  handlerService,
+		photoService:    photoService,
+		budgetService:   budgetService,
+		importService:   importService,
+		exportService:   exportService,
+		transactor:      transactor,
+		graph:           graphSchema,
+		spec:            openapi.Build(apiInfo, apiErrorResponse, apiOperations()),
+	}
+
+	server.registerRoutes(router.Group(APIV1, withAPIVersion(1)))
+	server.registerRoutes(router.Group(APIV2, withAPIVersion(2)))
+	server.registerRoutes(router.Group("", withAPIVersion(1), Deprecated(UnversionedDeprecation, UnversionedSunset, APIV1)))
+
+	router.GET(Endpoints.PhotoFile, server.handleGetPhotoFile)
+	router.GET(Endpoints.OpenAPI, server.handleGetOpenAPI)
+	router.GET(Endpoints.Docs, server.handleGetDocs)
+	router.POST(Endpoints.GraphQL, server.handleGraphQL)
+	router.NoRoute(func(ctx *gin.Context) {
+		ctx.Error(myerrors.NewNotFoundError("route " + ctx.Request.Method + " " + ctx.Request.URL.Path + " doesn't exist"))
+	})
+
+	server.httpServer = &http.Server{
+		Addr:              ":8080",
+		Handler:           router,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		ReadHeaderTimeout: 5 * time.Second,
+		MaxHeaderBytes:    1 << 20, //1MB
+	}
+
+	return server
+}
+
+// registerRoutes registers API routes that are available in every version of the API
+func (s *Server) registerRoutes(r gin.IRoutes) {
+	r.POST(Endpoints.CatCreate, s.handleAddCat)
+	r.GET(Endpoints.CatGet, s.handleGetCat)
+	r.GET(Endpoints.CatGetAll, s.handleGetAllCats)
+	r.PUT(Endpoints.CatUpdate, s.handleUpdateCat)
+	r.DELETE(Endpoints.CatDelete, s.handleDeleteCat)
+	r.PATCH(Endpoints.CatPatch, s.handlePatchCat)
+	r.GET(Endpoints.CatSalaryHistory, s.handleGetSalaryHistory)
+	r.GET(Endpoints.PayrollGet, s.handleGetPayroll)
+	r.PUT(Endpoints.CatStatusUpdate, s.handleUpdateCatStatus)
+	r.GET(Endpoints.CatStatusHistory, s.handleGetStatusHistory)
+	r.GET(Endpoints.CatStats, s.handleGetCatStats)
+	r.GET(Endpoints.CatLeaderboard, s.handleGetLeaderboard)
+	r.GET(Endpoints.CatPromotions, s.handleGetPromotions)
+	r.GET(Endpoints.RankGetAll, s.handleGetRanks)
+	r.GET(Endpoints.CatLedgerGet, s.handleGetLedger)
+	r.POST(Endpoints.CatLedgerCreate, s.handleAddLedgerEntry)
+	r.GET(Endpoints.CatSkillsGet, s.handleGetCatSkills)
+	r.PUT(Endpoints.CatSkillsUpdate, s.handleUpdateCatSkills)
+	r.POST(Endpoints.CatAbsenceCreate, s.handleAddAbsence)
+	r.GET(Endpoints.CatAbsenceGetAll, s.handleGetAbsences)
+	r.PUT(Endpoints.CatAbsenceUpdate, s.handleUpdateAbsence)
+	r.DELETE(Endpoints.CatAbsenceDelete, s.handleDeleteAbsence)
+	r.GET(Endpoints.CatAvailable, s.handleGetAvailableCats)
+	r.POST(Endpoints.CatImport, s.handleImportCats)
+	r.GET(Endpoints.CatExport, s.handleExportCats)
+
+	r.GET(Endpoints.CatMissions, s.handleGetCatMissions)
+	r.PUT(Endpoints.CatPhotoUpload, s.handleUploadCatPhoto)
+	r.GET(Endpoints.CatPhotoGet, s.handleGetCatPhoto)
+
+	r.POST(Endpoints.CatTransfer, s.handleTransferCat)
+	r.POST(Endpoints.HandlerCreate, s.handleAddHandler)
+	r.GET(Endpoints.HandlerGet, s.handleGetHandler)
+	r.GET(Endpoints.HandlerGetAll, s.handleGetAllHandlers)
+	r.GET(Endpoints.HandlerCats, s.handleGetHandlerCats)
+	r.GET(Endpoints.HandlerMissions, s.handleGetHandlerMissions)
+	r.GET(Endpoints.HandlerDashboard, s.handleGetHandlerDashboard)
+	r.PUT(Endpoints.HandlerBudget, s.handleUpdateHandlerBudget)
+	r.GET(Endpoints.BudgetGet, s.handleGetBudget)
+
+	r.POST(Endpoints.SkillCreate, s.handleAddSkill)
+	r.GET(Endpoints.SkillGetAll, s.handleGetAllSkills)
+
+	r.POST(Endpoints.MissionCreate, s.handleAddMission)
+	r.GET(Endpoints.MissionGet, s.handleGetMission)
+	r.GET(Endpoints.MissionGetAll, s.handleGetAllMissions)
+	r.GET(Endpoints.MissionExport, s.handleExportMissions)
+	r.POST(Endpoints.MissionAssign, s.handleAssignMission)
+	r.PUT(Endpoints.MissionWindow, s.handleUpdateMissionWindow)
+	r.POST(Endpoints.MissionComplete, s.handleCompleteMission)
+	r.DELETE(Endpoints.MissionDelete, s.handleDeleteMission)
+	r.POST(Endpoints.MissionClone, s.handleCloneMission)
+	r.POST(Endpoints.MissionFromTemplate, s.handleAddMissionFromTemplate)
+
+	r.GET(Endpoints.MissionTypeGetAll, s.handleGetMissionTypes)
+
+	r.POST(Endpoints.Batch, s.handleBatch)
+
+	r.POST(Endpoints.TargetComplete, s.handleCompleteTarget)
+	r.POST(Endpoints.TargetUpdate, s.handleUpdateTarget)
+	r.DELETE(Endpoints.TargetDelete, s.handleDeleteTarget)
+	r.POST(Endpoints.TargetAdd, s.handleAddTarget)
+
+	r.POST(Endpoints.TemplateCreate, s.handleAddTemplate)
+	r.GET(Endpoints.TemplateGet, s.handleGetTemplate)
+	r.GET(Endpoints.TemplateGetAll, s.handleGetAllTemplates)
+	r.PUT(Endpoints.TemplateUpdate, s.handleUpdateTemplate)
+	r.DELETE(Endpoints.TemplateDelete, s.handleDeleteTemplate)
+}
+
+func (s *Server) handleAddCat(ctx *gin.Context) {
+	var cat models.Cat
+	if err := ctx.ShouldBindJSON(&cat); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+
+	newCat, err := s.catService.Add(ctx, cat)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, newCat)
+}
+
+func (s *Server) handleGetCat(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+
+	cat, err := s.catService.GetById(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, cat)
+}
+
+func (s *Server) handleUpdateCat(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var update models.CatUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	updatedCat, err := s.catService.Update(ctx, int64(id), update)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedCat)
+}
+
+func (s *Server) handlePatchCat(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	contentType := ctx.ContentType()
+	if contentType != mergepatch.ContentType && contentType != binding.MIMEJSON {
+		ctx.Error(myerrors.NewUnsupportedMediaTypeError("use " + mergepatch.ContentType + " content type"))
+		return
+	}
+	patch, err := ctx.GetRawData()
+	if err != nil {
+		ctx.Error(myerrors.NewBadRequestError(err.Error()))
+		return
+	}
+	patchedCat, err := s.catService.Patch(ctx, int64(id), patch)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, patchedCat)
+}
+
+func (s *Server) handleDeleteCat(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+
+	err = s.catService.DeleteById(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	respondEmpty(ctx)
+}
+
+func (s *Server) handleGetAllCats(ctx *gin.Context) {
+	var query models.CatQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	cats, err := s.catService.GetAll(ctx, query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, cats)
+}
+
+// importFormats maps content types of imported files to their formats
+var importFormats = map[string]string{
+	"text/csv":             models.ImportFormatCSV,
+	"application/x-ndjson": models.ImportFormatNDJSON,
+	"application/ndjson":   models.ImportFormatNDJSON,
+}
+
+func (s *Server) handleImportCats(ctx *gin.Context) {
+	var query models.CatImportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	format, ok := importFormats[ctx.ContentType()]
+	if !ok {
+		ctx.Error(myerrors.NewUnsupportedMediaTypeError("use text/csv or application/x-ndjson content type"))
+		return
+	}
+	report, err := s.importService.ImportCats(ctx, format, ctx.Request.Body, query.Mode)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	status := http.StatusCreated
+	if report.Failed > 0 {
+		status = http.StatusOK
+		// nothing is created when a row of all-or-nothing import fails
+		if report.Mode == models.ImportModeAllOrNothing {
+			status = http.StatusUnprocessableEntity
+		}
+	}
+	ctx.JSON(status, report)
+}
+
+func (s *Server) handleExportCats(ctx *gin.Context) {
+	var query models.CatExportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	if query.Format == "" {
+		query.Format = models.ExportFormatCSV
+	}
+	streamExport(ctx, "cats", query.Format, func(w io.Writer) error {
+		return s.exportService.ExportCats(ctx, query, w)
+	})
+}
+
+func (s *Server) handleGetSalaryHistory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	history, err := s.catService.GetSalaryHistory(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
+
+func (s *Server) handleUpdateCatStatus(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var update models.StatusUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	updatedCat, err := s.catService.UpdateStatus(ctx, int64(id), update)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedCat)
+}
+
+func (s *Server) handleGetStatusHistory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	history, err := s.catService.GetStatusHistory(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
+
+func (s *Server) handleGetCatStats(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	stats, err := s.statsService.GetCatStats(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+}
+
+func (s *Server) handleGetLeaderboard(ctx *gin.Context) {
+	var query models.LeaderboardQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	leaderboard, err := s.statsService.GetLeaderboard(ctx, query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, leaderboard)
+}
+
+func (s *Server) handleGetPromotions(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	promotions, err := s.catService.GetPromotions(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, promotions)
+}
+
+func (s *Server) handleGetRanks(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, s.catService.GetRanks(ctx))
+}
+
+func (s *Server) handleGetLedger(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	ledger, err := s.ledgerService.GetByCatId(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, ledger)
+}
+
+func (s *Server) handleAddLedgerEntry(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var entry models.LedgerEntry
+	if err := ctx.ShouldBindJSON(&entry); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	newEntry, err := s.ledgerService.Add(ctx, int64(id), entry)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, newEntry)
+}
+
+func (s *Server) handleGetCatMissions(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var query models.CatMissionQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	missions, err := s.missionService.GetByCatId(ctx, int64(id), query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, missions)
+}
+
+func (s *Server) handleUploadCatPhoto(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	if contentType := ctx.ContentType(); contentType != "image/jpeg" && contentType != "image/png" {
+		ctx.Error(myerrors.NewUnsupportedMediaTypeError("use image/jpeg or image/png content type"))
+		return
+	}
+	photo, err := s.photoService.Upload(ctx, int64(id), ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, photo)
+}
+
+func (s *Server) handleGetCatPhoto(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	photo, err := s.photoService.Get(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, photo)
+}
+
+func (s *Server) handleGetPhotoFile(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	file, err := s.photoService.Open(ctx, key)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	defer file.Close()
+	ctx.DataFromReader(http.StatusOK, -1, mime.TypeByExtension(path.Ext(key)), file, nil)
+}
+
+func (s *Server) handleTransferCat(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var transfer models.CatTransfer
+	if err := ctx.ShouldBindJSON(&transfer); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	cat, err := s.handlerService.TransferCat(ctx, int64(id), transfer.HandlerId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, cat)
+}
+
+func (s *Server) handleAddHandler(ctx *gin.Context) {
+	var handler models.Handler
+	if err := ctx.ShouldBindJSON(&handler); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	newHandler, err := s.handlerService.Add(ctx, handler)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, newHandler)
+}
+
+func (s *Server) handleGetHandler(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	handler, err := s.handlerService.GetById(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, handler)
+}
+
+func (s *Server) handleGetAllHandlers(ctx *gin.Context) {
+	handlers, err := s.handlerService.GetAll(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, handlers)
+}
+
+func (s *Server) handleGetHandlerCats(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var query models.CatQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	cats, err := s.handlerService.GetCats(ctx, int64(id), query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, cats)
+}
+
+func (s *Server) handleGetHandlerMissions(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var query models.PaginationQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	missions, err := s.handlerService.GetMissions(ctx, int64(id), query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, missions)
+}
+
+func (s *Server) handleGetHandlerDashboard(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	dashboard, err := s.handlerService.GetDashboard(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, dashboard)
+}
+
+func (s *Server) handleUpdateHandlerBudget(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var update models.HandlerBudgetUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	handler, err := s.handlerService.UpdateBudget(ctx, int64(id), update)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, handler)
+}
+
+func (s *Server) handleGetBudget(ctx *gin.Context) {
+	budget, err := s.budgetService.Get(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, budget)
+}
+
+func (s *Server) handleGetPayroll(ctx *gin.Context) {
+	var query models.PayrollQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	payroll, err := s.payrollService.Calculate(ctx, query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, payroll)
+}
+
+func (s *Server) handleGetCatSkills(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	skills, err := s.skillService.GetCatSkills(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, skills)
+}
+
+func (s *Server) handleUpdateCatSkills(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var skills []models.CatSkill
+	if err := ctx.ShouldBindJSON(&skills); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	updatedSkills, err := s.skillService.SetCatSkills(ctx, int64(id), skills)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedSkills)
 }
 
-type Server struct {
-	httpServer     *http.Server
-	router         *gin.Engine
-	catService     services.CatService
-	catAPI         catapi.CatAPI
-	missionService services.MissionService
+func (s *Server) handleAddSkill(ctx *gin.Context) {
+	var skill models.Skill
+	if err := ctx.ShouldBindJSON(&skill); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	newSkill, err := s.skillService.Add(ctx, skill)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, newSkill)
 }
 
-func NewServer(catService services.CatService, catAPI catapi.CatAPI, missionService services.MissionService) *Server {
-	router := gin.Default()
-
-	router.Use(SimpleLoggingMiddleware())
-	router.Use(ErrorHandler())
-
-	server := &Server{
-		router:         router,
-		catService:     catService,
-		catAPI:         catAPI,
-		missionService: missionService,
-	}
-
-	router.POST(Endpoints.CatCreate, server.handleAddCat)
-	router.GET(Endpoints.CatGet, server.handleGetCat)
-	router.GET(Endpoints.CatGetAll, server.handleGetAllCats)
-	router.PUT(Endpoints.CatUpdate, server.handleUpdateCat)
-	router.DELETE(Endpoints.CatDelete, server.handleDeleteCat)
-
-	router.POST(Endpoints.MissionCreate, server.handleAddMission)
-	router.GET(Endpoints.MissionGet, server.handleGetMission)
-	router.GET(Endpoints.MissionGetAll, server.handleGetAllMissions)
-	router.POST(Endpoints.MissionAssign, server.handleAssignMission)
-	router.POST(Endpoints.MissionComplete, server.handleCompleteMission)
-	router.DELETE(Endpoints.MissionDelete, server.handleDeleteMission)
-
-	router.POST(Endpoints.TargetComplete, server.handleCompleteTarget)
-	router.POST(Endpoints.TargetUpdate, server.handleUpdateTarget)
-	router.DELETE(Endpoints.TargetDelete, server.handleDeleteTarget)
-	router.POST(Endpoints.TargetAdd, server.handleAddTarget)
-
-	server.httpServer = &http.Server{
-		Addr:              ":8080",
-		Handler:           router,
-		ReadTimeout:       10 * time.Second,
-		WriteTimeout:      10 * time.Second,
-		IdleTimeout:       60 * time.Second,
-		ReadHeaderTimeout: 5 * time.Second,
-		MaxHeaderBytes:    1 << 20, //1MB
+func (s *Server) handleGetAllSkills(ctx *gin.Context) {
+	skills, err := s.skillService.GetAll(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
 	}
-
-	return server
+	ctx.JSON(http.StatusOK, skills)
 }
 
-func (s *Server) handleAddCat(ctx *gin.Context) {
-	var cat models.Cat
-	if err := ctx.ShouldBindJSON(&cat); err != nil {
-		ctx.Error(myerrors.NewBadRequestError(err.Error()))
+func (s *Server) handleAddAbsence(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
 		return
 	}
-
-	newCat, err := s.catService.Add(ctx, cat)
+	var absence models.Absence
+	if err := ctx.ShouldBindJSON(&absence); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	newAbsence, err := s.absenceService.Add(ctx, int64(id), absence)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
-	ctx.JSON(http.StatusCreated, newCat)
+	ctx.JSON(http.StatusCreated, newAbsence)
 }
 
-func (s *Server) handleGetCat(ctx *gin.Context) {
+func (s *Server) handleGetAbsences(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		ctx.Error(myerrors.NewNotFoundError("use number as id"))
 		return
 	}
-
-	cat, err := s.catService.GetById(ctx, int64(id))
+	absences, err := s.absenceService.GetByCatId(ctx, int64(id))
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
-	ctx.JSON(http.StatusOK, cat)
+	ctx.JSON(http.StatusOK, absences)
 }
 
-func (s *Server) handleUpdateCat(ctx *gin.Context) {
+func (s *Server) handleUpdateAbsence(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		ctx.Error(myerrors.NewNotFoundError("use number as id"))
 		return
 	}
-	var update models.CatUpdate
-	if err := ctx.ShouldBindJSON(&update); err != nil {
-		ctx.Error(myerrors.NewBadRequestError(err.Error()))
+	absenceId, err := strconv.Atoi(ctx.Param("absenceId"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
 		return
 	}
-	updatedCat, err := s.catService.Update(ctx, int64(id), update)
+	var absence models.Absence
+	if err := ctx.ShouldBindJSON(&absence); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	updatedAbsence, err := s.absenceService.Update(ctx, int64(id), int64(absenceId), absence)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
-	ctx.JSON(http.StatusOK, updatedCat)
+	ctx.JSON(http.StatusOK, updatedAbsence)
 }
-func (s *Server) handleDeleteCat(ctx *gin.Context) {
+
+func (s *Server) handleDeleteAbsence(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		ctx.Error(myerrors.NewNotFoundError("use number as id"))
 		return
 	}
-
-	err = s.catService.DeleteById(ctx, int64(id))
+	absenceId, err := strconv.Atoi(ctx.Param("absenceId"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	err = s.absenceService.Delete(ctx, int64(id), int64(absenceId))
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
-	ctx.JSON(http.StatusOK, nil)
+	respondEmpty(ctx)
 }
 
-func (s *Server) handleGetAllCats(ctx *gin.Context) {
-	var query models.PaginationQuery
+func (s *Server) handleGetAvailableCats(ctx *gin.Context) {
+	var query models.AvailabilityQuery
 	if err := ctx.ShouldBindQuery(&query); err != nil {
-		ctx.Error(myerrors.NewBadRequestError(err.Error()))
+		ctx.Error(myerrors.NewValidationError(err))
 		return
 	}
-	cats, err := s.catService.GetAll(ctx, query)
+	cats, err := s.absenceService.GetAvailableCats(ctx, query)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -189,12 +1012,13 @@ func (s *Server) handleGetAllCats(ctx *gin.Context) {
 func (s *Server) handleAddMission(ctx *gin.Context) {
 	var mission models.Mission
 	if err := ctx.ShouldBindJSON(&mission); err != nil {
-		ctx.Error(myerrors.NewBadRequestError(err.Error()))
+		ctx.Error(myerrors.NewValidationError(err))
 		return
 	}
 	savedMission, err := s.missionService.Add(ctx, mission)
 	if err != nil {
 		ctx.Error(err)
+		return
 	}
 	ctx.JSON(http.StatusCreated, savedMission)
 }
@@ -215,13 +1039,32 @@ func (s *Server) handleGetMission(ctx *gin.Context) {
 
 }
 
+func (s *Server) handleExportMissions(ctx *gin.Context) {
+	var query models.MissionExportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	if query.Format == "" {
+		query.Format = models.ExportFormatCSV
+	}
+	streamExport(ctx, "missions", query.Format, func(w io.Writer) error {
+		return s.exportService.ExportMissions(ctx, query, w)
+	})
+}
+
 func (s *Server) handleGetAllMissions(ctx *gin.Context) {
 	var query models.PaginationQuery
 	if err := ctx.ShouldBindQuery(&query); err != nil {
-		ctx.Error(myerrors.NewBadRequestError(err.Error()))
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	var filter models.MissionFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
 		return
 	}
-	missions, err := s.missionService.GetAll(ctx, query)
+	missions, err := s.missionService.GetAll(ctx, query, filter)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -240,12 +1083,36 @@ func (s *Server) handleAssignMission(ctx *gin.Context) {
 		ctx.Error(myerrors.NewNotFoundError("use number as id"))
 		return
 	}
-	err = s.missionService.Assign(ctx, int64(missionId), int64(catId))
+	var query models.AssignQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	result, err := s.missionService.Assign(ctx, int64(missionId), int64(catId), query.Override)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (s *Server) handleUpdateMissionWindow(ctx *gin.Context) {
+	missionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var window models.MissionWindow
+	if err := ctx.ShouldBindJSON(&window); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	mission, err := s.missionService.UpdateWindow(ctx, int64(missionId), window)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
-	ctx.JSON(http.StatusOK, nil)
+	ctx.JSON(http.StatusOK, mission)
 }
 
 func (s *Server) handleCompleteTarget(ctx *gin.Context) {
@@ -265,7 +1132,7 @@ func (s *Server) handleCompleteTarget(ctx *gin.Context) {
 		ctx.Error(err)
 		return
 	}
-	ctx.JSON(http.StatusOK, nil)
+	respondEmpty(ctx)
 }
 
 func (s *Server) handleUpdateTarget(ctx *gin.Context) {
@@ -282,7 +1149,7 @@ func (s *Server) handleUpdateTarget(ctx *gin.Context) {
 
 	var update models.TargetUpdate
 	if err := ctx.ShouldBindJSON(&update); err != nil {
-		ctx.Error(myerrors.NewBadRequestError(err.Error()))
+		ctx.Error(myerrors.NewValidationError(err))
 		return
 	}
 	target, err := s.missionService.UpdateTarget(ctx, int64(missionId), int64(targetId), update)
@@ -309,7 +1176,7 @@ func (s *Server) handleDeleteTarget(ctx *gin.Context) {
 		ctx.Error(err)
 		return
 	}
-	ctx.JSON(http.StatusOK, nil)
+	respondEmpty(ctx)
 }
 
 func (s *Server) handleAddTarget(ctx *gin.Context) {
@@ -320,7 +1187,7 @@ func (s *Server) handleAddTarget(ctx *gin.Context) {
 	}
 	var target models.Target
 	if err := ctx.ShouldBindJSON(&target); err != nil {
-		ctx.Error(myerrors.NewBadRequestError(err.Error()))
+		ctx.Error(myerrors.NewValidationError(err))
 		return
 	}
 
@@ -357,7 +1224,155 @@ func (s *Server) handleDeleteMission(ctx *gin.Context) {
 		ctx.Error(err)
 		return
 	}
-	ctx.JSON(http.StatusOK, nil)
+	respondEmpty(ctx)
+}
+
+func (s *Server) handleCloneMission(ctx *gin.Context) {
+	missionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	mission, err := s.missionService.Clone(ctx, int64(missionId))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, mission)
+}
+
+func (s *Server) handleAddMissionFromTemplate(ctx *gin.Context) {
+	templateId, err := strconv.Atoi(ctx.Param("templateId"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	mission, err := s.missionService.AddFromTemplate(ctx, int64(templateId))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, mission)
+}
+
+func (s *Server) handleAddTemplate(ctx *gin.Context) {
+	var template models.MissionTemplate
+	if err := ctx.ShouldBindJSON(&template); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	savedTemplate, err := s.templateService.Add(ctx, template)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, savedTemplate)
+}
+
+func (s *Server) handleGetTemplate(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	template, err := s.templateService.GetById(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, template)
+}
+
+func (s *Server) handleGetAllTemplates(ctx *gin.Context) {
+	var query models.PaginationQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	templates, err := s.templateService.GetAll(ctx, query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, templates)
+}
+
+func (s *Server) handleUpdateTemplate(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	var template models.MissionTemplate
+	if err := ctx.ShouldBindJSON(&template); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	updatedTemplate, err := s.templateService.Update(ctx, int64(id), template)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedTemplate)
+}
+
+func (s *Server) handleDeleteTemplate(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(myerrors.NewNotFoundError("use number as id"))
+		return
+	}
+	err = s.templateService.Delete(ctx, int64(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	respondEmpty(ctx)
+}
+
+func (s *Server) handleGetMissionTypes(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, s.missionService.GetTypes(ctx))
+}
+
+func (s *Server) handleGetOpenAPI(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, s.spec)
+}
+
+// handleGraphQL responds with 200 OK to every request that was executed. Errors of services are reported
+// in errors of the result with the same code, status and violations as problems of the REST API
+func (s *Server) handleGraphQL(ctx *gin.Context) {
+	var request graph.Request
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(myerrors.NewValidationError(err))
+		return
+	}
+	result := s.graph.Execute(ctx.Request.Context(), request)
+	for i, err := range result.Errors {
+		appErr := graph.AppError(err)
+		if appErr == nil {
+			continue
+		}
+		problem := newProblem(appErr, ctx.Request.URL.Path)
+		extensions := map[string]any{"code": problem.Code, "status": problem.Status}
+		if len(problem.Violations) > 0 {
+			extensions["violations"] = problem.Violations
+		}
+		if problem.CorrelationId != "" {
+			extensions["correlationId"] = problem.CorrelationId
+		}
+		result.Errors[i].Message = problem.Detail
+		result.Errors[i].Extensions = extensions
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (s *Server) handleGetDocs(ctx *gin.Context) {
+	page, err := openapi.Explorer(apiInfo.Title, Endpoints.OpenAPI)
+	if err != nil {
+		ctx.Error(myerrors.NewServerError(err.Error()))
+		return
+	}
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", page)
 }
 
 func (s *Server) Run() error {
@@ -379,31 +1394,164 @@ type responseWriter struct {
 }
 
 func (rw *responseWriter) Write(b []byte) (int, error) {
-	rw.body.Write(b)
+	// files are not logged, so they aren't kept in memory
+	if !isFile(rw.Header().Get("Content-Type")) {
+		rw.body.Write(b)
+	}
 	return rw.ResponseWriter.Write(b)
 }
+
+// Unwrap lets http.ResponseController reach the connection under the writer
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// withAPIVersion stores version of the API that serves the request
+func withAPIVersion(version int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(apiVersionKey, version)
+		c.Next()
+	}
+}
+
+func apiVersion(ctx *gin.Context) int {
+	if version := ctx.GetInt(apiVersionKey); version > 0 {
+		return version
+	}
+	return 1
+}
+
+// exportContentTypes are content types of export formats
+var exportContentTypes = map[string]string{
+	models.ExportFormatCSV:    "text/csv; charset=utf-8",
+	models.ExportFormatNDJSON: "application/x-ndjson",
+	models.ExportFormatXLSX:   xlsx.ContentType,
+}
+
+// exportBufferSize is the amount of the exported file that is buffered before the response starts.
+// Errors that happen earlier are still returned as problems
+const exportBufferSize = 32 << 10
+
+// exportWriteTimeout replaces write timeout of the server for exports. Large exports take longer than the
+// server allows, so the deadline is extended every time a chunk of the file is sent
+const exportWriteTimeout = time.Minute
+
+// deadlineWriter extends write deadline of the response before every write. Writers that don't support
+// deadlines, like httptest recorders, are written without them
+type deadlineWriter struct {
+	w           http.ResponseWriter
+	controller  *http.ResponseController
+	unsupported bool
+}
+
+func newDeadlineWriter(w http.ResponseWriter) *deadlineWriter {
+	return &deadlineWriter{w: w, controller: http.NewResponseController(w)}
+}
+
+func (d *deadlineWriter) Write(p []byte) (int, error) {
+	d.extend()
+	return d.w.Write(p)
+}
+
+func (d *deadlineWriter) extend() {
+	if d.unsupported {
+		return
+	}
+	if err := d.controller.SetWriteDeadline(time.Now().Add(exportWriteTimeout)); err != nil {
+		d.unsupported = true
+		if !errors.Is(err, http.ErrNotSupported) {
+			log.Printf("failed to extend write deadline of export: %v", err)
+		}
+	}
+}
+
+// streamExport sends the exported file as an attachment while it is written. An error that happens after
+// the response started can't change the status anymore, so it's logged and the response is cut short
+func streamExport(ctx *gin.Context, name, format string, export func(w io.Writer) error) {
+	// the buffer is flushed to the deadline writer, so every flush extends the deadline
+	dw := newDeadlineWriter(ctx.Writer)
+	dw.extend()
+	ctx.Header("Content-Type", exportContentTypes[format])
+	ctx.Header("Content-Disposition", `attachment; filename="`+name+"."+format+`"`)
+	w := bufio.NewWriterSize(dw, exportBufferSize)
+	err := export(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		return
+	}
+	if !ctx.Writer.Written() {
+		ctx.Header("Content-Type", "")
+		ctx.Header("Content-Disposition", "")
+		ctx.Error(err)
+		return
+	}
+	log.Printf("%s export failed after the response started: %v", name, err)
+	ctx.Abort()
+}
+
+// respondEmpty finishes request that has no result. v1 responds with JSON null, later versions with 204 No Content
+func respondEmpty(ctx *gin.Context) {
+	if apiVersion(ctx) == 1 {
+		ctx.JSON(http.StatusOK, nil)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// Deprecated adds Deprecation (RFC 9745) and Sunset (RFC 8594) headers to responses of deprecated routes
+// and links the same route in the successor version
+func Deprecated(deprecation, sunset time.Time, successor string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "@"+strconv.FormatInt(deprecation.Unix(), 10))
+		c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+		c.Header("Link", "<"+successor+c.Request.URL.Path+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
+
+// ErrorHandler renders the last error of the request as RFC 7807 problem. Details of internal errors
+// are logged with correlation id that is shown to the client instead
 func ErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
-		if len(c.Errors) > 0 {
-			err := c.Errors.Last().Err
-
-			switch e := err.(type) {
-			case *myerrors.AppError:
-				c.JSON(e.StatusCode, gin.H{"error": e.Message})
-			default:
-				// Log unexpected errors
-				log.Printf("Unexpected error: %v", err)
-				c.JSON(500, gin.H{"error": "Internal server error"})
-			}
+		if len(c.Errors) == 0 {
+			return
 		}
+		writeProblem(c, c.Errors.Last().Err)
+	}
+}
+
+// writeProblem responds with RFC 7807 problem that describes err
+func writeProblem(c *gin.Context, err error) {
+	var appErr *myerrors.AppError
+	if !errors.As(err, &appErr) {
+		appErr = myerrors.NewServerError(err.Error())
+	}
+	problem := newProblem(appErr, c.Request.URL.Path)
+	c.Header("Content-Type", myerrors.ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+// newProblem describes err, internal errors are logged with a new correlation id
+func newProblem(appErr *myerrors.AppError, instance string) myerrors.Problem {
+	var correlationId string
+	if appErr.StatusCode >= http.StatusInternalServerError {
+		correlationId = myerrors.NewCorrelationId()
+		log.Printf("Internal error %s: %s", correlationId, appErr.Message)
 	}
+	return myerrors.NewProblem(appErr, instance, correlationId)
 }
 
 func SimpleLoggingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Log Request
-		logRequest(c)
+		if err := logRequest(c); err != nil {
+			writeProblem(c, err)
+			c.Abort()
+			return
+		}
 
 		// Capture response body
 		responseBody := &bytes.Buffer{}
@@ -417,22 +1565,31 @@ func SimpleLoggingMiddleware() gin.HandlerFunc {
 		c.Next()
 
 		// Log Response
-		logResponse(c.Writer.Status(), responseBody.String())
+		if isFile(c.Writer.Header().Get("Content-Type")) {
+			logResponse(c.Writer.Status(), "")
+		} else {
+			logResponse(c.Writer.Status(), responseBody.String())
+		}
 	}
 }
 
-func logRequest(c *gin.Context) {
+func logRequest(c *gin.Context) error {
 	method := c.Request.Method
 	path := c.Request.URL.Path
 
-	// Read request body if present
+	// Read beginning of request body if present. Images and data files are not logged
 	var requestBody string
-	if c.Request.Body != nil {
-		bodyBytes, err := io.ReadAll(c.Request.Body)
-		if err == nil && len(bodyBytes) > 0 {
+	if c.Request.Body != nil && !isFile(c.ContentType()) {
+		bodyBytes, err := io.ReadAll(io.LimitReader(c.Request.Body, maxLoggedBodySize+1))
+		if err != nil {
+			return myerrors.NewBadRequestError("failed to read request body")
+		}
+		// Restore the request body for further processing, the rest of it is not read yet
+		c.Request.Body = &loggedBody{Reader: io.MultiReader(bytes.NewReader(bodyBytes), c.Request.Body), Closer: c.Request.Body}
+		if len(bodyBytes) > maxLoggedBodySize {
+			requestBody = string(bodyBytes[:maxLoggedBodySize]) + "\n... (truncated)"
+		} else {
 			requestBody = string(bodyBytes)
-			// Restore the request body for further processing
-			c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 		}
 	}
 
@@ -442,6 +1599,29 @@ func logRequest(c *gin.Context) {
 	} else {
 		log.Printf("REQUEST: %s %s", method, path)
 	}
+	return nil
+}
+
+// maxLoggedBodySize is the part of request bodies that is logged
+const maxLoggedBodySize = 4 << 10
+
+// loggedBody is the request body with its logged beginning put back
+type loggedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// readBody reads the request body of at most maxBodySize bytes
+func readBody(c *gin.Context) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, myerrors.NewRequestEntityTooLargeError("request body must not be larger than " + strconv.Itoa(maxBodySize) + " bytes")
+		}
+		return nil, myerrors.NewBadRequestError("failed to read request body")
+	}
+	return body, nil
 }
 
 func logResponse(statusCode int, responseBody string) {
@@ -453,6 +1633,19 @@ func logResponse(statusCode int, responseBody string) {
 	}
 }
 
+// isFile tells whether the content is an image or a data file, their bodies are not logged
+func isFile(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if strings.HasPrefix(mediaType, "image/") {
+		return true
+	}
+	switch mediaType {
+	case "text/csv", "application/x-ndjson", "application/ndjson", xlsx.ContentType:
+		return true
+	}
+	return false
+}
+
 // prettifyJSON formats JSON string with indentation if valid, otherwise returns original
 func prettifyJSON(jsonStr string) string {
 	if jsonStr == "" {
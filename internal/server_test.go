@@ -4,16 +4,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
 	"github.com/4oBuko/spy-cat-agency/pkg/catapi"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGracefulShutdown(t *testing.T) {
@@ -32,7 +40,8 @@ func TestGracefulShutdown(t *testing.T) {
 		catService := &MockCatService{
 			onRequestStart: make(chan bool, 2),
 		}
-		server := NewServer(catService, &MockCatAPI{}, &MockMissionService{})
+		server := NewServer(catService, &MockCatAPI{}, &MockMissionService{}, &MockTemplateService{}, &MockPayrollService{}, &MockSkillService{}, &MockAbsenceService{}, &MockStatsService{}, &MockLedgerService{},
+			&MockHandlerService{}, &MockPhotoService{}, &MockBudgetService{}, &MockImportService{}, &MockExportService{}, &MockTransactor{}, repositories.NewMemoryIdempotencyRepository(), time.Hour)
 		go func() {
 			err := server.Run()
 
@@ -61,7 +70,8 @@ func TestGracefulShutdown(t *testing.T) {
 			onRequestStart: make(chan bool, 1),
 		}
 		catService.On("Add", body).Return(models.Cat{}, nil)
-		server := NewServer(catService, &MockCatAPI{}, &MockMissionService{})
+		server := NewServer(catService, &MockCatAPI{}, &MockMissionService{}, &MockTemplateService{}, &MockPayrollService{}, &MockSkillService{}, &MockAbsenceService{}, &MockStatsService{}, &MockLedgerService{},
+			&MockHandlerService{}, &MockPhotoService{}, &MockBudgetService{}, &MockImportService{}, &MockExportService{}, &MockTransactor{}, repositories.NewMemoryIdempotencyRepository(), time.Hour)
 		go func() {
 			err := server.Run()
 			if err != nil {
@@ -83,6 +93,445 @@ func TestGracefulShutdown(t *testing.T) {
 	})
 }
 
+func TestAPIVersions(t *testing.T) {
+	server := NewServer(&MockCatService{}, &MockCatAPI{}, &MockMissionService{}, &MockTemplateService{}, &MockPayrollService{}, &MockSkillService{}, &MockAbsenceService{}, &MockStatsService{}, &MockLedgerService{},
+		&MockHandlerService{}, &MockPhotoService{}, &MockBudgetService{}, &MockImportService{}, &MockExportService{}, &MockTransactor{}, repositories.NewMemoryIdempotencyRepository(), time.Hour)
+	deleteCat := func(prefix string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodDelete, prefix+"/cats/1", nil))
+		return w
+	}
+
+	t.Run("v1", func(t *testing.T) {
+		w := deleteCat(APIV1)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "null", w.Body.String())
+		assert.Empty(t, w.Header().Get("Deprecation"))
+		assert.Empty(t, w.Header().Get("Sunset"))
+	})
+
+	t.Run("v2 responds without content", func(t *testing.T) {
+		w := deleteCat(APIV2)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Empty(t, w.Header().Get("Deprecation"))
+	})
+
+	t.Run("unversioned alias is deprecated", func(t *testing.T) {
+		w := deleteCat("")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "null", w.Body.String())
+		assert.Equal(t, fmt.Sprintf("@%d", UnversionedDeprecation.Unix()), w.Header().Get("Deprecation"))
+		sunset, err := http.ParseTime(w.Header().Get("Sunset"))
+		assert.NoError(t, err)
+		assert.True(t, sunset.Equal(UnversionedSunset))
+		assert.Equal(t, `</v1/cats/1>; rel="successor-version"`, w.Header().Get("Link"))
+	})
+}
+
+func TestErrorHandler(t *testing.T) {
+	server := NewServer(&MockCatService{}, &MockCatAPI{}, &MockMissionService{}, &MockTemplateService{}, &MockPayrollService{}, &MockSkillService{}, &MockAbsenceService{}, &MockStatsService{}, &MockLedgerService{},
+		&MockHandlerService{}, &MockPhotoService{}, &MockBudgetService{}, &MockImportService{}, &MockExportService{}, &MockTransactor{}, repositories.NewMemoryIdempotencyRepository(), time.Hour)
+	serve := func(handler http.Handler, request *http.Request) (*httptest.ResponseRecorder, myerrors.Problem) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, request)
+		assert.Equal(t, myerrors.ProblemContentType, w.Header().Get("Content-Type"))
+		var problem myerrors.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, w.Code, problem.Status)
+		assert.Equal(t, http.StatusText(w.Code), problem.Title)
+		return w, problem
+	}
+
+	t.Run("validation errors are reported per field", func(t *testing.T) {
+		body := `{"name":"","yearsOfExperience":-1,"breed":"abys","salary":100}`
+		w, problem := serve(server.Handler(), httptest.NewRequest(http.MethodPost, APIV1+Endpoints.CatCreate, strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, myerrors.CodeValidationFailed, problem.Code)
+		assert.Equal(t, "urn:spy-cat-agency:problem:"+myerrors.CodeValidationFailed, problem.Type)
+		assert.Equal(t, APIV1+Endpoints.CatCreate, problem.Instance)
+		assert.ElementsMatch(t, []myerrors.Violation{
+			{Field: "name", Rule: "required", Message: "is required"},
+			{Field: "yearsOfExperience", Rule: "gte", Message: "must be at least 0"},
+		}, problem.Violations)
+	})
+
+	t.Run("type errors are reported per field", func(t *testing.T) {
+		body := `{"name":"Tom","yearsOfExperience":"many","breed":"abys","salary":100}`
+		w, problem := serve(server.Handler(), httptest.NewRequest(http.MethodPost, APIV1+Endpoints.CatCreate, strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []myerrors.Violation{{Field: "yearsOfExperience", Rule: "type", Message: "must be a number"}}, problem.Violations)
+	})
+
+	t.Run("photo of unsupported content type", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPut, APIV1+"/cats/1/photo", bytes.NewReader(make([]byte, maxBodySize+1)))
+		request.Header.Set("Content-Type", "application/octet-stream")
+		w, _ := serve(server.Handler(), request)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		w, problem := serve(server.Handler(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, myerrors.CodeNotFound, problem.Code)
+	})
+
+	t.Run("internal errors are hidden", func(t *testing.T) {
+		router := gin.New()
+		router.Use(ErrorHandler())
+		router.GET("/app-error", func(ctx *gin.Context) {
+			ctx.Error(myerrors.NewServerError("Error 1146: Table 'spycatagency.cats' doesn't exist"))
+		})
+		router.GET("/unexpected-error", func(ctx *gin.Context) {
+			ctx.Error(errors.New("dial tcp 10.0.0.1:3306: connection refused"))
+		})
+
+		for _, path := range []string{"/app-error", "/unexpected-error"} {
+			w, problem := serve(router, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.Equal(t, myerrors.CodeInternal, problem.Code)
+			assert.NotEmpty(t, problem.CorrelationId)
+			assert.Contains(t, problem.Detail, problem.CorrelationId)
+			assert.NotContains(t, w.Body.String(), "spycatagency.cats")
+			assert.NotContains(t, w.Body.String(), "3306")
+		}
+	})
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	t.Run("large body is logged in part and passed on whole", func(t *testing.T) {
+		router := gin.New()
+		router.Use(SimpleLoggingMiddleware())
+		var received int
+		router.POST("/items", func(ctx *gin.Context) {
+			body, _ := io.ReadAll(ctx.Request.Body)
+			received = len(body)
+			ctx.Status(http.StatusNoContent)
+		})
+
+		body := `{"name":"` + strings.Repeat("a", 2*maxBodySize) + `"}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, len(body), received)
+	})
+}
+
+func TestIdempotency(t *testing.T) {
+	newRouter := func(handler gin.HandlerFunc) *gin.Engine {
+		router := gin.New()
+		router.Use(Idempotency(repositories.NewMemoryIdempotencyRepository(), time.Hour))
+		router.Use(ErrorHandler())
+		router.POST("/items", handler)
+		router.GET("/items", handler)
+		return router
+	}
+	send := func(router *gin.Engine, method, key, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, "/items", strings.NewReader(body))
+		if key != "" {
+			request.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+		return w
+	}
+
+	t.Run("retry replays stored response", func(t *testing.T) {
+		calls := 0
+		router := newRouter(func(ctx *gin.Context) {
+			calls++
+			ctx.JSON(http.StatusCreated, gin.H{"id": calls})
+		})
+
+		first := send(router, http.MethodPost, "key", `{"name":"a"}`)
+		retry := send(router, http.MethodPost, "key", `{"name":"a"}`)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, first.Header().Get("Content-Type"), retry.Header().Get("Content-Type"))
+
+		other := send(router, http.MethodPost, "other key", `{"name":"a"}`)
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, other.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("key reused with different body", func(t *testing.T) {
+		router := newRouter(func(ctx *gin.Context) {
+			ctx.JSON(http.StatusCreated, nil)
+		})
+
+		send(router, http.MethodPost, "key", `{"name":"a"}`)
+		w := send(router, http.MethodPost, "key", `{"name":"b"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var problem myerrors.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, myerrors.CodeIdempotencyKeyReused, problem.Code)
+	})
+
+	t.Run("too large body", func(t *testing.T) {
+		calls := 0
+		router := newRouter(func(ctx *gin.Context) {
+			calls++
+			ctx.JSON(http.StatusCreated, nil)
+		})
+
+		w := send(router, http.MethodPost, "key", strings.Repeat("a", maxBodySize+1))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("request in progress", func(t *testing.T) {
+		started := make(chan bool)
+		release := make(chan bool)
+		router := newRouter(func(ctx *gin.Context) {
+			started <- true
+			<-release
+			ctx.JSON(http.StatusCreated, nil)
+		})
+
+		done := make(chan *httptest.ResponseRecorder)
+		go func() {
+			done <- send(router, http.MethodPost, "key", `{}`)
+		}()
+		<-started
+		w := send(router, http.MethodPost, "key", `{}`)
+		close(release)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, http.StatusCreated, (<-done).Code)
+	})
+
+	t.Run("server errors are not stored", func(t *testing.T) {
+		calls := 0
+		router := newRouter(func(ctx *gin.Context) {
+			calls++
+			if calls == 1 {
+				ctx.Error(errors.New("connection refused"))
+				return
+			}
+			ctx.JSON(http.StatusCreated, nil)
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, send(router, http.MethodPost, "key", `{}`).Code)
+		assert.Equal(t, http.StatusCreated, send(router, http.MethodPost, "key", `{}`).Code)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("only POST requests with key are deduplicated", func(t *testing.T) {
+		calls := 0
+		router := newRouter(func(ctx *gin.Context) {
+			calls++
+			ctx.JSON(http.StatusOK, nil)
+		})
+
+		send(router, http.MethodGet, "key", "")
+		send(router, http.MethodGet, "key", "")
+		send(router, http.MethodPost, "", `{}`)
+		send(router, http.MethodPost, "", `{}`)
+		assert.Equal(t, 4, calls)
+	})
+}
+
+func TestStreamExport(t *testing.T) {
+	newRouter := func() *gin.Engine {
+		router := gin.New()
+		router.GET("/export", func(ctx *gin.Context) {
+			streamExport(ctx, "cats", models.ExportFormatCSV, func(w io.Writer) error {
+				_, err := w.Write(bytes.Repeat([]byte("a"), 3*exportBufferSize))
+				return err
+			})
+		})
+		return router
+	}
+
+	t.Run("writer without deadlines", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 3*exportBufferSize, w.Body.Len())
+	})
+
+	t.Run("connection with write timeout", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(newRouter())
+		server.Config.WriteTimeout = time.Nanosecond
+		server.Start()
+		defer server.Close()
+
+		response, err := http.Get(server.URL + "/export")
+		require.NoError(t, err)
+		defer response.Body.Close()
+		body, err := io.ReadAll(response.Body)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3*exportBufferSize, len(body))
+	})
+}
+
+func TestGraphQL(t *testing.T) {
+	server := NewServer(&MockCatService{}, &MockCatAPI{}, &MockMissionService{}, &MockTemplateService{}, &MockPayrollService{}, &MockSkillService{}, &MockAbsenceService{}, &MockStatsService{}, &MockLedgerService{},
+		&MockHandlerService{}, &MockPhotoService{}, &MockBudgetService{}, &MockImportService{}, &MockExportService{}, &MockTransactor{}, repositories.NewMemoryIdempotencyRepository(), time.Hour)
+	type response struct {
+		Data   map[string]any `json:"data"`
+		Errors []struct {
+			Message    string `json:"message"`
+			Extensions struct {
+				Code       string               `json:"code"`
+				Status     int                  `json:"status"`
+				Violations []myerrors.Violation `json:"violations"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}
+	serve := func(body string) (*httptest.ResponseRecorder, response) {
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, Endpoints.GraphQL, strings.NewReader(body)))
+		var result response
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		return w, result
+	}
+
+	t.Run("query", func(t *testing.T) {
+		w, result := serve(`{"query":"{ cats { cats { id missions { id } } meta { total } } }"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, result.Errors)
+		assert.Contains(t, result.Data, "cats")
+	})
+
+	t.Run("errors of services are reported like problems", func(t *testing.T) {
+		body := `{"query":"mutation { addCat(input: {name: \"Tom\", yearsOfExperience: 1, breed: \"abys\", salary: 100, status: \"sleeping\"}) { id } }"}`
+		w, result := serve(body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, myerrors.CodeValidationFailed, result.Errors[0].Extensions.Code)
+			assert.Equal(t, http.StatusBadRequest, result.Errors[0].Extensions.Status)
+			assert.Equal(t, []myerrors.Violation{{Field: "status", Rule: "oneof", Message: "must be one of: recruit, active, suspended, retired, deceased"}},
+				result.Errors[0].Extensions.Violations)
+		}
+	})
+
+	t.Run("request without query", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, Endpoints.GraphQL, strings.NewReader(`{}`)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, myerrors.ProblemContentType, w.Header().Get("Content-Type"))
+	})
+}
+
+func TestBatch(t *testing.T) {
+	transactor := &MockTransactor{}
+	server := NewServer(&MockCatService{}, &MockCatAPI{}, &MockMissionService{}, &MockTemplateService{}, &MockPayrollService{}, &MockSkillService{}, &MockAbsenceService{}, &MockStatsService{}, &MockLedgerService{},
+		&MockHandlerService{}, &MockPhotoService{}, &MockBudgetService{}, &MockImportService{}, &MockExportService{}, transactor, repositories.NewMemoryIdempotencyRepository(), time.Hour)
+	serve := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		return w
+	}
+
+	t.Run("operations refer to results of earlier operations", func(t *testing.T) {
+		body := `{"operations":[
+			{"ref":"m","method":"POST","path":"/missions","body":{"targets":[{"name":"Jerry","country":"Spain"}]}},
+			{"method":"POST","path":"/missions/$m/targets","body":{"name":"Spike","country":"France"}},
+			{"method":"POST","path":"/missions/$m/assign/3"},
+			{"method":"DELETE","path":"/missions/$m/targets/4"}
+		]}`
+		w := serve(APIV2+Endpoints.Batch, body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.BatchResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		if assert.Len(t, response.Results, 4) {
+			assert.Equal(t, "m", response.Results[0].Ref)
+			assert.Equal(t, http.StatusCreated, response.Results[0].Status)
+			assert.Equal(t, http.StatusNoContent, response.Results[3].Status, "operations use API version of the batch")
+			assert.Empty(t, response.Results[3].Body)
+		}
+		assert.Equal(t, 1, transactor.committed)
+	})
+
+	t.Run("failed operation rolls back the batch", func(t *testing.T) {
+		body := `{"operations":[
+			{"ref":"m","method":"POST","path":"/missions","body":{"targets":[{"name":"Jerry","country":"Spain"}]}},
+			{"method":"POST","path":"/missions/$m/unknown"}
+		]}`
+		w := serve(APIV1+Endpoints.Batch, body)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, myerrors.ProblemContentType, w.Header().Get("Content-Type"))
+		var problem myerrors.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, myerrors.CodeNotFound, problem.Code)
+		assert.Equal(t, APIV1+Endpoints.Batch, problem.Instance)
+		if assert.NotNil(t, problem.Operation) {
+			assert.Equal(t, 1, *problem.Operation)
+		}
+		assert.Equal(t, 1, transactor.rolledBack)
+	})
+
+	t.Run("redirect rolls back the batch", func(t *testing.T) {
+		body := `{"operations":[
+			{"method":"POST","path":"/missions","body":{"targets":[{"name":"Jerry","country":"Spain"}]}},
+			{"method":"POST","path":"/missions/","body":{"targets":[{"name":"Jerry","country":"Spain"}]}}
+		]}`
+		w := serve(APIV1+Endpoints.Batch, body)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var problem myerrors.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "operation responded with status 307", problem.Detail)
+		if assert.NotNil(t, problem.Operation) {
+			assert.Equal(t, 1, *problem.Operation)
+		}
+		assert.Equal(t, 2, transactor.rolledBack)
+	})
+
+	t.Run("nested batch is detected on the cleaned path", func(t *testing.T) {
+		body := `{"operations":[
+			{"method":"POST","path":"/./batch"},
+			{"method":"POST","path":"/%62atch"},
+			{"method":"POST","path":"/missions/../batch/"}
+		]}`
+		w := serve(APIV1+Endpoints.Batch, body)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var problem myerrors.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Len(t, problem.Violations, 3)
+	})
+
+	t.Run("refs must be defined by earlier operations", func(t *testing.T) {
+		body := `{"operations":[
+			{"method":"POST","path":"/missions/$m/targets","body":{}},
+			{"ref":"m","method":"POST","path":"/missions","body":{}},
+			{"ref":"m","method":"POST","path":"/batch"}
+		]}`
+		w := serve(APIV1+Endpoints.Batch, body)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var problem myerrors.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		var rules []string
+		for _, v := range problem.Violations {
+			rules = append(rules, v.Field+" "+v.Rule)
+		}
+		assert.ElementsMatch(t, []string{"operations[0].path ref", "operations[2].path nested", "operations[2].ref unique"}, rules)
+		assert.Equal(t, 1, transactor.committed, "invalid batch doesn't start a transaction")
+		assert.Equal(t, 2, transactor.rolledBack, "invalid batch doesn't start a transaction")
+	})
+}
+
 type MockCatService struct {
 	mock.Mock
 	addCounter     int
@@ -96,10 +545,53 @@ type MockMissionService struct {
 type MockCatAPI struct {
 }
 
+type MockTemplateService struct {
+}
+
+type MockPayrollService struct {
+}
+
+type MockSkillService struct {
+}
+
+type MockAbsenceService struct {
+}
+
+type MockStatsService struct {
+}
+
+type MockLedgerService struct {
+}
+
+type MockHandlerService struct {
+}
+
+type MockPhotoService struct {
+}
+
+type MockBudgetService struct {
+}
+
+type MockImportService struct {
+}
+
+type MockExportService struct {
+}
+
+// MockTransactor runs functions without a database and counts their outcomes
+type MockTransactor struct {
+	committed  int
+	rolledBack int
+}
+
 func (m *MockCatAPI) GetBreedById(ctx context.Context, id string) (catapi.Breed, error) {
 	return catapi.Breed{}, nil
 }
 
+func (m *MockCatAPI) GetAllBreeds(ctx context.Context) ([]catapi.Breed, error) {
+	return nil, nil
+}
+
 func (m *MockCatService) Add(ctx context.Context, cat models.Cat) (models.Cat, error) {
 	m.onRequestStart <- true
 	time.Sleep(5 * time.Second)
@@ -119,6 +611,10 @@ func (m *MockCatService) GetById(ctx context.Context, id int64) (models.Cat, err
 	return models.Cat{}, nil
 }
 
+func (m *MockCatService) GetByIds(ctx context.Context, ids []int64) (map[int64]models.Cat, error) {
+	return map[int64]models.Cat{}, nil
+}
+
 func (m *MockCatService) Update(ctx context.Context, id int64, update models.CatUpdate) (models.Cat, error) {
 	return models.Cat{}, nil
 }
@@ -127,10 +623,34 @@ func (m *MockCatService) DeleteById(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (m *MockCatService) GetAll(ctx context.Context, query models.PaginationQuery) (models.PaginatedCats, error) {
+func (m *MockCatService) GetAll(ctx context.Context, query models.CatQuery) (models.PaginatedCats, error) {
 	return models.PaginatedCats{}, nil
 }
 
+func (m *MockCatService) UpdateStatus(ctx context.Context, id int64, update models.StatusUpdate) (models.Cat, error) {
+	return models.Cat{}, nil
+}
+
+func (m *MockCatService) GetStatusHistory(ctx context.Context, id int64) ([]models.StatusChange, error) {
+	return nil, nil
+}
+
+func (m *MockCatService) GetPromotions(ctx context.Context, id int64) ([]models.Promotion, error) {
+	return nil, nil
+}
+
+func (m *MockCatService) GetRanks(ctx context.Context) []models.Rank {
+	return nil
+}
+
+func (m *MockCatService) Patch(ctx context.Context, id int64, patch []byte) (models.Cat, error) {
+	return models.Cat{}, nil
+}
+
+func (m *MockCatService) GetSalaryHistory(ctx context.Context, id int64) ([]models.SalaryChange, error) {
+	return nil, nil
+}
+
 func (m *MockMissionService) Add(ctx context.Context, mission models.Mission) (models.Mission, error) {
 	return models.Mission{}, nil
 }
@@ -139,12 +659,24 @@ func (m *MockMissionService) GetById(ctx context.Context, id int64) (models.Miss
 	return models.Mission{}, nil
 }
 
-func (m *MockMissionService) GetAll(ctx context.Context, query models.PaginationQuery) (models.PaginatedMissions, error) {
+func (m *MockMissionService) GetByCatIds(ctx context.Context, catIds []int64) (map[int64][]models.Mission, error) {
+	return map[int64][]models.Mission{}, nil
+}
+
+func (m *MockMissionService) GetAll(ctx context.Context, query models.PaginationQuery, filter models.MissionFilter) (models.PaginatedMissions, error) {
 	return models.PaginatedMissions{}, nil
 }
 
-func (m *MockMissionService) Assign(ctx context.Context, missionId, catId int64) error {
-	return nil
+func (m *MockMissionService) GetByCatId(ctx context.Context, catId int64, query models.CatMissionQuery) (models.PaginatedMissions, error) {
+	return models.PaginatedMissions{}, nil
+}
+
+func (m *MockMissionService) Assign(ctx context.Context, missionId, catId int64, override bool) (models.AssignResult, error) {
+	return models.AssignResult{}, nil
+}
+
+func (m *MockMissionService) UpdateWindow(ctx context.Context, missionId int64, window models.MissionWindow) (models.Mission, error) {
+	return models.Mission{}, nil
 }
 
 func (m *MockMissionService) CompleteTarget(ctx context.Context, missionId, targetId int64) error {
@@ -170,3 +702,183 @@ func (m *MockMissionService) Complete(ctx context.Context, missionId int64) (mod
 func (m *MockMissionService) Delete(ctx context.Context, missionId int64) error {
 	return nil
 }
+
+func (m *MockMissionService) GetTypes(ctx context.Context) []models.MissionType {
+	return nil
+}
+
+func (m *MockMissionService) AddFromTemplate(ctx context.Context, templateId int64) (models.Mission, error) {
+	return models.Mission{}, nil
+}
+
+func (m *MockMissionService) Clone(ctx context.Context, missionId int64) (models.Mission, error) {
+	return models.Mission{}, nil
+}
+
+func (m *MockTemplateService) Add(ctx context.Context, template models.MissionTemplate) (models.MissionTemplate, error) {
+	return models.MissionTemplate{}, nil
+}
+
+func (m *MockTemplateService) GetById(ctx context.Context, id int64) (models.MissionTemplate, error) {
+	return models.MissionTemplate{}, nil
+}
+
+func (m *MockTemplateService) GetAll(ctx context.Context, query models.PaginationQuery) (models.PaginatedTemplates, error) {
+	return models.PaginatedTemplates{}, nil
+}
+
+func (m *MockTemplateService) Update(ctx context.Context, id int64, template models.MissionTemplate) (models.MissionTemplate, error) {
+	return models.MissionTemplate{}, nil
+}
+
+func (m *MockTemplateService) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (m *MockPayrollService) Calculate(ctx context.Context, query models.PayrollQuery) (models.Payroll, error) {
+	return models.Payroll{}, nil
+}
+
+func (m *MockSkillService) Add(ctx context.Context, skill models.Skill) (models.Skill, error) {
+	return models.Skill{}, nil
+}
+
+func (m *MockSkillService) GetAll(ctx context.Context) ([]models.Skill, error) {
+	return nil, nil
+}
+
+func (m *MockSkillService) GetCatSkills(ctx context.Context, catId int64) ([]models.CatSkill, error) {
+	return nil, nil
+}
+
+func (m *MockSkillService) SetCatSkills(ctx context.Context, catId int64, skills []models.CatSkill) ([]models.CatSkill, error) {
+	return nil, nil
+}
+
+func (m *MockAbsenceService) Add(ctx context.Context, catId int64, absence models.Absence) (models.Absence, error) {
+	return models.Absence{}, nil
+}
+
+func (m *MockAbsenceService) GetByCatId(ctx context.Context, catId int64) ([]models.Absence, error) {
+	return nil, nil
+}
+
+func (m *MockAbsenceService) Update(ctx context.Context, catId, absenceId int64, absence models.Absence) (models.Absence, error) {
+	return models.Absence{}, nil
+}
+
+func (m *MockAbsenceService) Delete(ctx context.Context, catId, absenceId int64) error {
+	return nil
+}
+
+func (m *MockAbsenceService) GetAvailableCats(ctx context.Context, query models.AvailabilityQuery) ([]models.Cat, error) {
+	return nil, nil
+}
+
+func (m *MockStatsService) GetCatStats(ctx context.Context, catId int64) (models.CatStats, error) {
+	return models.CatStats{}, nil
+}
+
+func (m *MockStatsService) GetLeaderboard(ctx context.Context, query models.LeaderboardQuery) ([]models.CatStats, error) {
+	return nil, nil
+}
+
+func (m *MockLedgerService) Add(ctx context.Context, catId int64, entry models.LedgerEntry) (models.LedgerEntry, error) {
+	return models.LedgerEntry{}, nil
+}
+
+func (m *MockLedgerService) GetByCatId(ctx context.Context, catId int64) (models.Ledger, error) {
+	return models.Ledger{}, nil
+}
+
+func (m *MockHandlerService) Add(ctx context.Context, handler models.Handler) (models.Handler, error) {
+	return models.Handler{}, nil
+}
+
+func (m *MockHandlerService) GetById(ctx context.Context, id int64) (models.Handler, error) {
+	return models.Handler{}, nil
+}
+
+func (m *MockHandlerService) GetAll(ctx context.Context) ([]models.Handler, error) {
+	return nil, nil
+}
+
+func (m *MockHandlerService) GetCats(ctx context.Context, id int64, query models.CatQuery) (models.PaginatedCats, error) {
+	return models.PaginatedCats{}, nil
+}
+
+func (m *MockHandlerService) GetMissions(ctx context.Context, id int64, query models.PaginationQuery) (models.PaginatedMissions, error) {
+	return models.PaginatedMissions{}, nil
+}
+
+func (m *MockHandlerService) GetDashboard(ctx context.Context, id int64) (models.HandlerDashboard, error) {
+	return models.HandlerDashboard{}, nil
+}
+
+func (m *MockHandlerService) TransferCat(ctx context.Context, catId, handlerId int64) (models.Cat, error) {
+	return models.Cat{}, nil
+}
+
+func (m *MockHandlerService) UpdateBudget(ctx context.Context, id int64, update models.HandlerBudgetUpdate) (models.Handler, error) {
+	return models.Handler{}, nil
+}
+
+func (m *MockBudgetService) Get(ctx context.Context) (models.Budget, error) {
+	return models.Budget{}, nil
+}
+
+func (m *MockBudgetService) CheckNewCat(ctx context.Context, cat models.Cat) error {
+	return nil
+}
+
+func (m *MockBudgetService) CheckNewCats(ctx context.Context, cats []models.Cat) error {
+	return nil
+}
+
+func (m *MockBudgetService) CheckSalaryChange(ctx context.Context, cat models.Cat, salary int) error {
+	return nil
+}
+
+func (m *MockBudgetService) CheckTransfer(ctx context.Context, cat models.Cat, handlerId int64) error {
+	return nil
+}
+
+func (m *MockBudgetService) CheckRaise(ctx context.Context, cat models.Cat, raise int) error {
+	return nil
+}
+
+func (m *MockImportService) ImportCats(ctx context.Context, format string, file io.Reader, mode string) (models.CatImportReport, error) {
+	return models.CatImportReport{}, nil
+}
+
+func (m *MockExportService) ExportCats(ctx context.Context, query models.CatExportQuery, w io.Writer) error {
+	return nil
+}
+
+func (m *MockExportService) ExportMissions(ctx context.Context, query models.MissionExportQuery, w io.Writer) error {
+	return nil
+}
+
+func (m *MockTransactor) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		m.rolledBack++
+		return err
+	}
+	m.committed++
+	return nil
+}
+
+func (m *MockPhotoService) Upload(ctx context.Context, catId int64, photo io.Reader) (models.CatPhoto, error) {
+	return models.CatPhoto{}, nil
+}
+
+func (m *MockPhotoService) Get(ctx context.Context, catId int64) (models.CatPhoto, error) {
+	return models.CatPhoto{}, nil
+}
+
+func (m *MockPhotoService) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (m *MockPhotoService) Remove(ctx context.Context, cat models.Cat) {
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+)
+
+type AbsenceService interface {
+	Add(ctx context.Context, catId int64, absence models.Absence) (models.Absence, error)
+	GetByCatId(ctx context.Context, catId int64) ([]models.Absence, error)
+	Update(ctx context.Context, catId, absenceId int64, absence models.Absence) (models.Absence, error)
+	Delete(ctx context.Context, catId, absenceId int64) error
+	GetAvailableCats(ctx context.Context, query models.AvailabilityQuery) ([]models.Cat, error)
+}
+
+type DefaultAbsenceService struct {
+	absenceRepo repositories.AbsenceRepository
+	catRepo     repositories.CatRepository
+}
+
+func NewDefaultAbsenceService(absenceRepo repositories.AbsenceRepository, catRepo repositories.CatRepository) *DefaultAbsenceService {
+	return &DefaultAbsenceService{
+		absenceRepo: absenceRepo,
+		catRepo:     catRepo,
+	}
+}
+
+func (d *DefaultAbsenceService) Add(ctx context.Context, catId int64, absence models.Absence) (models.Absence, error) {
+	err := d.catExists(ctx, catId)
+	if err != nil {
+		return models.Absence{}, err
+	}
+	absence, err = normalizeAbsence(absence)
+	if err != nil {
+		return models.Absence{}, err
+	}
+	absence.CatId = catId
+	newAbsence, err := d.absenceRepo.Add(ctx, absence)
+	if err != nil {
+		return models.Absence{}, myerrors.NewServerError(err.Error())
+	}
+	return newAbsence, nil
+}
+
+func (d *DefaultAbsenceService) GetByCatId(ctx context.Context, catId int64) ([]models.Absence, error) {
+	err := d.catExists(ctx, catId)
+	if err != nil {
+		return nil, err
+	}
+	absences, err := d.absenceRepo.GetByCatId(ctx, catId)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	return absences, nil
+}
+
+func (d *DefaultAbsenceService) Update(ctx context.Context, catId, absenceId int64, absence models.Absence) (models.Absence, error) {
+	_, err := d.getCatAbsence(ctx, catId, absenceId)
+	if err != nil {
+		return models.Absence{}, err
+	}
+	absence, err = normalizeAbsence(absence)
+	if err != nil {
+		return models.Absence{}, err
+	}
+	absence.Id = absenceId
+	absence.CatId = catId
+	err = d.absenceRepo.Update(ctx, absence)
+	if err != nil {
+		return models.Absence{}, myerrors.NewServerError(err.Error())
+	}
+	return absence, nil
+}
+
+func (d *DefaultAbsenceService) Delete(ctx context.Context, catId, absenceId int64) error {
+	_, err := d.getCatAbsence(ctx, catId, absenceId)
+	if err != nil {
+		return err
+	}
+	err = d.absenceRepo.Delete(ctx, absenceId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrAbsenceNotFound) {
+			return myerrors.NewNotFoundError(err.Error())
+		}
+		return myerrors.NewServerError(err.Error())
+	}
+	return nil
+}
+
+// GetAvailableCats returns cats that can be assigned to a mission at any moment between from and to dates inclusively
+func (d *DefaultAbsenceService) GetAvailableCats(ctx context.Context, query models.AvailabilityQuery) ([]models.Cat, error) {
+	from := query.From.UTC()
+	to := query.To.UTC().AddDate(0, 0, 1)
+	if !from.Before(to) {
+		return nil, myerrors.NewBadRequestError("from date must not be after to date")
+	}
+	cats, err := d.catRepo.GetAvailable(ctx, from, to)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	if cats == nil {
+		cats = []models.Cat{}
+	}
+	return cats, nil
+}
+
+// getCatAbsence returns the absence only when it belongs to the cat
+func (d *DefaultAbsenceService) getCatAbsence(ctx context.Context, catId, absenceId int64) (models.Absence, error) {
+	absence, err := d.absenceRepo.GetById(ctx, absenceId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrAbsenceNotFound) {
+			return models.Absence{}, myerrors.NewNotFoundError(err.Error())
+		}
+		return models.Absence{}, myerrors.NewServerError(err.Error())
+	}
+	if absence.CatId != catId {
+		return models.Absence{}, myerrors.NewNotFoundError(repositories.ErrAbsenceNotFound.Error())
+	}
+	return absence, nil
+}
+
+func (d *DefaultAbsenceService) catExists(ctx context.Context, catId int64) error {
+	err := d.catRepo.Exists(ctx, catId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return myerrors.NewNotFoundError(err.Error())
+		}
+		return myerrors.NewServerError(err.Error())
+	}
+	return nil
+}
+
+func normalizeAbsence(absence models.Absence) (models.Absence, error) {
+	// DATETIME column keeps only seconds
+	absence.From = absence.From.UTC().Truncate(time.Second)
+	absence.To = absence.To.UTC().Truncate(time.Second)
+	if !absence.From.Before(absence.To) {
+		return models.Absence{}, myerrors.NewBadRequestError("absence must end after it starts")
+	}
+	return absence, nil
+}
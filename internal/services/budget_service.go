@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+)
+
+// BudgetService keeps monthly payroll within the agency budget and budgets of handlers.
+// Only changes that raise payroll are checked, so lowering a budget never blocks existing cats.
+// Checks lock the budgets until the end of the transaction of ctx, so they have to run in the transaction of Transactor
+// that writes the change
+type BudgetService interface {
+	Get(ctx context.Context) (models.Budget, error)
+	CheckNewCat(ctx context.Context, cat models.Cat) error
+	// CheckNewCats checks cats that are added together against the same budgets
+	CheckNewCats(ctx context.Context, cats []models.Cat) error
+	CheckSalaryChange(ctx context.Context, cat models.Cat, salary int) error
+	CheckTransfer(ctx context.Context, cat models.Cat, handlerId int64) error
+	// CheckRaise checks a raise of the cat salary and of its scheduled salary changes
+	CheckRaise(ctx context.Context, cat models.Cat, raise int) error
+}
+
+type DefaultBudgetService struct {
+	budgetRepo repositories.BudgetRepository
+	// limit of the agency payroll. Zero means there is no cap
+	limit int
+}
+
+func NewDefaultBudgetService(budgetRepo repositories.BudgetRepository, limit int) *DefaultBudgetService {
+	return &DefaultBudgetService{
+		budgetRepo: budgetRepo,
+		limit:      limit,
+	}
+}
+
+func (d *DefaultBudgetService) Get(ctx context.Context) (models.Budget, error) {
+	used, err := d.budgetRepo.GetCommittedPayroll(ctx)
+	if err != nil {
+		return models.Budget{}, myerrors.NewServerError(err.Error())
+	}
+	handlers, err := d.budgetRepo.GetHandlerBudgets(ctx)
+	if err != nil {
+		return models.Budget{}, myerrors.NewServerError(err.Error())
+	}
+	budget := models.Budget{Used: used, Handlers: make([]models.HandlerBudget, 0, len(handlers))}
+	if d.limit > 0 {
+		budget.Limit = &d.limit
+		budget.Remaining = remaining(d.limit, used)
+	}
+	for _, h := range handlers {
+		if h.Limit != nil {
+			h.Remaining = remaining(*h.Limit, h.Used)
+		}
+		budget.Handlers = append(budget.Handlers, h)
+	}
+	return budget, nil
+}
+
+func (d *DefaultBudgetService) CheckNewCat(ctx context.Context, cat models.Cat) error {
+	return d.CheckNewCats(ctx, []models.Cat{cat})
+}
+
+func (d *DefaultBudgetService) CheckNewCats(ctx context.Context, cats []models.Cat) error {
+	total := 0
+	var handlerIds []int64
+	byHandler := make(map[int64]int)
+	for _, cat := range cats {
+		if !onPayroll(cat.Status) {
+			continue
+		}
+		total += cat.Salary
+		if _, ok := byHandler[cat.HandlerId]; !ok {
+			handlerIds = append(handlerIds, cat.HandlerId)
+		}
+		byHandler[cat.HandlerId] += cat.Salary
+	}
+	if total == 0 {
+		return nil
+	}
+	if err := d.lock(ctx, true, handlerIds...); err != nil {
+		return err
+	}
+	if err := d.checkAgency(ctx, total); err != nil {
+		return err
+	}
+	for _, handlerId := range handlerIds {
+		if err := d.checkHandler(ctx, handlerId, byHandler[handlerId]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckSalaryChange compares new salary with the latest salary of the cat, including scheduled raises
+func (d *DefaultBudgetService) CheckSalaryChange(ctx context.Context, cat models.Cat, salary int) error {
+	if !onPayroll(cat.Status) {
+		return nil
+	}
+	if err := d.lock(ctx, true, cat.HandlerId); err != nil {
+		return err
+	}
+	committed, err := d.budgetRepo.GetCommittedSalary(ctx, cat.Id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return myerrors.NewNotFoundError(err.Error())
+		}
+		return myerrors.NewServerError(err.Error())
+	}
+	if err := d.checkAgency(ctx, salary-committed); err != nil {
+		return err
+	}
+	return d.checkHandler(ctx, cat.HandlerId, salary-committed)
+}
+
+// CheckTransfer checks budget of the new handler. Payroll of the agency does not change
+func (d *DefaultBudgetService) CheckTransfer(ctx context.Context, cat models.Cat, handlerId int64) error {
+	if !onPayroll(cat.Status) || cat.HandlerId == handlerId {
+		return nil
+	}
+	if err := d.lock(ctx, false, handlerId); err != nil {
+		return err
+	}
+	committed, err := d.budgetRepo.GetCommittedSalary(ctx, cat.Id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return myerrors.NewNotFoundError(err.Error())
+		}
+		return myerrors.NewServerError(err.Error())
+	}
+	return d.checkHandler(ctx, handlerId, committed)
+}
+
+func (d *DefaultBudgetService) CheckRaise(ctx context.Context, cat models.Cat, raise int) error {
+	if !onPayroll(cat.Status) || raise <= 0 {
+		return nil
+	}
+	if err := d.lock(ctx, true, cat.HandlerId); err != nil {
+		return err
+	}
+	if err := d.checkAgency(ctx, raise); err != nil {
+		return err
+	}
+	return d.checkHandler(ctx, cat.HandlerId, raise)
+}
+
+// lock locks budgets of the handlers and the agency budget when it is capped and agency payroll changes
+func (d *DefaultBudgetService) lock(ctx context.Context, agency bool, handlerIds ...int64) error {
+	if err := d.budgetRepo.Lock(ctx, agency && d.limit > 0, handlerIds); err != nil {
+		return myerrors.NewServerError(err.Error())
+	}
+	return nil
+}
+
+func (d *DefaultBudgetService) checkAgency(ctx context.Context, increase int) error {
+	if d.limit == 0 || increase <= 0 {
+		return nil
+	}
+	used, err := d.budgetRepo.GetCommittedPayroll(ctx)
+	if err != nil {
+		return myerrors.NewServerError(err.Error())
+	}
+	if used+increase > d.limit {
+		return myerrors.NewBadRequestError(fmt.Sprintf(
+			"monthly payroll would become %d, which exceeds agency budget of %d by %d", used+increase, d.limit, used+increase-d.limit))
+	}
+	return nil
+}
+
+func (d *DefaultBudgetService) checkHandler(ctx context.Context, handlerId int64, increase int) error {
+	if handlerId == 0 || increase <= 0 {
+		return nil
+	}
+	budget, err := d.budgetRepo.GetHandlerBudget(ctx, handlerId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrHandlerNotFound) {
+			return myerrors.NewBadRequestError(err.Error())
+		}
+		return myerrors.NewServerError(err.Error())
+	}
+	if budget.Limit != nil && budget.Used+increase > *budget.Limit {
+		return myerrors.NewBadRequestError(fmt.Sprintf(
+			"monthly payroll of handler %q would become %d, which exceeds the handler budget of %d by %d",
+			budget.Name, budget.Used+increase, *budget.Limit, budget.Used+increase-*budget.Limit))
+	}
+	return nil
+}
+
+// onPayroll reports whether the cat with the status is paid
+func onPayroll(status string) bool {
+	return status != models.CatStatusRetired && status != models.CatStatusDeceased
+}
+
+func remaining(limit, used int) *int {
+	r := limit - used
+	return &r
+}
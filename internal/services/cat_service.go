@@ -2,12 +2,18 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/4oBuko/spy-cat-agency/internal/models"
 	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
 	"github.com/4oBuko/spy-cat-agency/internal/repositories"
 	"github.com/4oBuko/spy-cat-agency/pkg/catapi"
+	"github.com/4oBuko/spy-cat-agency/pkg/mergepatch"
+	"github.com/gin-gonic/gin/binding"
 )
 
 var MaxCatsPerPage = 50
@@ -16,35 +22,89 @@ var DefaultCatsPageSize = 10
 type CatService interface {
 	Add(ctx context.Context, cat models.Cat) (models.Cat, error)
 	GetById(ctx context.Context, id int64) (models.Cat, error)
+	// GetByIds loads several cats at once. Cats that don't exist are missing in the result
+	GetByIds(ctx context.Context, ids []int64) (map[int64]models.Cat, error)
 	Update(ctx context.Context, id int64, update models.CatUpdate) (models.Cat, error)
 	DeleteById(ctx context.Context, id int64) error
-	GetAll(ctx context.Context, query models.PaginationQuery) (models.PaginatedCats, error)
+	GetAll(ctx context.Context, query models.CatQuery) (models.PaginatedCats, error)
+	GetSalaryHistory(ctx context.Context, id int64) ([]models.SalaryChange, error)
+	Patch(ctx context.Context, id int64, patch []byte) (models.Cat, error)
+	UpdateStatus(ctx context.Context, id int64, update models.StatusUpdate) (models.Cat, error)
+	GetStatusHistory(ctx context.Context, id int64) ([]models.StatusChange, error)
+	GetPromotions(ctx context.Context, id int64) ([]models.Promotion, error)
+	GetRanks(ctx context.Context) []models.Rank
+}
+
+// catStatusTransitions lists statuses the cat can move to from its current status
+var catStatusTransitions = map[string][]string{
+	models.CatStatusRecruit:   {models.CatStatusActive, models.CatStatusRetired, models.CatStatusDeceased},
+	models.CatStatusActive:    {models.CatStatusSuspended, models.CatStatusRetired, models.CatStatusDeceased},
+	models.CatStatusSuspended: {models.CatStatusActive, models.CatStatusRetired, models.CatStatusDeceased},
+	models.CatStatusRetired:   {models.CatStatusDeceased},
+	models.CatStatusDeceased:  {},
+}
+
+// patchableCatFields are fields of models.Cat that can be changed with a merge patch
+var patchableCatFields = map[string]bool{
+	"name":              true,
+	"yearsOfExperience": true,
+	"breed":             true,
+	"salary":            true,
 }
 
 type DefaultCatService struct {
-	catRepo repositories.CatRepository
-	catAPI  catapi.CatAPI
+	catRepo    repositories.CatRepository
+	salaryRepo repositories.SalaryRepository
+	statusRepo repositories.StatusRepository
+	rankRepo   repositories.RankRepository
+	transactor repositories.Transactor
+	catAPI     catapi.CatAPI
+	ranks      *Ranks
+	budget     BudgetService
+	photos     PhotoService
 }
 
-func NewDefaultCatService(catRepo repositories.CatRepository, catAPI catapi.CatAPI) *DefaultCatService {
+func NewDefaultCatService(catRepo repositories.CatRepository, salaryRepo repositories.SalaryRepository,
+	statusRepo repositories.StatusRepository, rankRepo repositories.RankRepository, transactor repositories.Transactor,
+	catAPI catapi.CatAPI, ranks *Ranks, budget BudgetService, photos PhotoService) *DefaultCatService {
 	return &DefaultCatService{
-		catRepo: catRepo,
-		catAPI:  catAPI,
+		catRepo:    catRepo,
+		salaryRepo: salaryRepo,
+		statusRepo: statusRepo,
+		rankRepo:   rankRepo,
+		transactor: transactor,
+		catAPI:     catAPI,
+		ranks:      ranks,
+		budget:     budget,
+		photos:     photos,
 	}
 }
 
 func (d *DefaultCatService) Add(ctx context.Context, cat models.Cat) (models.Cat, error) {
-	breed, err := d.catAPI.GetBreedById(ctx, cat.Breed)
+	breed, err := d.resolveBreed(ctx, cat.Breed)
 	if err != nil {
-		if errors.Is(err, catapi.ErrBreedNotFound) {
-			return models.Cat{}, myerrors.NewBadRequestError(err.Error())
-		}
-		return models.Cat{}, myerrors.NewServerError(err.Error())
+		return models.Cat{}, err
 	}
-	cat.Breed = breed.Id
-	newCat, err := d.catRepo.Add(ctx, cat)
+	cat, err = prepareNewCat(cat, breed, d.ranks)
 	if err != nil {
-		return models.Cat{}, myerrors.NewServerError(err.Error())
+		return models.Cat{}, err
+	}
+	var newCat models.Cat
+	err = d.transactor.InTransaction(ctx, func(ctx context.Context) error {
+		if err := d.budget.CheckNewCat(ctx, cat); err != nil {
+			return err
+		}
+		newCat, err = d.catRepo.Add(ctx, cat)
+		if err != nil {
+			if errors.Is(err, repositories.ErrHandlerNotFound) {
+				return myerrors.NewBadRequestError(err.Error())
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Cat{}, appError(err)
 	}
 	return newCat, nil
 }
@@ -61,17 +121,49 @@ func (d *DefaultCatService) GetById(ctx context.Context, id int64) (models.Cat,
 }
 
 func (d *DefaultCatService) Update(ctx context.Context, id int64, update models.CatUpdate) (models.Cat, error) {
-	err := d.catRepo.Update(ctx, id, update)
-	if err != nil {
-		if errors.Is(err, repositories.ErrCatNotFound) {
-			return models.Cat{}, myerrors.NewNotFoundError(err.Error())
+	err := d.transactor.InTransaction(ctx, func(ctx context.Context) error {
+		cat, err := d.getForUpdate(ctx, id)
+		if err != nil {
+			return err
 		}
-		return models.Cat{}, myerrors.NewServerError(err.Error())
+		if err := d.budget.CheckSalaryChange(ctx, cat, update.Salary); err != nil {
+			return err
+		}
+		now := time.Now().UTC().Truncate(time.Second) // DATETIME column keeps only seconds
+		change := models.SalaryChange{
+			Salary:        update.Salary,
+			EffectiveFrom: now,
+			Reason:        update.Reason,
+			CreatedAt:     now,
+		}
+		if update.EffectiveFrom != nil {
+			if update.EffectiveFrom.Before(now) {
+				return myerrors.NewBadRequestError("effective date of salary change must not be in the past")
+			}
+			change.EffectiveFrom = update.EffectiveFrom.UTC()
+		}
+		if err := d.catRepo.UpdateSalary(ctx, id, change); err != nil {
+			if errors.Is(err, repositories.ErrCatNotFound) {
+				return myerrors.NewNotFoundError(err.Error())
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Cat{}, appError(err)
 	}
 	return d.GetById(ctx, id)
 }
 
 func (d *DefaultCatService) DeleteById(ctx context.Context, id int64) error {
+	cat, err := d.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if cat.Status == models.CatStatusRetired || cat.Status == models.CatStatusDeceased {
+		return myerrors.NewBadRequestError(fmt.Sprintf("%s cat keeps its mission history and cannot be deleted", cat.Status))
+	}
 	busy, err := d.catRepo.IsBusy(ctx, id)
 	if err != nil {
 		return myerrors.NewServerError(err.Error())
@@ -84,13 +176,38 @@ func (d *DefaultCatService) DeleteById(ctx context.Context, id int64) error {
 		if errors.Is(err, repositories.ErrCatNotFound) {
 			return myerrors.NewNotFoundError(err.Error())
 		}
+		if errors.Is(err, repositories.ErrCatHasHistory) {
+			return myerrors.NewBadRequestError("cat shares mission history with other cats or keeps missions it was released from and cannot be deleted. Retire the cat instead")
+		}
 		return myerrors.NewServerError(err.Error())
 	}
+	d.photos.Remove(ctx, cat)
 	return nil
 }
 
-func (d *DefaultCatService) GetAll(ctx context.Context, query models.PaginationQuery) (models.PaginatedCats, error) {
-	count, err := d.catRepo.GetCount(ctx)
+// getForUpdate returns the cat and locks it until the end of the transaction of ctx
+func (d *DefaultCatService) getForUpdate(ctx context.Context, id int64) (models.Cat, error) {
+	cat, err := d.catRepo.GetByIdForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return models.Cat{}, myerrors.NewNotFoundError(err.Error())
+		}
+		return models.Cat{}, myerrors.NewServerError(err.Error())
+	}
+	return cat, nil
+}
+
+func (d *DefaultCatService) GetByIds(ctx context.Context, ids []int64) (map[int64]models.Cat, error) {
+	cats, err := d.catRepo.GetByIds(ctx, ids)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	return cats, nil
+}
+
+func (d *DefaultCatService) GetAll(ctx context.Context, query models.CatQuery) (models.PaginatedCats, error) {
+	filter := models.CatFilter{Skill: query.Skill, Status: query.Status, HandlerId: query.HandlerId}
+	count, err := d.catRepo.GetCount(ctx, filter)
 	if err != nil {
 		return models.PaginatedCats{}, myerrors.NewServerError(err.Error())
 	}
@@ -112,7 +229,7 @@ func (d *DefaultCatService) GetAll(ctx context.Context, query models.PaginationQ
 	if query.Page > totalPages {
 		return models.PaginatedCats{}, myerrors.NewBadRequestError("request page is greater than total pages")
 	}
-	cats, err := d.catRepo.GetAll(ctx, limit, offset)
+	cats, err := d.catRepo.GetAll(ctx, filter, limit, offset)
 	if err != nil {
 		return models.PaginatedCats{}, myerrors.NewServerError(err.Error())
 	}
@@ -128,3 +245,229 @@ func (d *DefaultCatService) GetAll(ctx context.Context, query models.PaginationQ
 	}
 	return pCats, nil
 }
+
+func (d *DefaultCatService) GetSalaryHistory(ctx context.Context, id int64) ([]models.SalaryChange, error) {
+	err := d.catRepo.Exists(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return nil, myerrors.NewNotFoundError(err.Error())
+		}
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	history, err := d.salaryRepo.GetByCatId(ctx, id)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	return history, nil
+}
+
+// UpdateStatus moves the cat to a new employment status. Transition can be backdated,
+// but not earlier than the previous transition and not into the future
+func (d *DefaultCatService) UpdateStatus(ctx context.Context, id int64, update models.StatusUpdate) (models.Cat, error) {
+	now := time.Now().UTC().Truncate(time.Second) // DATETIME column keeps only seconds
+	change := models.StatusChange{
+		Status:        update.Status,
+		EffectiveFrom: now,
+		Reason:        update.Reason,
+		CreatedAt:     now,
+	}
+	if update.EffectiveFrom != nil {
+		change.EffectiveFrom = update.EffectiveFrom.UTC().Truncate(time.Second)
+	}
+
+	// the cat is locked while it is checked, so it can't get a mission before the new status is saved
+	err := d.transactor.InTransaction(ctx, func(ctx context.Context) error {
+		cat, err := d.getForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(catStatusTransitions[cat.Status], update.Status) {
+			return myerrors.NewBadRequestError(fmt.Sprintf("%s cat cannot become %s", cat.Status, update.Status))
+		}
+		if change.EffectiveFrom.After(now) {
+			return myerrors.NewBadRequestError("status change cannot be effective in the future")
+		}
+		history, err := d.statusRepo.GetByCatId(ctx, id)
+		if err != nil {
+			return myerrors.NewServerError(err.Error())
+		}
+		if len(history) > 0 && change.EffectiveFrom.Before(history[len(history)-1].EffectiveFrom) {
+			return myerrors.NewBadRequestError("status change cannot be effective before the previous one")
+		}
+
+		// deceased cat can't finish its missions, so they are released for other cats.
+		// Other statuses require the cat to finish its mission first
+		releaseMissions := update.Status == models.CatStatusDeceased
+		if !releaseMissions && update.Status != models.CatStatusActive {
+			busy, err := d.catRepo.IsBusy(ctx, id)
+			if err != nil {
+				return myerrors.NewServerError(err.Error())
+			}
+			if busy {
+				return myerrors.NewBadRequestError("cat is busy with a mission. Complete mission before changing the status")
+			}
+		}
+
+		if err := d.catRepo.UpdateStatus(ctx, id, change, releaseMissions); err != nil {
+			if errors.Is(err, repositories.ErrCatNotFound) {
+				return myerrors.NewNotFoundError(err.Error())
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Cat{}, appError(err)
+	}
+	return d.GetById(ctx, id)
+}
+
+func (d *DefaultCatService) GetStatusHistory(ctx context.Context, id int64) ([]models.StatusChange, error) {
+	err := d.catRepo.Exists(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return nil, myerrors.NewNotFoundError(err.Error())
+		}
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	history, err := d.statusRepo.GetByCatId(ctx, id)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	return history, nil
+}
+
+func (d *DefaultCatService) GetPromotions(ctx context.Context, id int64) ([]models.Promotion, error) {
+	err := d.catRepo.Exists(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return nil, myerrors.NewNotFoundError(err.Error())
+		}
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	promotions, err := d.rankRepo.GetPromotions(ctx, id)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	if promotions == nil {
+		promotions = []models.Promotion{}
+	}
+	return promotions, nil
+}
+
+func (d *DefaultCatService) GetRanks(ctx context.Context) []models.Rank {
+	return d.ranks.All()
+}
+
+// Patch applies JSON Merge Patch (RFC 7396) to the profile of the cat
+func (d *DefaultCatService) Patch(ctx context.Context, id int64, patch []byte) (models.Cat, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		return models.Cat{}, myerrors.NewBadRequestError("merge patch must be a JSON object")
+	}
+	for field := range fields {
+		if !patchableCatFields[field] {
+			return models.Cat{}, myerrors.NewBadRequestError(fmt.Sprintf("field %q cannot be patched", field))
+		}
+	}
+
+	cat, err := d.GetById(ctx, id)
+	if err != nil {
+		return models.Cat{}, err
+	}
+	document, err := json.Marshal(cat)
+	if err != nil {
+		return models.Cat{}, myerrors.NewServerError(err.Error())
+	}
+	patched, err := mergepatch.Apply(document, patch)
+	if err != nil {
+		return models.Cat{}, myerrors.NewBadRequestError(err.Error())
+	}
+	var patchedCat models.Cat
+	if err := json.Unmarshal(patched, &patchedCat); err != nil {
+		return models.Cat{}, myerrors.NewValidationError(err)
+	}
+	if err := binding.Validator.ValidateStruct(patchedCat); err != nil {
+		return models.Cat{}, myerrors.NewValidationError(err)
+	}
+	patchedCat.Id = id
+
+	if patchedCat.Breed != cat.Breed {
+		breed, err := d.resolveBreed(ctx, patchedCat.Breed)
+		if err != nil {
+			return models.Cat{}, err
+		}
+		patchedCat.Breed = breed.Id
+		patchedCat.BreedDetails = NewBreedDetails(breed)
+	}
+	err = d.transactor.InTransaction(ctx, func(ctx context.Context) error {
+		var salaryChange *models.SalaryChange
+		if patchedCat.Salary != cat.Salary {
+			locked, err := d.getForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := d.budget.CheckSalaryChange(ctx, locked, patchedCat.Salary); err != nil {
+				return err
+			}
+			now := time.Now().UTC().Truncate(time.Second)
+			salaryChange = &models.SalaryChange{
+				Salary:        patchedCat.Salary,
+				EffectiveFrom: now,
+				Reason:        "profile edit",
+				CreatedAt:     now,
+			}
+		}
+		if err := d.catRepo.UpdateProfile(ctx, patchedCat, salaryChange); err != nil {
+			if errors.Is(err, repositories.ErrCatNotFound) {
+				return myerrors.NewNotFoundError(err.Error())
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Cat{}, appError(err)
+	}
+	return d.GetById(ctx, id)
+}
+
+// prepareNewCat checks that the new cat has a handler and sets attributes that come from its breed and experience
+func prepareNewCat(cat models.Cat, breed catapi.Breed, ranks *Ranks) (models.Cat, error) {
+	if cat.HandlerId == 0 {
+		return models.Cat{}, myerrors.NewBadRequestError("new cat must have a handler")
+	}
+	cat.Breed = breed.Id
+	cat.BreedDetails = NewBreedDetails(breed)
+	switch cat.Status {
+	case "":
+		cat.Status = models.CatStatusActive
+	case models.CatStatusRecruit, models.CatStatusActive:
+	default:
+		return models.Cat{}, myerrors.NewBadRequestError("new cat can be only a recruit or active")
+	}
+	cat.Rank = ranks.For(cat.YearsOfExperience, 0).Name
+	cat.PhotoUrl = "" // photo is uploaded separately
+	return cat, nil
+}
+
+// NewBreedDetails keeps attributes of the breed that are shown together with the cat
+func NewBreedDetails(breed catapi.Breed) *models.BreedDetails {
+	return &models.BreedDetails{
+		Name:        breed.Name,
+		Origin:      breed.Origin,
+		Temperament: breed.Temperament,
+		LifeSpan:    breed.LifeSpan,
+	}
+}
+
+func (d *DefaultCatService) resolveBreed(ctx context.Context, id string) (catapi.Breed, error) {
+	breed, err := d.catAPI.GetBreedById(ctx, id)
+	if err != nil {
+		if errors.Is(err, catapi.ErrBreedNotFound) {
+			return catapi.Breed{}, myerrors.NewBadRequestError(err.Error())
+		}
+		return catapi.Breed{}, myerrors.NewServerError(err.Error())
+	}
+	return breed, nil
+}
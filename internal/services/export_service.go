@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+	"github.com/4oBuko/spy-cat-agency/pkg/xlsx"
+)
+
+type ExportService interface {
+	// ExportCats writes cats matching the query to w in the format of the query
+	ExportCats(ctx context.Context, query models.CatExportQuery, w io.Writer) error
+	// ExportMissions writes a row for every target of missions matching the query to w in the format of the query
+	ExportMissions(ctx context.Context, query models.MissionExportQuery, w io.Writer) error
+}
+
+type DefaultExportService struct {
+	catRepo     repositories.CatRepository
+	missionRepo repositories.MissionRepository
+}
+
+func NewDefaultExportService(catRepo repositories.CatRepository, missionRepo repositories.MissionRepository) *DefaultExportService {
+	return &DefaultExportService{
+		catRepo:     catRepo,
+		missionRepo: missionRepo,
+	}
+}
+
+// exportColumn is a column of CSV and XLSX exports. Nil values leave cells empty
+type exportColumn[T any] struct {
+	name  string
+	value func(T) any
+}
+
+var catExportColumns = []exportColumn[models.Cat]{
+	{"id", func(c models.Cat) any { return c.Id }},
+	{"name", func(c models.Cat) any { return c.Name }},
+	{"breed", func(c models.Cat) any { return c.Breed }},
+	{"breedName", func(c models.Cat) any {
+		if c.BreedDetails == nil {
+			return nil
+		}
+		return c.BreedDetails.Name
+	}},
+	{"yearsOfExperience", func(c models.Cat) any { return c.YearsOfExperience }},
+	{"salary", func(c models.Cat) any { return c.Salary }},
+	{"status", func(c models.Cat) any { return c.Status }},
+	{"rank", func(c models.Cat) any { return c.Rank }},
+	{"handlerId", func(c models.Cat) any { return optionalId(c.HandlerId) }},
+	{"photoUrl", func(c models.Cat) any { return c.PhotoUrl }},
+}
+
+var missionExportColumns = []exportColumn[models.MissionExportRow]{
+	{"missionId", func(r models.MissionExportRow) any { return r.MissionId }},
+	{"catId", func(r models.MissionExportRow) any { return optionalId(r.CatId) }},
+	{"type", func(r models.MissionExportRow) any { return r.Type }},
+	{"startsAt", func(r models.MissionExportRow) any { return optionalTime(r.StartsAt) }},
+	{"endsAt", func(r models.MissionExportRow) any { return optionalTime(r.EndsAt) }},
+	{"bonus", func(r models.MissionExportRow) any { return r.Bonus }},
+	{"completed", func(r models.MissionExportRow) any { return r.Completed }},
+	{"targetId", func(r models.MissionExportRow) any { return optionalId(r.TargetId) }},
+	{"targetName", func(r models.MissionExportRow) any { return r.TargetName }},
+	{"country", func(r models.MissionExportRow) any { return r.Country }},
+	{"countryCode", func(r models.MissionExportRow) any { return r.CountryCode }},
+	{"notes", func(r models.MissionExportRow) any { return r.Notes }},
+	{"targetCompleted", func(r models.MissionExportRow) any { return r.TargetCompleted }},
+}
+
+func (d *DefaultExportService) ExportCats(ctx context.Context, query models.CatExportQuery, w io.Writer) error {
+	writer, err := newExportWriter(query.Format, w, "cats", catExportColumns)
+	if err != nil {
+		return err
+	}
+	filter := models.CatFilter{Skill: query.Skill, Status: query.Status, HandlerId: query.HandlerId}
+	if err := d.catRepo.Export(ctx, filter, writer.write); err != nil {
+		return myerrors.NewServerError(err.Error())
+	}
+	if err := writer.close(); err != nil {
+		return myerrors.NewServerError(err.Error())
+	}
+	return nil
+}
+
+func (d *DefaultExportService) ExportMissions(ctx context.Context, query models.MissionExportQuery, w io.Writer) error {
+	writer, err := newExportWriter(query.Format, w, "missions", missionExportColumns)
+	if err != nil {
+		return err
+	}
+	filter := models.MissionFilter{HandlerId: query.HandlerId}
+	if err := d.missionRepo.ExportTargets(ctx, filter, writer.write); err != nil {
+		return myerrors.NewServerError(err.Error())
+	}
+	if err := writer.close(); err != nil {
+		return myerrors.NewServerError(err.Error())
+	}
+	return nil
+}
+
+// exportWriter writes exported values in one of export formats
+type exportWriter[T any] interface {
+	write(value T) error
+	close() error
+}
+
+// newExportWriter creates writer of the format. CSV and XLSX start with a header row of column names,
+// NDJSON has JSON of every value on a separate line
+func newExportWriter[T any](format string, w io.Writer, name string, columns []exportColumn[T]) (exportWriter[T], error) {
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	switch format {
+	case models.ExportFormatCSV, "":
+		writer := &csvExportWriter[T]{csv: csv.NewWriter(w), columns: columns}
+		return writer, writer.writeRecord(header)
+	case models.ExportFormatNDJSON:
+		return &ndjsonExportWriter[T]{encoder: json.NewEncoder(w)}, nil
+	case models.ExportFormatXLSX:
+		sheet, err := xlsx.NewWriter(w, name)
+		if err != nil {
+			return nil, myerrors.NewServerError(err.Error())
+		}
+		writer := &xlsxExportWriter[T]{sheet: sheet, columns: columns}
+		return writer, sheet.Write(header)
+	default:
+		return nil, myerrors.NewBadRequestError(fmt.Sprintf("unknown export format %q", format))
+	}
+}
+
+type csvExportWriter[T any] struct {
+	csv     *csv.Writer
+	columns []exportColumn[T]
+}
+
+func (c *csvExportWriter[T]) write(value T) error {
+	record := make([]any, len(c.columns))
+	for i, column := range c.columns {
+		record[i] = column.value(value)
+	}
+	return c.writeRecord(record)
+}
+
+func (c *csvExportWriter[T]) writeRecord(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = csvValue(value)
+	}
+	return c.csv.Write(record)
+}
+
+func (c *csvExportWriter[T]) close() error {
+	c.csv.Flush()
+	return c.csv.Error()
+}
+
+type ndjsonExportWriter[T any] struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonExportWriter[T]) write(value T) error {
+	return n.encoder.Encode(value)
+}
+
+func (n *ndjsonExportWriter[T]) close() error {
+	return nil
+}
+
+type xlsxExportWriter[T any] struct {
+	sheet   *xlsx.Writer
+	columns []exportColumn[T]
+}
+
+func (x *xlsxExportWriter[T]) write(value T) error {
+	row := make([]any, len(x.columns))
+	for i, column := range x.columns {
+		row[i] = column.value(value)
+	}
+	return x.sheet.Write(row)
+}
+
+func (x *xlsxExportWriter[T]) close() error {
+	return x.sheet.Close()
+}
+
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// optionalId leaves the cell empty when the id is not set
+func optionalId(id int64) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+func optionalTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
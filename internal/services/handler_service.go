@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+)
+
+type HandlerService interface {
+	Add(ctx context.Context, handler models.Handler) (models.Handler, error)
+	GetById(ctx context.Context, id int64) (models.Handler, error)
+	GetAll(ctx context.Context) ([]models.Handler, error)
+	GetCats(ctx context.Context, id int64, query models.CatQuery) (models.PaginatedCats, error)
+	GetMissions(ctx context.Context, id int64, query models.PaginationQuery) (models.PaginatedMissions, error)
+	GetDashboard(ctx context.Context, id int64) (models.HandlerDashboard, error)
+	TransferCat(ctx context.Context, catId, handlerId int64) (models.Cat, error)
+	UpdateBudget(ctx context.Context, id int64, update models.HandlerBudgetUpdate) (models.Handler, error)
+}
+
+type DefaultHandlerService struct {
+	handlerRepo    repositories.HandlerRepository
+	catRepo        repositories.CatRepository
+	transactor     repositories.Transactor
+	catService     CatService
+	missionService MissionService
+	budget         BudgetService
+}
+
+func NewDefaultHandlerService(handlerRepo repositories.HandlerRepository, catRepo repositories.CatRepository,
+	transactor repositories.Transactor, catService CatService, missionService MissionService, budget BudgetService) *DefaultHandlerService {
+	return &DefaultHandlerService{
+		handlerRepo:    handlerRepo,
+		catRepo:        catRepo,
+		transactor:     transactor,
+		catService:     catService,
+		missionService: missionService,
+		budget:         budget,
+	}
+}
+
+func (d *DefaultHandlerService) Add(ctx context.Context, handler models.Handler) (models.Handler, error) {
+	handler.Id = 0
+	newHandler, err := d.handlerRepo.Add(ctx, handler)
+	if err != nil {
+		return models.Handler{}, myerrors.NewServerError(err.Error())
+	}
+	return newHandler, nil
+}
+
+func (d *DefaultHandlerService) GetById(ctx context.Context, id int64) (models.Handler, error) {
+	handler, err := d.handlerRepo.GetById(ctx, id)
+	if err != nil {
+		return models.Handler{}, handlerError(err)
+	}
+	return handler, nil
+}
+
+func (d *DefaultHandlerService) GetAll(ctx context.Context) ([]models.Handler, error) {
+	handlers, err := d.handlerRepo.GetAll(ctx)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	if handlers == nil {
+		handlers = []models.Handler{}
+	}
+	return handlers, nil
+}
+
+func (d *DefaultHandlerService) GetCats(ctx context.Context, id int64, query models.CatQuery) (models.PaginatedCats, error) {
+	if _, err := d.GetById(ctx, id); err != nil {
+		return models.PaginatedCats{}, err
+	}
+	query.HandlerId = id
+	return d.catService.GetAll(ctx, query)
+}
+
+func (d *DefaultHandlerService) GetMissions(ctx context.Context, id int64, query models.PaginationQuery) (models.PaginatedMissions, error) {
+	if _, err := d.GetById(ctx, id); err != nil {
+		return models.PaginatedMissions{}, err
+	}
+	return d.missionService.GetAll(ctx, query, models.MissionFilter{HandlerId: id})
+}
+
+func (d *DefaultHandlerService) GetDashboard(ctx context.Context, id int64) (models.HandlerDashboard, error) {
+	dashboard, err := d.handlerRepo.GetDashboard(ctx, id)
+	if err != nil {
+		return models.HandlerDashboard{}, handlerError(err)
+	}
+	return dashboard, nil
+}
+
+// TransferCat hands the cat over to another handler. Open missions of the cat stay assigned
+func (d *DefaultHandlerService) TransferCat(ctx context.Context, catId, handlerId int64) (models.Cat, error) {
+	err := d.transactor.InTransaction(ctx, func(ctx context.Context) error {
+		cat, err := d.catRepo.GetByIdForUpdate(ctx, catId)
+		if err != nil {
+			if errors.Is(err, repositories.ErrCatNotFound) {
+				return myerrors.NewNotFoundError(err.Error())
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		if err := d.budget.CheckTransfer(ctx, cat, handlerId); err != nil {
+			return err
+		}
+		err = d.catRepo.UpdateHandler(ctx, catId, handlerId)
+		if err != nil {
+			if errors.Is(err, repositories.ErrCatNotFound) {
+				return myerrors.NewNotFoundError(err.Error())
+			}
+			if errors.Is(err, repositories.ErrHandlerNotFound) {
+				return myerrors.NewBadRequestError(err.Error())
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Cat{}, appError(err)
+	}
+	return d.catService.GetById(ctx, catId)
+}
+
+func (d *DefaultHandlerService) UpdateBudget(ctx context.Context, id int64, update models.HandlerBudgetUpdate) (models.Handler, error) {
+	err := d.handlerRepo.UpdateBudget(ctx, id, update.Budget)
+	if err != nil {
+		return models.Handler{}, handlerError(err)
+	}
+	return d.GetById(ctx, id)
+}
+
+func handlerError(err error) error {
+	if errors.Is(err, repositories.ErrHandlerNotFound) {
+		return myerrors.NewNotFoundError(err.Error())
+	}
+	return myerrors.NewServerError(err.Error())
+}
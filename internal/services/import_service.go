@@ -0,0 +1,349 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+	"github.com/4oBuko/spy-cat-agency/pkg/catapi"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	maxImportSize = 1 << 20
+	maxImportRows = 1000
+)
+
+// errRowFailed rolls back the all-or-nothing import after the failure is reported in the row
+var errRowFailed = errors.New("row failed")
+
+// csvColumns sets fields of the cat from CSV columns. Columns are matched by JSON names of the fields
+var csvColumns = map[string]func(cat *models.Cat, value string) error{
+	"name": func(cat *models.Cat, value string) error {
+		cat.Name = value
+		return nil
+	},
+	"yearsofexperience": func(cat *models.Cat, value string) error {
+		return parseInt(value, &cat.YearsOfExperience)
+	},
+	"breed": func(cat *models.Cat, value string) error {
+		cat.Breed = value
+		return nil
+	},
+	"salary": func(cat *models.Cat, value string) error {
+		return parseInt(value, &cat.Salary)
+	},
+	"status": func(cat *models.Cat, value string) error {
+		cat.Status = value
+		return nil
+	},
+	"handlerid": func(cat *models.Cat, value string) error {
+		if value == "" {
+			return nil
+		}
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		cat.HandlerId = id
+		return nil
+	},
+}
+
+type ImportService interface {
+	// ImportCats adds cats from CSV or NDJSON file and reports result of every row
+	ImportCats(ctx context.Context, format string, file io.Reader, mode string) (models.CatImportReport, error)
+}
+
+type DefaultImportService struct {
+	catRepo    repositories.CatRepository
+	transactor repositories.Transactor
+	catAPI     catapi.CatAPI
+	ranks      *Ranks
+	budget     BudgetService
+}
+
+func NewDefaultImportService(catRepo repositories.CatRepository, transactor repositories.Transactor, catAPI catapi.CatAPI,
+	ranks *Ranks, budget BudgetService) *DefaultImportService {
+	return &DefaultImportService{
+		catRepo:    catRepo,
+		transactor: transactor,
+		catAPI:     catAPI,
+		ranks:      ranks,
+		budget:     budget,
+	}
+}
+
+// importedRow is a parsed row. Rows that can't be parsed have an error instead of the cat
+type importedRow struct {
+	line int
+	cat  models.Cat
+	err  error
+}
+
+func (d *DefaultImportService) ImportCats(ctx context.Context, format string, file io.Reader, mode string) (models.CatImportReport, error) {
+	if mode == "" {
+		mode = models.ImportModeAllOrNothing
+	}
+	data, err := io.ReadAll(io.LimitReader(file, maxImportSize+1))
+	if err != nil {
+		return models.CatImportReport{}, myerrors.NewBadRequestError("failed to read imported file")
+	}
+	if len(data) > maxImportSize {
+		return models.CatImportReport{}, myerrors.NewRequestEntityTooLargeError(fmt.Sprintf("imported file must not be larger than %d bytes", maxImportSize))
+	}
+
+	var rows []importedRow
+	switch format {
+	case models.ImportFormatCSV:
+		rows, err = parseCSVCats(data)
+	case models.ImportFormatNDJSON:
+		rows, err = parseNDJSONCats(data)
+	default:
+		err = myerrors.NewUnsupportedMediaTypeError("cats can be imported only from CSV or NDJSON")
+	}
+	if err != nil {
+		return models.CatImportReport{}, err
+	}
+	if len(rows) == 0 {
+		return models.CatImportReport{}, myerrors.NewBadRequestError("imported file has no cats")
+	}
+	if len(rows) > maxImportRows {
+		return models.CatImportReport{}, myerrors.NewRequestEntityTooLargeError(fmt.Sprintf("at most %d cats can be imported at once", maxImportRows))
+	}
+
+	// the whole catalog is fetched once instead of resolving every row separately
+	catalog, err := d.catAPI.GetAllBreeds(ctx)
+	if err != nil {
+		return models.CatImportReport{}, myerrors.NewServerError(err.Error())
+	}
+	breeds := make(map[string]catapi.Breed, len(catalog))
+	for _, breed := range catalog {
+		breeds[breed.Id] = breed
+	}
+
+	report := models.CatImportReport{Mode: mode, Rows: make([]models.CatImportRow, len(rows))}
+	// valid rows and their cats share indexes
+	var valid []int
+	var cats []models.Cat
+	for i, row := range rows {
+		report.Rows[i].Line = row.line
+		if row.err != nil {
+			setRowError(&report.Rows[i], row.err)
+			continue
+		}
+		cat, err := d.prepareImportedCat(row.cat, breeds)
+		if err != nil {
+			setRowError(&report.Rows[i], err)
+			continue
+		}
+		valid = append(valid, i)
+		cats = append(cats, cat)
+	}
+
+	if mode == models.ImportModeAllOrNothing {
+		err = d.addAll(ctx, &report, valid, cats)
+	} else {
+		err = d.addValid(ctx, &report, valid, cats)
+	}
+	if err != nil {
+		return models.CatImportReport{}, err
+	}
+	for _, row := range report.Rows {
+		// valid rows of a rolled back all-or-nothing import are neither created nor failed
+		switch {
+		case row.Id != 0:
+			report.Created++
+		case row.Error != "":
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// addAll adds cats only when all rows are valid and fit into budgets together
+func (d *DefaultImportService) addAll(ctx context.Context, report *models.CatImportReport, valid []int, cats []models.Cat) error {
+	if len(valid) < len(report.Rows) {
+		return nil
+	}
+	var added []models.Cat
+	err := d.transactor.InTransaction(ctx, func(ctx context.Context) error {
+		if err := d.budget.CheckNewCats(ctx, cats); err != nil {
+			return err
+		}
+		var err error
+		added, err = d.catRepo.AddAll(ctx, cats)
+		if err != nil {
+			var batchErr *repositories.BatchError
+			if errors.As(err, &batchErr) && errors.Is(err, repositories.ErrHandlerNotFound) {
+				setRowError(&report.Rows[valid[batchErr.Index]], myerrors.NewBadRequestError(repositories.ErrHandlerNotFound.Error()))
+				// cats of previous rows are rolled back together with the transaction
+				return errRowFailed
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		return nil
+	})
+	if errors.Is(err, errRowFailed) {
+		return nil
+	}
+	if err != nil {
+		return appError(err)
+	}
+	for i, cat := range added {
+		report.Rows[valid[i]].Id = cat.Id
+	}
+	return nil
+}
+
+// addValid adds valid rows one by one. Every cat is checked against budgets that include previously added rows
+func (d *DefaultImportService) addValid(ctx context.Context, report *models.CatImportReport, valid []int, cats []models.Cat) error {
+	for i, cat := range cats {
+		var added models.Cat
+		err := d.transactor.InTransaction(ctx, func(ctx context.Context) error {
+			if err := d.budget.CheckNewCat(ctx, cat); err != nil {
+				return err
+			}
+			var err error
+			added, err = d.catRepo.Add(ctx, cat)
+			if err != nil {
+				if errors.Is(err, repositories.ErrHandlerNotFound) {
+					return myerrors.NewBadRequestError(err.Error())
+				}
+				return myerrors.NewServerError(err.Error())
+			}
+			return nil
+		})
+		row := &report.Rows[valid[i]]
+		if err != nil {
+			var appErr *myerrors.AppError
+			if errors.As(err, &appErr) && appErr.StatusCode < 500 {
+				setRowError(row, err)
+				continue
+			}
+			return appError(err)
+		}
+		row.Id = added.Id
+	}
+	return nil
+}
+
+// prepareImportedCat validates the cat like body of POST /cats
+func (d *DefaultImportService) prepareImportedCat(cat models.Cat, breeds map[string]catapi.Breed) (models.Cat, error) {
+	if err := binding.Validator.ValidateStruct(cat); err != nil {
+		return models.Cat{}, myerrors.NewValidationError(err)
+	}
+	breed, ok := breeds[cat.Breed]
+	if !ok {
+		return models.Cat{}, myerrors.NewBadRequestError(catapi.ErrBreedNotFound.Error())
+	}
+	return prepareNewCat(cat, breed, d.ranks)
+}
+
+func setRowError(row *models.CatImportRow, err error) {
+	var appErr *myerrors.AppError
+	if errors.As(err, &appErr) {
+		row.Error = appErr.Message
+		row.Violations = appErr.Violations
+		return
+	}
+	row.Error = err.Error()
+}
+
+// parseCSVCats reads cats from CSV file with a header row
+func parseCSVCats(data []byte) ([]importedRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, myerrors.NewBadRequestError("failed to read CSV header: " + err.Error())
+	}
+	setters := make([]func(cat *models.Cat, value string) error, len(header))
+	for i, column := range header {
+		setter, ok := csvColumns[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			return nil, myerrors.NewBadRequestError(fmt.Sprintf("unknown CSV column %q", column))
+		}
+		setters[i] = setter
+	}
+
+	var rows []importedRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, importedRow{line: parseErr.StartLine, err: myerrors.NewBadRequestError(parseErr.Err.Error())})
+				continue
+			}
+			return nil, myerrors.NewBadRequestError("failed to read CSV: " + err.Error())
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := importedRow{line: line}
+		var violations []myerrors.Violation
+		for i, value := range record {
+			if err := setters[i](&row.cat, strings.TrimSpace(value)); err != nil {
+				violations = append(violations, myerrors.Violation{Field: header[i], Rule: "type", Message: "must be a number"})
+			}
+		}
+		if len(violations) > 0 {
+			row.err = myerrors.NewViolationsError(violations)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseNDJSONCats reads cats from file with a JSON object on every line. Blank lines are skipped
+func parseNDJSONCats(data []byte) ([]importedRow, error) {
+	var rows []importedRow
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64<<10), maxImportSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		row := importedRow{line: line}
+		if err := json.Unmarshal(text, &row.cat); err != nil {
+			row.err = myerrors.NewValidationError(err)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, myerrors.NewBadRequestError("failed to read NDJSON: " + err.Error())
+	}
+	return rows, nil
+}
+
+func parseInt(value string, target *int) error {
+	if value == "" {
+		*target = 0
+		return nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	*target = n
+	return nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+)
+
+type LedgerService interface {
+	Add(ctx context.Context, catId int64, entry models.LedgerEntry) (models.LedgerEntry, error)
+	GetByCatId(ctx context.Context, catId int64) (models.Ledger, error)
+}
+
+type DefaultLedgerService struct {
+	ledgerRepo repositories.LedgerRepository
+	catRepo    repositories.CatRepository
+}
+
+func NewDefaultLedgerService(ledgerRepo repositories.LedgerRepository, catRepo repositories.CatRepository) *DefaultLedgerService {
+	return &DefaultLedgerService{
+		ledgerRepo: ledgerRepo,
+		catRepo:    catRepo,
+	}
+}
+
+// Add posts salary payment or manual adjustment to the ledger of the cat.
+// Bonuses are posted only by completion of missions
+func (d *DefaultLedgerService) Add(ctx context.Context, catId int64, entry models.LedgerEntry) (models.LedgerEntry, error) {
+	err := d.catExists(ctx, catId)
+	if err != nil {
+		return models.LedgerEntry{}, err
+	}
+	if entry.Kind == models.LedgerSalary && entry.Amount < 0 {
+		return models.LedgerEntry{}, myerrors.NewBadRequestError("salary entry must not be negative. Use adjustment instead")
+	}
+	entry.CatId = catId
+	entry.MissionId = 0
+	entry.PostedAt = time.Now().UTC().Truncate(time.Second) // DATETIME column keeps only seconds
+	newEntry, err := d.ledgerRepo.Add(ctx, entry)
+	if err != nil {
+		return models.LedgerEntry{}, myerrors.NewServerError(err.Error())
+	}
+	return newEntry, nil
+}
+
+func (d *DefaultLedgerService) GetByCatId(ctx context.Context, catId int64) (models.Ledger, error) {
+	err := d.catExists(ctx, catId)
+	if err != nil {
+		return models.Ledger{}, err
+	}
+	entries, err := d.ledgerRepo.GetByCatId(ctx, catId)
+	if err != nil {
+		return models.Ledger{}, myerrors.NewServerError(err.Error())
+	}
+	ledger := models.Ledger{CatId: catId, Entries: entries}
+	if len(entries) > 0 {
+		ledger.Balance = entries[len(entries)-1].Balance
+	} else {
+		ledger.Entries = []models.LedgerEntry{}
+	}
+	return ledger, nil
+}
+
+func (d *DefaultLedgerService) catExists(ctx context.Context, catId int64) error {
+	err := d.catRepo.Exists(ctx, catId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return myerrors.NewNotFoundError(err.Error())
+		}
+		return myerrors.NewServerError(err.Error())
+	}
+	return nil
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/4oBuko/spy-cat-agency/internal/models"
 	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
 	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+	"github.com/4oBuko/spy-cat-agency/pkg/country"
 )
 
 var MaxMissionsPerPage = 50
@@ -16,31 +20,83 @@ var DefaultMissionsPageSize = 10
 type MissionService interface {
 	Add(ctx context.Context, mission models.Mission) (models.Mission, error)
 	GetById(ctx context.Context, id int64) (models.Mission, error)
-	GetAll(ctx context.Context, query models.PaginationQuery) (models.PaginatedMissions, error)
-	Assign(ctx context.Context, missionId, catId int64) error
+	GetAll(ctx context.Context, query models.PaginationQuery, filter models.MissionFilter) (models.PaginatedMissions, error)
+	GetByCatId(ctx context.Context, catId int64, query models.CatMissionQuery) (models.PaginatedMissions, error)
+	// GetByCatIds loads mission history of several cats at once, including missions the cats were released from.
+	// Cats without missions are missing in the result
+	GetByCatIds(ctx context.Context, catIds []int64) (map[int64][]models.Mission, error)
+	Assign(ctx context.Context, missionId, catId int64, override bool) (models.AssignResult, error)
+	UpdateWindow(ctx context.Context, missionId int64, window models.MissionWindow) (models.Mission, error)
 	CompleteTarget(ctx context.Context, missionId, targetId int64) error
 	UpdateTarget(ctx context.Context, missionId, targetId int64, update models.TargetUpdate) (models.Target, error)
 	DeleteTarget(ctx context.Context, missionId, targetId int64) error
 	AddTarget(ctx context.Context, missionId int64, target models.Target) (models.Mission, error)
 	Complete(ctx context.Context, missionId int64) (models.Mission, error)
 	Delete(ctx context.Context, missionId int64) error
+	GetTypes(ctx context.Context) []models.MissionType
+	AddFromTemplate(ctx context.Context, templateId int64) (models.Mission, error)
+	Clone(ctx context.Context, missionId int64) (models.Mission, error)
 }
 
 type DefaultMissionService struct {
-	missionRepository repositories.TxMissionRepository
-	targetRepository  repositories.TxTargetRepository
-	catRepository     repositories.CatRepository
+	missionRepository  repositories.TxMissionRepository
+	targetRepository   repositories.TxTargetRepository
+	catRepository      repositories.CatRepository
+	templateRepository repositories.TemplateRepository
+	skillRepository    repositories.SkillRepository
+	absenceRepository  repositories.AbsenceRepository
+	rankRepository     repositories.RankRepository
+	ledgerRepository   repositories.LedgerRepository
+	transactor         repositories.Transactor
+	missionTypes       *MissionTypes
+	ranks              *Ranks
+	budget             BudgetService
 }
 
-func NewDefaultMissionService(mr repositories.TxMissionRepository, tr repositories.TxTargetRepository, cr repositories.CatRepository) *DefaultMissionService {
+func NewDefaultMissionService(mr repositories.TxMissionRepository, tr repositories.TxTargetRepository, cr repositories.CatRepository,
+	tmr repositories.TemplateRepository, sr repositories.SkillRepository, ar repositories.AbsenceRepository,
+	rr repositories.RankRepository, lr repositories.LedgerRepository, transactor repositories.Transactor, mt *MissionTypes, ranks *Ranks,
+	budget BudgetService) *DefaultMissionService {
 	return &DefaultMissionService{
-		missionRepository: mr,
-		targetRepository:  tr,
-		catRepository:     cr,
+		missionRepository:  mr,
+		targetRepository:   tr,
+		catRepository:      cr,
+		templateRepository: tmr,
+		skillRepository:    sr,
+		absenceRepository:  ar,
+		rankRepository:     rr,
+		ledgerRepository:   lr,
+		transactor:         transactor,
+		missionTypes:       mt,
+		ranks:              ranks,
+		budget:             budget,
 	}
 }
 
 func (d *DefaultMissionService) Add(ctx context.Context, mission models.Mission) (models.Mission, error) {
+	missionType, err := d.missionTypes.Get(mission.Type)
+	if err != nil {
+		return models.Mission{}, err
+	}
+	if err := validateTargetCount(missionType, len(mission.Targets)); err != nil {
+		return models.Mission{}, err
+	}
+	mission.Type = missionType.Name
+	if mission.Bonus == nil {
+		bonus := missionType.SalaryBonus
+		mission.Bonus = &bonus
+	}
+	if err := normalizeWindow(&mission); err != nil {
+		return models.Mission{}, err
+	}
+	for i := range mission.Targets {
+		if err := normalizeCountry(&mission.Targets[i]); err != nil {
+			return models.Mission{}, err
+		}
+		if err := normalizeRequirements(&mission.Targets[i]); err != nil {
+			return models.Mission{}, err
+		}
+	}
 	savedMission, err := d.missionRepository.WithTransaction(ctx,
 		func(tx *sql.Tx) (models.Mission, error) {
 			sm, err := d.missionRepository.AddWithTx(ctx, tx, mission)
@@ -60,6 +116,9 @@ func (d *DefaultMissionService) Add(ctx context.Context, mission models.Mission)
 			return sm, nil
 		})
 	if err != nil {
+		if errors.Is(err, repositories.ErrSkillNotFound) {
+			return models.Mission{}, myerrors.NewBadRequestError(err.Error())
+		}
 		return models.Mission{}, myerrors.NewServerError(err.Error())
 	}
 
@@ -83,8 +142,8 @@ func (d *DefaultMissionService) GetById(ctx context.Context, id int64) (models.M
 	return mission, nil
 }
 
-func (d *DefaultMissionService) GetAll(ctx context.Context, query models.PaginationQuery) (models.PaginatedMissions, error) {
-	count, err := d.missionRepository.GetCount(ctx)
+func (d *DefaultMissionService) GetAll(ctx context.Context, query models.PaginationQuery, filter models.MissionFilter) (models.PaginatedMissions, error) {
+	count, err := d.missionRepository.GetCount(ctx, filter)
 	if err != nil {
 		return models.PaginatedMissions{}, myerrors.NewServerError(err.Error())
 	}
@@ -108,16 +167,20 @@ func (d *DefaultMissionService) GetAll(ctx context.Context, query models.Paginat
 		return models.PaginatedMissions{}, myerrors.NewBadRequestError("request page is greater than total pages")
 	}
 
-	missions, err := d.missionRepository.GetAll(ctx, limit, offset)
+	missions, err := d.missionRepository.GetAll(ctx, filter, limit, offset)
+	if err != nil {
+		return models.PaginatedMissions{}, myerrors.NewServerError(err.Error())
+	}
+	ids := make([]int64, 0, len(missions))
+	for _, ms := range missions {
+		ids = append(ids, ms.Id)
+	}
+	targets, err := d.targetRepository.GetByMissionIds(ctx, ids)
 	if err != nil {
 		return models.PaginatedMissions{}, myerrors.NewServerError(err.Error())
 	}
 	for i := range missions {
-		targets, err := d.targetRepository.GetByMissionId(ctx, missions[i].Id)
-		if err != nil {
-			return models.PaginatedMissions{}, myerrors.NewServerError(err.Error())
-		}
-		missions[i].Targets = targets
+		missions[i].Targets = targets[missions[i].Id]
 	}
 	pMissions := models.PaginatedMissions{
 		Missions: missions,
@@ -132,42 +195,217 @@ func (d *DefaultMissionService) GetAll(ctx context.Context, query models.Paginat
 	return pMissions, nil
 }
 
-func (d *DefaultMissionService) Assign(ctx context.Context, missionId, catId int64) error {
-	mission, err := d.missionRepository.GetById(ctx, missionId)
+// GetByCatId returns current and past missions of the cat
+func (d *DefaultMissionService) GetByCatId(ctx context.Context, catId int64, query models.CatMissionQuery) (models.PaginatedMissions, error) {
+	err := d.catRepository.Exists(ctx, catId)
 	if err != nil {
-		if errors.Is(err, repositories.ErrMissionNotFound) {
-			return myerrors.NewNotFoundError(err.Error())
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return models.PaginatedMissions{}, myerrors.NewNotFoundError(err.Error())
 		}
-		return myerrors.NewServerError(err.Error())
+		return models.PaginatedMissions{}, myerrors.NewServerError(err.Error())
 	}
-	if mission.CatId != 0 {
-		return myerrors.NewBadRequestError("mission is already assigned")
+	if query.From != nil && query.To != nil && !query.To.After(*query.From) {
+		return models.PaginatedMissions{}, myerrors.NewBadRequestError("end of the period must be after its start")
+	}
+	filter := models.MissionFilter{CatId: catId, From: query.From, To: query.To}
+	switch query.Status {
+	case "open":
+		filter.Completed = new(bool)
+	case "completed":
+		completed := true
+		filter.Completed = &completed
+	}
+	return d.GetAll(ctx, query.PaginationQuery, filter)
+}
+
+func (d *DefaultMissionService) GetByCatIds(ctx context.Context, catIds []int64) (map[int64][]models.Mission, error) {
+	byCat, err := d.missionRepository.GetByCatIds(ctx, catIds)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	var ids []int64
+	for _, missions := range byCat {
+		for _, ms := range missions {
+			ids = append(ids, ms.Id)
+		}
+	}
+	targets, err := d.targetRepository.GetByMissionIds(ctx, ids)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	for _, missions := range byCat {
+		for i := range missions {
+			missions[i].Targets = targets[missions[i].Id]
+		}
+	}
+	return byCat, nil
+}
+
+// Assign assigns the cat to the mission. When the cat lacks skills required by open targets
+// assignment is rejected unless override is set. In that case missing skills are returned as warnings
+func (d *DefaultMissionService) Assign(ctx context.Context, missionId, catId int64, override bool) (models.AssignResult, error) {
+	var missing []string
+	// the mission and the cat are locked while they are checked, so concurrent assignments can't both pass the checks
+	err := d.transactor.InTransaction(ctx, func(ctx context.Context) error {
+		mission, err := d.missionRepository.GetByIdForUpdate(ctx, missionId)
+		if err != nil {
+			if errors.Is(err, repositories.ErrMissionNotFound) {
+				return myerrors.NewNotFoundError(err.Error())
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		if mission.CatId != 0 {
+			return myerrors.NewBadRequestError("mission is already assigned")
+		}
+		if mission.Completed {
+			return myerrors.NewBadRequestError("cannot assign cat to a completed mission")
+		}
+		mission.Targets, err = d.targetRepository.GetByMissionId(ctx, mission.Id)
+		if err != nil {
+			return myerrors.NewServerError(err.Error())
+		}
+		cat, err := d.catRepository.GetByIdForUpdate(ctx, catId)
+		if err != nil {
+			if errors.Is(err, repositories.ErrCatNotFound) {
+				return myerrors.NewNotFoundError(err.Error())
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		if cat.Status != models.CatStatusActive {
+			return myerrors.NewBadRequestError(fmt.Sprintf("only active cats can be assigned, the cat is %s", cat.Status))
+		}
+		missionType := d.missionTypes.Of(mission)
+		if cat.YearsOfExperience < missionType.MinExperience {
+			return myerrors.NewBadRequestError(fmt.Sprintf("%s mission requires at least %d years of experience",
+				missionType.Name, missionType.MinExperience))
+		}
+		if err := d.checkAvailability(ctx, catId, mission); err != nil {
+			return err
+		}
+		catSkills, err := d.skillRepository.GetCatSkills(ctx, catId)
+		if err != nil {
+			return myerrors.NewServerError(err.Error())
+		}
+		missing = missingSkills(mission, catSkills)
+		if len(missing) > 0 && !override {
+			return myerrors.NewBadRequestError("cat lacks required skills: " + strings.Join(missing, "; ") +
+				". Use override to assign anyway")
+		}
+		if err := d.missionRepository.Assign(ctx, missionId, catId); err != nil {
+			if errors.Is(err, repositories.ErrMissionNotAssignable) {
+				return myerrors.NewBadRequestError(err.Error())
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return models.AssignResult{}, appError(err)
+	}
+	return models.AssignResult{Warnings: missing}, nil
+}
+
+// UpdateWindow moves the mission to another period. The assigned cat must be available during the new window
+func (d *DefaultMissionService) UpdateWindow(ctx context.Context, missionId int64, window models.MissionWindow) (models.Mission, error) {
+	mission, err := d.GetById(ctx, missionId)
+	if err != nil {
+		return models.Mission{}, err
 	}
 	if mission.Completed {
-		return myerrors.NewBadRequestError("cannot assign cat to a completed mission")
+		return models.Mission{}, myerrors.NewBadRequestError("cannot change window of a completed mission")
 	}
-	_, err = d.catRepository.GetById(ctx, catId)
+	mission.StartsAt, mission.EndsAt = window.StartsAt, window.EndsAt
+	if err := normalizeWindow(&mission); err != nil {
+		return models.Mission{}, err
+	}
+	if mission.CatId != 0 {
+		err = d.checkAvailability(ctx, mission.CatId, mission)
+		if err != nil {
+			return models.Mission{}, err
+		}
+	}
+	err = d.missionRepository.UpdateWindow(ctx, missionId, mission.StartsAt, mission.EndsAt)
 	if err != nil {
-		if errors.Is(err, repositories.ErrCatNotFound) {
-			return myerrors.NewNotFoundError(err.Error())
+		if errors.Is(err, repositories.ErrMissionNotFound) {
+			return models.Mission{}, myerrors.NewNotFoundError(err.Error())
 		}
-		return myerrors.NewServerError(err.Error())
+		return models.Mission{}, myerrors.NewServerError(err.Error())
 	}
-	busy, err := d.catRepository.IsBusy(ctx, catId)
+	return mission, nil
+}
+
+// checkAvailability rejects the cat which other open missions or absences intersect with the mission window
+func (d *DefaultMissionService) checkAvailability(ctx context.Context, catId int64, mission models.Mission) error {
+	from, to := missionWindow(mission, time.Now().UTC().Truncate(time.Second))
+	busy, err := d.catRepository.IsBusyDuring(ctx, catId, mission.Id, from, mission.EndsAt)
 	if err != nil {
 		return myerrors.NewServerError(err.Error())
 	}
 	if busy {
-		return myerrors.NewBadRequestError("cat is busy with another mission")
+		return myerrors.NewBadRequestError("cat is busy with another mission during the mission window")
 	}
-	err = d.missionRepository.Assign(ctx, missionId, catId)
+	absences, err := d.absenceRepository.GetOverlapping(ctx, catId, from, to)
 	if err != nil {
 		return myerrors.NewServerError(err.Error())
 	}
+	if len(absences) > 0 {
+		a := absences[0]
+		return myerrors.NewBadRequestError(fmt.Sprintf("cat is unavailable (%s) from %s to %s",
+			a.Kind, a.From.Format(time.RFC3339), a.To.Format(time.RFC3339)))
+	}
+	return nil
+}
 
+// missionWindow returns the period [from, to) when the assigned cat must be available.
+// Mission without start date starts now and mission without end date requires the cat only at its start
+func missionWindow(mission models.Mission, now time.Time) (time.Time, time.Time) {
+	from := now
+	if mission.StartsAt != nil {
+		from = *mission.StartsAt
+	}
+	to := from.Add(time.Second)
+	if mission.EndsAt != nil && mission.EndsAt.After(from) {
+		to = *mission.EndsAt
+	}
+	return from, to
+}
+
+func normalizeWindow(mission *models.Mission) error {
+	// DATETIME column keeps only seconds
+	if mission.StartsAt != nil {
+		startsAt := mission.StartsAt.UTC().Truncate(time.Second)
+		mission.StartsAt = &startsAt
+	}
+	if mission.EndsAt != nil {
+		endsAt := mission.EndsAt.UTC().Truncate(time.Second)
+		mission.EndsAt = &endsAt
+	}
+	if mission.StartsAt != nil && mission.EndsAt != nil && !mission.StartsAt.Before(*mission.EndsAt) {
+		return myerrors.NewBadRequestError("mission must end after it starts")
+	}
 	return nil
 }
 
+// missingSkills describes skill requirements of open targets that the cat doesn't meet
+func missingSkills(mission models.Mission, catSkills []models.CatSkill) []string {
+	proficiency := make(map[string]int, len(catSkills))
+	for _, s := range catSkills {
+		proficiency[s.Skill] = s.Proficiency
+	}
+	var missing []string
+	for _, t := range mission.Targets {
+		if t.Completed {
+			continue
+		}
+		for _, r := range t.RequiredSkills {
+			if proficiency[r.Skill] < r.MinProficiency {
+				missing = append(missing, fmt.Sprintf("target %s requires %s level %d", t.Name, r.Skill, r.MinProficiency))
+			}
+		}
+	}
+	return missing
+}
+
 func (d *DefaultMissionService) CompleteTarget(ctx context.Context, missionId, targetId int64) error {
 	mission, err := d.missionRepository.GetById(ctx, missionId)
 	if err != nil {
@@ -251,8 +489,10 @@ func (d *DefaultMissionService) DeleteTarget(ctx context.Context, missionId, tar
 	if mission.Completed {
 		return myerrors.NewBadRequestError("Mission is already completed")
 	}
-	if len(mission.Targets) == 1 {
-		return myerrors.NewBadRequestError("Mission must have at least one target")
+	missionType := d.missionTypes.Of(mission)
+	if len(mission.Targets) <= missionType.MinTargets {
+		return myerrors.NewBadRequestError(fmt.Sprintf("%s mission must have at least %d targets",
+			missionType.Name, missionType.MinTargets))
 	}
 
 	err = d.targetRepository.Delete(ctx, targetId)
@@ -273,12 +513,23 @@ func (d *DefaultMissionService) AddTarget(ctx context.Context, missionId int64,
 	if mission.Completed {
 		return models.Mission{}, myerrors.NewBadRequestError("Mission is already completed")
 	}
-	if len(mission.Targets) == 3 {
-		return models.Mission{}, myerrors.NewBadRequestError("Mission cannot have more than 3 targets")
+	missionType := d.missionTypes.Of(mission)
+	if len(mission.Targets) >= missionType.MaxTargets {
+		return models.Mission{}, myerrors.NewBadRequestError(fmt.Sprintf("%s mission cannot have more than %d targets",
+			missionType.Name, missionType.MaxTargets))
+	}
+	if err := normalizeCountry(&target); err != nil {
+		return models.Mission{}, err
+	}
+	if err := normalizeRequirements(&target); err != nil {
+		return models.Mission{}, err
 	}
 	target.MissionId = missionId
 	nTarget, err := d.targetRepository.Add(ctx, target)
 	if err != nil {
+		if errors.Is(err, repositories.ErrSkillNotFound) {
+			return models.Mission{}, myerrors.NewBadRequestError(err.Error())
+		}
 		return models.Mission{}, myerrors.NewServerError(err.Error())
 
 	}
@@ -287,32 +538,88 @@ func (d *DefaultMissionService) AddTarget(ctx context.Context, missionId int64,
 }
 
 func (d *DefaultMissionService) Complete(ctx context.Context, id int64) (models.Mission, error) {
-	mission, err := d.GetById(ctx, id)
-	if err != nil {
-		if errors.Is(err, repositories.ErrMissionNotFound) {
-			return models.Mission{}, myerrors.NewNotFoundError(err.Error())
+	var mission models.Mission
+	err := d.transactor.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		mission, err = d.missionRepository.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, repositories.ErrMissionNotFound) {
+				return myerrors.NewNotFoundError(err.Error())
+			}
+			return myerrors.NewServerError(err.Error())
 		}
-		return models.Mission{}, myerrors.NewServerError(err.Error())
-	}
-	if mission.CatId == 0 {
-		return models.Mission{}, myerrors.NewBadRequestError("mission must be assigned first")
-	}
-	if mission.Completed {
-		return models.Mission{}, myerrors.NewBadRequestError("mission is already completed")
-	}
-	for i := range mission.Targets {
-		if !mission.Targets[i].Completed {
-			return models.Mission{}, myerrors.NewBadRequestError("mission has uncompleted targets")
+		if mission.CatId == 0 {
+			return myerrors.NewBadRequestError("mission must be assigned first")
 		}
-	}
-	err = d.missionRepository.Complete(ctx, id)
+		if mission.Completed {
+			return myerrors.NewBadRequestError("mission is already completed")
+		}
+		mission.Targets, err = d.targetRepository.GetByMissionId(ctx, mission.Id)
+		if err != nil {
+			return myerrors.NewServerError(err.Error())
+		}
+		for i := range mission.Targets {
+			if !mission.Targets[i].Completed {
+				return myerrors.NewBadRequestError("mission has uncompleted targets")
+			}
+		}
+		// the lock makes missions of the cat complete one after another, so every completion counts the previous ones
+		cat, err := d.catRepository.GetByIdForUpdate(ctx, mission.CatId)
+		if err != nil {
+			return myerrors.NewServerError(err.Error())
+		}
+		completedMissions, err := d.rankRepository.CountCompletedMissions(ctx, cat.Id)
+		if err != nil {
+			return myerrors.NewServerError(err.Error())
+		}
+		now := time.Now().UTC().Truncate(time.Second) // DATETIME column keeps only seconds
+
+		if err := d.missionRepository.Complete(ctx, id); err != nil {
+			if errors.Is(err, repositories.ErrMissionCompleted) {
+				return myerrors.NewBadRequestError("mission is already completed")
+			}
+			return myerrors.NewServerError(err.Error())
+		}
+		if mission.Bonus != nil && *mission.Bonus > 0 {
+			_, err := d.ledgerRepository.Add(ctx, models.LedgerEntry{
+				CatId:       cat.Id,
+				Kind:        models.LedgerBonus,
+				Amount:      *mission.Bonus,
+				MissionId:   mission.Id,
+				Description: fmt.Sprintf("%s mission completed", mission.Type),
+				PostedAt:    now,
+			})
+			if err != nil {
+				return myerrors.NewServerError(err.Error())
+			}
+		}
+		if promotion := d.ranks.Promotion(cat, completedMissions+1, now); promotion != nil {
+			// the raise of the promotion is not optional, so a completion that exceeds a budget is rejected
+			if err := d.budget.CheckRaise(ctx, cat, promotion.SalaryRaise); err != nil {
+				return err
+			}
+			if err := d.rankRepository.Promote(ctx, *promotion); err != nil {
+				return myerrors.NewServerError(err.Error())
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return models.Mission{}, myerrors.NewServerError(err.Error())
+		return models.Mission{}, appError(err)
 	}
 	mission.Completed = true
 	return mission, nil
 }
 
+// appError keeps errors of services and reports other errors, like failed commit, as server errors
+func appError(err error) error {
+	var appErr *myerrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return myerrors.NewServerError(err.Error())
+}
+
 func (d *DefaultMissionService) Delete(ctx context.Context, missionId int64) error {
 	mission, err := d.GetById(ctx, missionId)
 	if err != nil {
@@ -329,7 +636,93 @@ func (d *DefaultMissionService) Delete(ctx context.Context, missionId int64) err
 		if errors.Is(err, repositories.ErrMissionNotFound) {
 			return myerrors.NewNotFoundError(err.Error())
 		}
+		if errors.Is(err, repositories.ErrMissionHasHistory) {
+			return myerrors.NewBadRequestError("mission keeps history of cats that worked on it and cannot be deleted")
+		}
 		return myerrors.NewServerError(err.Error())
 	}
 	return nil
 }
+
+// normalizeCountry replaces country of the target with ISO 3166 name and sets country code
+func normalizeCountry(target *models.Target) error {
+	c, err := lookupCountry(target.Country)
+	if err != nil {
+		return err
+	}
+	target.Country = c.Name
+	target.CountryCode = c.Alpha2
+	return nil
+}
+
+// normalizeRequirements validates required skills of the target. Minimum proficiency defaults to 1
+func normalizeRequirements(target *models.Target) error {
+	seen := make(map[string]bool, len(target.RequiredSkills))
+	for i := range target.RequiredSkills {
+		r := &target.RequiredSkills[i]
+		if r.Skill == "" {
+			return myerrors.NewBadRequestError("required skill must have a name")
+		}
+		if seen[r.Skill] {
+			return myerrors.NewBadRequestError(fmt.Sprintf("skill %q is required twice", r.Skill))
+		}
+		seen[r.Skill] = true
+		if r.MinProficiency == 0 {
+			r.MinProficiency = 1
+		}
+		if r.MinProficiency < 1 || r.MinProficiency > 5 {
+			return myerrors.NewBadRequestError("minimum proficiency must be between 1 and 5")
+		}
+	}
+	return nil
+}
+
+func lookupCountry(value string) (country.Country, error) {
+	c, err := country.Lookup(value)
+	if err != nil {
+		return country.Country{}, myerrors.NewBadRequestError(fmt.Sprintf("unknown country %q. Use country name, alpha-2 or alpha-3 code", value))
+	}
+	return c, nil
+}
+
+func (d *DefaultMissionService) GetTypes(ctx context.Context) []models.MissionType {
+	return d.missionTypes.All()
+}
+
+func (d *DefaultMissionService) AddFromTemplate(ctx context.Context, templateId int64) (models.Mission, error) {
+	template, err := d.templateRepository.GetById(ctx, templateId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrTemplateNotFound) {
+			return models.Mission{}, myerrors.NewNotFoundError(err.Error())
+		}
+		return models.Mission{}, myerrors.NewServerError(err.Error())
+	}
+	mission := models.Mission{Type: template.Type}
+	for _, t := range template.Targets {
+		mission.Targets = append(mission.Targets, models.Target{
+			Name:    t.Name,
+			Country: t.Country,
+			Notes:   t.Notes,
+		})
+	}
+	return d.Add(ctx, mission)
+}
+
+// Clone creates a new unassigned mission with the same type and targets as the given mission.
+// Targets of the new mission are not completed regardless of their state in the original mission
+func (d *DefaultMissionService) Clone(ctx context.Context, missionId int64) (models.Mission, error) {
+	original, err := d.GetById(ctx, missionId)
+	if err != nil {
+		return models.Mission{}, err
+	}
+	mission := models.Mission{Type: original.Type, Bonus: original.Bonus}
+	for _, t := range original.Targets {
+		mission.Targets = append(mission.Targets, models.Target{
+			Name:           t.Name,
+			Country:        t.Country,
+			Notes:          t.Notes,
+			RequiredSkills: t.RequiredSkills,
+		})
+	}
+	return d.Add(ctx, mission)
+}
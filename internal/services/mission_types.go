@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+)
+
+// MissionTypes keeps configured mission types and their limits
+type MissionTypes struct {
+	types       map[string]models.MissionType
+	ordered     []models.MissionType
+	defaultType string
+}
+
+func NewMissionTypes(types []models.MissionType, defaultType string) *MissionTypes {
+	mt := &MissionTypes{
+		types:       make(map[string]models.MissionType, len(types)),
+		ordered:     types,
+		defaultType: defaultType,
+	}
+	for _, t := range types {
+		mt.types[t.Name] = t
+	}
+	return mt
+}
+
+// Get returns mission type by name. Empty name resolves to the default mission type
+func (m *MissionTypes) Get(name string) (models.MissionType, error) {
+	if name == "" {
+		name = m.defaultType
+	}
+	t, ok := m.types[name]
+	if !ok {
+		names := make([]string, 0, len(m.ordered))
+		for _, t := range m.ordered {
+			names = append(names, t.Name)
+		}
+		return models.MissionType{}, myerrors.NewBadRequestError(
+			fmt.Sprintf("unknown mission type %q. Available types: %s", name, strings.Join(names, ", ")))
+	}
+	return t, nil
+}
+
+// Of returns type of the existing mission. Missions which type was removed from configuration
+// are treated as missions of the default type
+func (m *MissionTypes) Of(mission models.Mission) models.MissionType {
+	if t, ok := m.types[mission.Type]; ok {
+		return t
+	}
+	return m.types[m.defaultType]
+}
+
+func (m *MissionTypes) All() []models.MissionType {
+	result := make([]models.MissionType, len(m.ordered))
+	copy(result, m.ordered)
+	return result
+}
+
+func validateTargetCount(missionType models.MissionType, count int) error {
+	if count < missionType.MinTargets || count > missionType.MaxTargets {
+		return myerrors.NewBadRequestError(fmt.Sprintf("%s mission must have from %d to %d targets",
+			missionType.Name, missionType.MinTargets, missionType.MaxTargets))
+	}
+	return nil
+}
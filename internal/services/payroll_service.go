@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+)
+
+var MaxPayrollDays = 366 * 5
+
+type PayrollService interface {
+	Calculate(ctx context.Context, query models.PayrollQuery) (models.Payroll, error)
+}
+
+type DefaultPayrollService struct {
+	salaryRepo repositories.SalaryRepository
+}
+
+func NewDefaultPayrollService(salaryRepo repositories.SalaryRepository) *DefaultPayrollService {
+	return &DefaultPayrollService{
+		salaryRepo: salaryRepo,
+	}
+}
+
+// Calculate returns salaries paid to every cat between from and to dates inclusively.
+// Salary is monthly, so every day is paid as a share of the month it belongs to. Salaries are prorated by the database
+func (d *DefaultPayrollService) Calculate(ctx context.Context, query models.PayrollQuery) (models.Payroll, error) {
+	from := query.From.UTC()
+	to := query.To.UTC().AddDate(0, 0, 1)
+	if !from.Before(to) {
+		return models.Payroll{}, myerrors.NewBadRequestError("from date must not be after to date")
+	}
+	if to.Sub(from) > time.Duration(MaxPayrollDays)*24*time.Hour {
+		return models.Payroll{}, myerrors.NewBadRequestError("payroll period is too long")
+	}
+
+	cats, err := d.salaryRepo.GetPayroll(ctx, from, to)
+	if err != nil {
+		return models.Payroll{}, myerrors.NewServerError(err.Error())
+	}
+
+	payroll := models.Payroll{
+		From: query.From.Format(time.DateOnly),
+		To:   query.To.Format(time.DateOnly),
+		Cats: make([]models.CatPayroll, 0, len(cats)),
+	}
+	for _, cat := range cats {
+		cat.Amount = roundMoney(cat.Amount)
+		payroll.Cats = append(payroll.Cats, cat)
+		payroll.Total += cat.Amount
+	}
+	payroll.Total = roundMoney(payroll.Total)
+	return payroll, nil
+}
+
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
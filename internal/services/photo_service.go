@@ -0,0 +1,179 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+	"github.com/4oBuko/spy-cat-agency/pkg/storage"
+	"github.com/4oBuko/spy-cat-agency/pkg/thumbnail"
+)
+
+// maxPhotoPixels limits resolution of the photo. Decoded image of this size takes about 100MB of memory
+const maxPhotoPixels = 25_000_000
+
+// photoExtensions maps formats returned by image.Decode to file extensions
+var photoExtensions = map[string]string{
+	"jpeg": ".jpg",
+	"png":  ".png",
+}
+
+type PhotoService interface {
+	Upload(ctx context.Context, catId int64, photo io.Reader) (models.CatPhoto, error)
+	Get(ctx context.Context, catId int64) (models.CatPhoto, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Remove deletes the photo and thumbnails of the cat from storage
+	Remove(ctx context.Context, cat models.Cat)
+}
+
+type DefaultPhotoService struct {
+	catRepo        repositories.CatRepository
+	storage        storage.Storage
+	maxSize        int64
+	thumbnailSizes []int
+}
+
+func NewDefaultPhotoService(catRepo repositories.CatRepository, storage storage.Storage, maxSize int64, thumbnailSizes []int) *DefaultPhotoService {
+	return &DefaultPhotoService{
+		catRepo:        catRepo,
+		storage:        storage,
+		maxSize:        maxSize,
+		thumbnailSizes: thumbnailSizes,
+	}
+}
+
+// Upload replaces photo of the cat. The photo is decoded and encoded again,
+// so EXIF and other metadata of the uploaded file are not stored
+func (d *DefaultPhotoService) Upload(ctx context.Context, catId int64, photo io.Reader) (models.CatPhoto, error) {
+	cat, err := d.catRepo.GetById(ctx, catId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return models.CatPhoto{}, myerrors.NewNotFoundError(err.Error())
+		}
+		return models.CatPhoto{}, myerrors.NewServerError(err.Error())
+	}
+
+	data, err := io.ReadAll(io.LimitReader(photo, d.maxSize+1))
+	if err != nil {
+		return models.CatPhoto{}, myerrors.NewBadRequestError("failed to read photo")
+	}
+	if int64(len(data)) > d.maxSize {
+		return models.CatPhoto{}, myerrors.NewRequestEntityTooLargeError(fmt.Sprintf("photo must not be larger than %d bytes", d.maxSize))
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	ext, supported := photoExtensions[format]
+	if err != nil || !supported {
+		return models.CatPhoto{}, myerrors.NewUnsupportedMediaTypeError("photo must be JPEG or PNG image")
+	}
+	if config.Width*config.Height > maxPhotoPixels {
+		return models.CatPhoto{}, myerrors.NewBadRequestError("photo resolution is too high")
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return models.CatPhoto{}, myerrors.NewBadRequestError("failed to decode photo: " + err.Error())
+	}
+
+	if err := d.put(ctx, photoKey(catId, ext), img, format); err != nil {
+		return models.CatPhoto{}, myerrors.NewServerError(err.Error())
+	}
+	for _, size := range d.thumbnailSizes {
+		if err := d.put(ctx, thumbnailKey(catId, size, ext), thumbnail.Square(img, size), format); err != nil {
+			return models.CatPhoto{}, myerrors.NewServerError(err.Error())
+		}
+	}
+	err = d.catRepo.UpdatePhoto(ctx, catId, d.storage.URL(photoKey(catId, ext)))
+	if err != nil {
+		return models.CatPhoto{}, myerrors.NewServerError(err.Error())
+	}
+
+	// photo in another format is not overwritten and has to be removed.
+	// Failure leaves only unreachable files, so it does not fail the upload
+	if old := path.Ext(cat.PhotoUrl); cat.PhotoUrl != "" && old != ext {
+		d.remove(ctx, catId, old)
+	}
+	return d.photo(catId, ext), nil
+}
+
+// Remove deletes files of the cat photo. Failure leaves only unreachable files, so it is not reported
+func (d *DefaultPhotoService) Remove(ctx context.Context, cat models.Cat) {
+	if cat.PhotoUrl == "" {
+		return
+	}
+	d.remove(ctx, cat.Id, path.Ext(cat.PhotoUrl))
+}
+
+func (d *DefaultPhotoService) remove(ctx context.Context, catId int64, ext string) {
+	d.storage.Delete(ctx, photoKey(catId, ext))
+	for _, size := range d.thumbnailSizes {
+		d.storage.Delete(ctx, thumbnailKey(catId, size, ext))
+	}
+}
+
+func (d *DefaultPhotoService) Get(ctx context.Context, catId int64) (models.CatPhoto, error) {
+	cat, err := d.catRepo.GetById(ctx, catId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return models.CatPhoto{}, myerrors.NewNotFoundError(err.Error())
+		}
+		return models.CatPhoto{}, myerrors.NewServerError(err.Error())
+	}
+	if cat.PhotoUrl == "" {
+		return models.CatPhoto{}, myerrors.NewNotFoundError("cat has no photo")
+	}
+	return d.photo(catId, path.Ext(cat.PhotoUrl)), nil
+}
+
+// Open returns content of stored photo or thumbnail
+func (d *DefaultPhotoService) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	r, err := d.storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, myerrors.NewNotFoundError("photo not found")
+		}
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	return r, nil
+}
+
+func (d *DefaultPhotoService) put(ctx context.Context, key string, img image.Image, format string) error {
+	var buf bytes.Buffer
+	var err error
+	if format == "png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	return d.storage.Put(ctx, key, &buf)
+}
+
+func (d *DefaultPhotoService) photo(catId int64, ext string) models.CatPhoto {
+	photo := models.CatPhoto{
+		CatId:      catId,
+		PhotoUrl:   d.storage.URL(photoKey(catId, ext)),
+		Thumbnails: make([]models.Thumbnail, 0, len(d.thumbnailSizes)),
+	}
+	for _, size := range d.thumbnailSizes {
+		photo.Thumbnails = append(photo.Thumbnails, models.Thumbnail{Size: size, Url: d.storage.URL(thumbnailKey(catId, size, ext))})
+	}
+	return photo
+}
+
+func photoKey(catId int64, ext string) string {
+	return fmt.Sprintf("cats/%d/photo%s", catId, ext)
+}
+
+func thumbnailKey(catId int64, size int, ext string) string {
+	return fmt.Sprintf("cats/%d/thumbnail-%d%s", catId, size, ext)
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"time"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+)
+
+// Ranks keeps the promotion ladder ordered from the lowest rank to the highest
+type Ranks struct {
+	ordered []models.Rank
+}
+
+func NewRanks(ranks []models.Rank) *Ranks {
+	return &Ranks{ordered: ranks}
+}
+
+func (r *Ranks) All() []models.Rank {
+	result := make([]models.Rank, len(r.ordered))
+	copy(result, r.ordered)
+	return result
+}
+
+// For returns the highest rank which requirements are met
+func (r *Ranks) For(experience, completedMissions int) models.Rank {
+	return r.ordered[r.levelFor(experience, completedMissions)]
+}
+
+// Promotion returns promotion of the cat when it deserves a higher rank than it has. Cats are never demoted
+func (r *Ranks) Promotion(cat models.Cat, completedMissions int, now time.Time) *models.Promotion {
+	current := r.level(cat.Rank)
+	next := r.levelFor(cat.YearsOfExperience, completedMissions)
+	if next <= current {
+		return nil
+	}
+	raise := 0
+	for _, rank := range r.ordered[current+1 : next+1] {
+		raise += rank.SalaryRaise
+	}
+	return &models.Promotion{
+		CatId:       cat.Id,
+		FromRank:    cat.Rank,
+		ToRank:      r.ordered[next].Name,
+		SalaryRaise: raise,
+		PromotedAt:  now,
+	}
+}
+
+func (r *Ranks) levelFor(experience, completedMissions int) int {
+	level := 0
+	for i, rank := range r.ordered {
+		if experience >= rank.MinExperience && completedMissions >= rank.MinCompletedMissions {
+			level = i
+		}
+	}
+	return level
+}
+
+// level returns position of the rank in the ladder. Ranks removed from configuration are considered the lowest
+func (r *Ranks) level(name string) int {
+	for i, rank := range r.ordered {
+		if rank.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+)
+
+type SkillService interface {
+	Add(ctx context.Context, skill models.Skill) (models.Skill, error)
+	GetAll(ctx context.Context) ([]models.Skill, error)
+	GetCatSkills(ctx context.Context, catId int64) ([]models.CatSkill, error)
+	SetCatSkills(ctx context.Context, catId int64, skills []models.CatSkill) ([]models.CatSkill, error)
+}
+
+type DefaultSkillService struct {
+	skillRepo repositories.SkillRepository
+	catRepo   repositories.CatRepository
+}
+
+func NewDefaultSkillService(skillRepo repositories.SkillRepository, catRepo repositories.CatRepository) *DefaultSkillService {
+	return &DefaultSkillService{
+		skillRepo: skillRepo,
+		catRepo:   catRepo,
+	}
+}
+
+func (d *DefaultSkillService) Add(ctx context.Context, skill models.Skill) (models.Skill, error) {
+	newSkill, err := d.skillRepo.Add(ctx, skill)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSkillExists) {
+			return models.Skill{}, myerrors.NewBadRequestError(err.Error())
+		}
+		return models.Skill{}, myerrors.NewServerError(err.Error())
+	}
+	return newSkill, nil
+}
+
+func (d *DefaultSkillService) GetAll(ctx context.Context) ([]models.Skill, error) {
+	skills, err := d.skillRepo.GetAll(ctx)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	return skills, nil
+}
+
+func (d *DefaultSkillService) GetCatSkills(ctx context.Context, catId int64) ([]models.CatSkill, error) {
+	err := d.catRepo.Exists(ctx, catId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return nil, myerrors.NewNotFoundError(err.Error())
+		}
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	skills, err := d.skillRepo.GetCatSkills(ctx, catId)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	return skills, nil
+}
+
+// SetCatSkills replaces all skills of the cat with the given ones
+func (d *DefaultSkillService) SetCatSkills(ctx context.Context, catId int64, skills []models.CatSkill) ([]models.CatSkill, error) {
+	err := d.catRepo.Exists(ctx, catId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return nil, myerrors.NewNotFoundError(err.Error())
+		}
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	seen := make(map[string]bool, len(skills))
+	for _, s := range skills {
+		if seen[s.Skill] {
+			return nil, myerrors.NewBadRequestError(fmt.Sprintf("skill %q is listed twice", s.Skill))
+		}
+		seen[s.Skill] = true
+	}
+
+	err = d.skillRepo.SetCatSkills(ctx, catId, skills)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSkillNotFound) {
+			return nil, myerrors.NewBadRequestError(err.Error())
+		}
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	return d.GetCatSkills(ctx, catId)
+}
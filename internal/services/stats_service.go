@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+)
+
+var DefaultLeaderboardSize = 10
+
+type StatsService interface {
+	GetCatStats(ctx context.Context, catId int64) (models.CatStats, error)
+	GetLeaderboard(ctx context.Context, query models.LeaderboardQuery) ([]models.CatStats, error)
+}
+
+type DefaultStatsService struct {
+	statsRepo repositories.StatsRepository
+}
+
+func NewDefaultStatsService(statsRepo repositories.StatsRepository) *DefaultStatsService {
+	return &DefaultStatsService{
+		statsRepo: statsRepo,
+	}
+}
+
+func (d *DefaultStatsService) GetCatStats(ctx context.Context, catId int64) (models.CatStats, error) {
+	stats, err := d.statsRepo.GetCatStats(ctx, catId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrCatNotFound) {
+			return models.CatStats{}, myerrors.NewNotFoundError(err.Error())
+		}
+		return models.CatStats{}, myerrors.NewServerError(err.Error())
+	}
+	all := []models.CatStats{stats}
+	if err := d.addSalaryCost(ctx, all); err != nil {
+		return models.CatStats{}, err
+	}
+	return all[0], nil
+}
+
+func (d *DefaultStatsService) GetLeaderboard(ctx context.Context, query models.LeaderboardQuery) ([]models.CatStats, error) {
+	if query.Limit == 0 {
+		query.Limit = DefaultLeaderboardSize
+	}
+	stats, err := d.statsRepo.GetLeaderboard(ctx, query.Limit)
+	if err != nil {
+		return nil, myerrors.NewServerError(err.Error())
+	}
+	if err := d.addSalaryCost(ctx, stats); err != nil {
+		return nil, err
+	}
+	for i := range stats {
+		stats[i].Rank = i + 1
+	}
+	if stats == nil {
+		stats = []models.CatStats{}
+	}
+	return stats, nil
+}
+
+// addSalaryCost rounds stats and calculates salary paid to every cat while it was working on its completed missions.
+// Salary depends on the month, so it's prorated the same way as payroll
+func (d *DefaultStatsService) addSalaryCost(ctx context.Context, stats []models.CatStats) error {
+	catIds := make([]int64, 0, len(stats))
+	for i, s := range stats {
+		stats[i].SuccessRate = math.Round(s.SuccessRate*10000) / 10000
+		stats[i].AverageMissionHours = math.Round(s.AverageMissionHours*100) / 100
+		if s.CompletedMissions > 0 {
+			catIds = append(catIds, s.CatId)
+		}
+	}
+	if len(catIds) == 0 {
+		return nil
+	}
+	costs, err := d.statsRepo.GetSalaryCosts(ctx, catIds)
+	if err != nil {
+		return myerrors.NewServerError(err.Error())
+	}
+	for i := range stats {
+		stats[i].CostPerCompletedMission = roundMoney(costs[stats[i].CatId])
+	}
+	return nil
+}
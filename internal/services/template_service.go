@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
+	"github.com/4oBuko/spy-cat-agency/internal/repositories"
+)
+
+var MaxTemplatesPerPage = 50
+var DefaultTemplatesPageSize = 10
+
+type TemplateService interface {
+	Add(ctx context.Context, template models.MissionTemplate) (models.MissionTemplate, error)
+	GetById(ctx context.Context, id int64) (models.MissionTemplate, error)
+	GetAll(ctx context.Context, query models.PaginationQuery) (models.PaginatedTemplates, error)
+	Update(ctx context.Context, id int64, template models.MissionTemplate) (models.MissionTemplate, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type DefaultTemplateService struct {
+	templateRepository repositories.TemplateRepository
+	missionTypes       *MissionTypes
+}
+
+func NewDefaultTemplateService(tr repositories.TemplateRepository, mt *MissionTypes) *DefaultTemplateService {
+	return &DefaultTemplateService{
+		templateRepository: tr,
+		missionTypes:       mt,
+	}
+}
+
+func (d *DefaultTemplateService) Add(ctx context.Context, template models.MissionTemplate) (models.MissionTemplate, error) {
+	template, err := d.validate(template)
+	if err != nil {
+		return models.MissionTemplate{}, err
+	}
+	savedTemplate, err := d.templateRepository.Add(ctx, template)
+	if err != nil {
+		return models.MissionTemplate{}, myerrors.NewServerError(err.Error())
+	}
+	return savedTemplate, nil
+}
+
+func (d *DefaultTemplateService) GetById(ctx context.Context, id int64) (models.MissionTemplate, error) {
+	template, err := d.templateRepository.GetById(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrTemplateNotFound) {
+			return models.MissionTemplate{}, myerrors.NewNotFoundError(err.Error())
+		}
+		return models.MissionTemplate{}, myerrors.NewServerError(err.Error())
+	}
+	return template, nil
+}
+
+func (d *DefaultTemplateService) GetAll(ctx context.Context, query models.PaginationQuery) (models.PaginatedTemplates, error) {
+	count, err := d.templateRepository.GetCount(ctx)
+	if err != nil {
+		return models.PaginatedTemplates{}, myerrors.NewServerError(err.Error())
+	}
+	if query.Size > MaxTemplatesPerPage {
+		return models.PaginatedTemplates{}, myerrors.NewBadRequestError("page size must be between 0 and 50")
+	}
+
+	var offset, limit int
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Size == 0 {
+		query.Size = DefaultTemplatesPageSize
+	}
+
+	offset = (query.Page - 1) * query.Size
+	limit = query.Size
+	totalPages := (count + query.Size - 1) / query.Size
+
+	if query.Page > totalPages {
+		return models.PaginatedTemplates{}, myerrors.NewBadRequestError("request page is greater than total pages")
+	}
+
+	templates, err := d.templateRepository.GetAll(ctx, limit, offset)
+	if err != nil {
+		return models.PaginatedTemplates{}, myerrors.NewServerError(err.Error())
+	}
+	pTemplates := models.PaginatedTemplates{
+		Templates: templates,
+		Meta: models.Pagination{
+			PageSize:   query.Size,
+			Page:       query.Page,
+			TotalPages: totalPages,
+			Total:      count,
+		},
+	}
+	return pTemplates, nil
+}
+
+func (d *DefaultTemplateService) Update(ctx context.Context, id int64, template models.MissionTemplate) (models.MissionTemplate, error) {
+	template, err := d.validate(template)
+	if err != nil {
+		return models.MissionTemplate{}, err
+	}
+	template.Id = id
+	updatedTemplate, err := d.templateRepository.Update(ctx, template)
+	if err != nil {
+		if errors.Is(err, repositories.ErrTemplateNotFound) {
+			return models.MissionTemplate{}, myerrors.NewNotFoundError(err.Error())
+		}
+		return models.MissionTemplate{}, myerrors.NewServerError(err.Error())
+	}
+	return updatedTemplate, nil
+}
+
+func (d *DefaultTemplateService) Delete(ctx context.Context, id int64) error {
+	err := d.templateRepository.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrTemplateNotFound) {
+			return myerrors.NewNotFoundError(err.Error())
+		}
+		return myerrors.NewServerError(err.Error())
+	}
+	return nil
+}
+
+// validate checks that template can be turned into a valid mission and normalizes countries of its targets
+func (d *DefaultTemplateService) validate(template models.MissionTemplate) (models.MissionTemplate, error) {
+	missionType, err := d.missionTypes.Get(template.Type)
+	if err != nil {
+		return models.MissionTemplate{}, err
+	}
+	if err := validateTargetCount(missionType, len(template.Targets)); err != nil {
+		return models.MissionTemplate{}, err
+	}
+	template.Type = missionType.Name
+	for i := range template.Targets {
+		c, err := lookupCountry(template.Targets[i].Country)
+		if err != nil {
+			return models.MissionTemplate{}, err
+		}
+		template.Targets[i].Country = c.Name
+		template.Targets[i].CountryCode = c.Alpha2
+	}
+	return template, nil
+}
@@ -1,6 +1,10 @@
 package catapi
 
 type Breed struct {
-	Id   string `json:"id"`
-	Name string `json:"name"`
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Origin      string `json:"origin"`
+	Temperament string `json:"temperament"`
+	LifeSpan    string `json:"life_span"`
+	Description string `json:"description"`
 }
@@ -11,6 +11,7 @@ import (
 
 type CatAPI interface {
 	GetBreedById(ctx context.Context, id string) (Breed, error)
+	GetAllBreeds(ctx context.Context) ([]Breed, error)
 }
 
 type CatAPIClient struct {
@@ -42,15 +43,19 @@ func NewCatAPIClient(url string, maxRetry int, retryDelay time.Duration) *CatAPI
 		retryDelay: 1 * time.Second,
 	}
 }
-func (c *CatAPIClient) getAllBreeds(ctx context.Context) ([]Breed, error) {
+
+// GetAllBreeds returns the whole catalog. It is fetched once and reused by later calls
+func (c *CatAPIClient) GetAllBreeds(ctx context.Context) ([]Breed, error) {
 	if c.breeds == nil {
-		c.fetchAllBreeds(ctx)
+		if err := c.fetchAllBreeds(ctx); err != nil {
+			return nil, err
+		}
 	}
 	return c.breeds, nil
 }
 
 func (c *CatAPIClient) GetBreedById(ctx context.Context, id string) (Breed, error) {
-	breeds, err := c.getAllBreeds(ctx)
+	breeds, err := c.GetAllBreeds(ctx)
 	if err != nil {
 		return Breed{}, fmt.Errorf("error while fetching breeds: %w", err)
 	}
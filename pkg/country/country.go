@@ -0,0 +1,64 @@
+package country
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// countries.json is generated from the ISO 3166-1 dataset of the iso-codes project
+// and extended with commonly used aliases (UK, USA, Russia, ...)
+//
+//go:embed countries.json
+var countriesJSON []byte
+
+var ErrCountryNotFound = errors.New("country not found")
+
+type Country struct {
+	Alpha2  string   `json:"alpha2"`
+	Alpha3  string   `json:"alpha3"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+var countries []Country
+var index map[string]Country
+
+func init() {
+	if err := json.Unmarshal(countriesJSON, &countries); err != nil {
+		panic(fmt.Sprintf("failed to parse embedded countries: %v", err))
+	}
+	index = make(map[string]Country, len(countries)*4)
+	for _, c := range countries {
+		keys := append([]string{c.Alpha2, c.Alpha3, c.Name}, c.Aliases...)
+		for _, k := range keys {
+			key := normalize(k)
+			if existing, ok := index[key]; ok && existing.Alpha2 != c.Alpha2 {
+				panic(fmt.Sprintf("country key %q is ambiguous: %s and %s", k, existing.Alpha2, c.Alpha2))
+			}
+			index[key] = c
+		}
+	}
+}
+
+// Lookup resolves a country by its name, alias, alpha-2 or alpha-3 code. Case and extra spaces are ignored
+func Lookup(value string) (Country, error) {
+	c, ok := index[normalize(value)]
+	if !ok {
+		return Country{}, fmt.Errorf("%w: %q", ErrCountryNotFound, value)
+	}
+	return c, nil
+}
+
+// All returns every country from the dataset ordered by alpha-2 code
+func All() []Country {
+	result := make([]Country, len(countries))
+	copy(result, countries)
+	return result
+}
+
+func normalize(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
@@ -0,0 +1,38 @@
+package country
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		input string
+		code  string
+		name  string
+	}{
+		{input: "GB", code: "GB", name: "United Kingdom"},
+		{input: "gbr", code: "GB", name: "United Kingdom"},
+		{input: "UK", code: "GB", name: "United Kingdom"},
+		{input: "  united   kingdom ", code: "GB", name: "United Kingdom"},
+		{input: "USA", code: "US", name: "United States"},
+		{input: "Russia", code: "RU", name: "Russian Federation"},
+		{input: "japan", code: "JP", name: "Japan"},
+	}
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			result, err := Lookup(c.input)
+			require.NoError(t, err)
+			assert.Equal(t, c.code, result.Alpha2)
+			assert.Equal(t, c.name, result.Name)
+		})
+	}
+
+	t.Run("unknown country", func(t *testing.T) {
+		_, err := Lookup("Atlantis")
+		assert.True(t, errors.Is(err, ErrCountryNotFound))
+	})
+}
@@ -0,0 +1,47 @@
+// Package mergepatch implements JSON Merge Patch described in RFC 7396
+package mergepatch
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const ContentType = "application/merge-patch+json"
+
+// Apply applies merge patch to the JSON document and returns patched document
+func Apply(document, patch []byte) ([]byte, error) {
+	var target any
+	if len(document) > 0 {
+		if err := json.Unmarshal(document, &target); err != nil {
+			return nil, fmt.Errorf("invalid document: %w", err)
+		}
+	}
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	result, err := json.Marshal(merge(target, p))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patched document: %w", err)
+	}
+	return result, nil
+}
+
+func merge(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = merge(targetObject[key], value)
+	}
+	return targetObject
+}
@@ -0,0 +1,45 @@
+package mergepatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cases are taken from appendix A of RFC 7396
+func TestApply(t *testing.T) {
+	cases := []struct {
+		document string
+		patch    string
+		expected string
+	}{
+		{document: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{document: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{document: `{"a":"b"}`, patch: `{"a":null}`, expected: `{}`},
+		{document: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{document: `{"a":["b"]}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{document: `{"a":"c"}`, patch: `{"a":["b"]}`, expected: `{"a":["b"]}`},
+		{document: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, expected: `{"a":{"b":"d"}}`},
+		{document: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, expected: `{"a":[1]}`},
+		{document: `["a","b"]`, patch: `["c","d"]`, expected: `["c","d"]`},
+		{document: `{"a":"b"}`, patch: `["c"]`, expected: `["c"]`},
+		{document: `{"a":"foo"}`, patch: `null`, expected: `null`},
+		{document: `{"a":"foo"}`, patch: `"bar"`, expected: `"bar"`},
+		{document: `{"e":null}`, patch: `{"a":1}`, expected: `{"a":1,"e":null}`},
+		{document: `[1,2]`, patch: `{"a":"b","c":null}`, expected: `{"a":"b"}`},
+		{document: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, expected: `{"a":{"bb":{}}}`},
+	}
+	for _, c := range cases {
+		t.Run(c.patch, func(t *testing.T) {
+			result, err := Apply([]byte(c.document), []byte(c.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, c.expected, string(result))
+		})
+	}
+
+	t.Run("invalid patch", func(t *testing.T) {
+		_, err := Apply([]byte(`{}`), []byte(`{`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,21 @@
+package openapi
+
+import (
+	_ "embed"
+	"html/template"
+	"strings"
+)
+
+//go:embed explorer.html
+var explorerHTML string
+
+var explorerTemplate = template.Must(template.New("explorer").Parse(explorerHTML))
+
+// Explorer renders self-contained page that loads specification from specURL and lets users send requests to the API
+func Explorer(title, specURL string) ([]byte, error) {
+	var page strings.Builder
+	if err := explorerTemplate.Execute(&page, struct{ Title, SpecURL string }{title, specURL}); err != nil {
+		return nil, err
+	}
+	return []byte(page.String()), nil
+}
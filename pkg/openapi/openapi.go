@@ -0,0 +1,205 @@
+// Package openapi builds OpenAPI 3 documents from Go types. Schemas follow json tags of the types,
+// query parameters follow form tags and validation rules are taken from binding tags used by gin
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const Version = "3.0.3"
+
+// Operation describes a single route. Path uses gin syntax: named parameters start with a colon
+// and are documented as integers, catch-all parameters start with an asterisk and are documented as strings
+type Operation struct {
+	// Name becomes operationId
+	Name    string
+	Method  string
+	Path    string
+	Summary string
+	Tag     string
+	// Query is a struct bound from the query string
+	Query any
+	// Parameters are added after path and query parameters, for example headers
+	Parameters []Parameter
+	// Body is a value bound from the request body. Types are separated with commas, non JSON types
+	// and types of nil Body are documented as binary
+	Body     any
+	BodyType string
+	// Response is a value returned on success. Nil response without ResponseType has no content
+	Response     any
+	ResponseType string
+	// Status of the successful response. 200 by default
+	Status     int
+	Deprecated bool
+}
+
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]*PathItem `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type PathItem struct {
+	OperationId string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// ErrorResponse describes body of error responses that every operation can return
+type ErrorResponse struct {
+	Body        any
+	ContentType string
+}
+
+// Build returns document that describes all operations
+func Build(info Info, errorResponse ErrorResponse, operations []Operation) Document {
+	g := &generator{schemas: make(map[string]*Schema)}
+	errorContent := g.content(errorResponse.Body, errorResponse.ContentType)
+	doc := Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]map[string]*PathItem),
+	}
+	for _, op := range operations {
+		path, params := pathParameters(op.Path)
+		item := &PathItem{
+			OperationId: op.Name,
+			Summary:     op.Summary,
+			Parameters:  params,
+			Deprecated:  op.Deprecated,
+			Responses: map[string]*Response{
+				"default": {Description: "Error", Content: errorContent},
+			},
+		}
+		if op.Tag != "" {
+			item.Tags = []string{op.Tag}
+		}
+		if op.Query != nil {
+			item.Parameters = append(item.Parameters, g.queryParameters(reflect.TypeOf(op.Query))...)
+		}
+		item.Parameters = append(item.Parameters, op.Parameters...)
+		if op.Body != nil || op.BodyType != "" {
+			item.RequestBody = &RequestBody{Required: true, Content: g.content(op.Body, op.BodyType)}
+		}
+		status := op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		response := &Response{Description: http.StatusText(status)}
+		if op.Response != nil || op.ResponseType != "" {
+			response.Content = g.content(op.Response, op.ResponseType)
+		}
+		item.Responses[strconv.Itoa(status)] = response
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*PathItem)
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = item
+	}
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+// Has reports whether the document describes the route registered with gin path
+func (d Document) Has(method, ginPath string) bool {
+	path, _ := pathParameters(ginPath)
+	_, ok := d.Paths[path][strings.ToLower(method)]
+	return ok
+}
+
+// pathParameters converts gin path to OpenAPI template and describes its parameters
+func pathParameters(ginPath string) (string, []Parameter) {
+	var params []Parameter
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if len(segment) < 2 || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		schema := &Schema{Type: "integer", Format: "int64"}
+		if segment[0] == '*' {
+			schema = &Schema{Type: "string"}
+		}
+		params = append(params, Parameter{Name: segment[1:], In: "path", Required: true, Schema: schema})
+		segments[i] = "{" + segment[1:] + "}"
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func (g *generator) content(value any, contentType string) map[string]*MediaType {
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	content := make(map[string]*MediaType)
+	for _, t := range strings.Split(contentType, ",") {
+		t = strings.TrimSpace(t)
+		if isJSON(t) && value != nil {
+			content[t] = &MediaType{Schema: g.schema(reflect.TypeOf(value))}
+		} else {
+			content[t] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+	}
+	return content
+}
+
+func isJSON(contentType string) bool {
+	return contentType == "" || strings.HasSuffix(contentType, "json")
+}
@@ -0,0 +1,127 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type page struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type itemQuery struct {
+	page
+	Status string     `form:"status" binding:"omitempty,oneof=open closed"`
+	From   *time.Time `form:"from" time_format:"2006-01-02"`
+	Hidden int        `form:"-"`
+}
+
+type item struct {
+	Id      int64           `json:"id"`
+	Name    string          `json:"name" binding:"required,min=1,max=20"`
+	Tags    []string        `json:"tags" binding:"required,min=1,dive,max=5"`
+	Parent  *item           `json:"parent,omitempty"`
+	Created time.Time       `json:"created"`
+	Closed  *time.Time      `json:"closed"`
+	Extra   json.RawMessage `json:"extra"`
+	Secret  string          `json:"-"`
+}
+
+type problem struct {
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+}
+
+func buildTestDocument() Document {
+	return Build(Info{Title: "test", Version: "1"}, ErrorResponse{Body: problem{}, ContentType: "application/problem+json"}, []Operation{
+		{Name: "ItemCreate", Method: http.MethodPost, Path: "/items", Body: item{}, Response: item{}, Status: http.StatusCreated,
+			Parameters: []Parameter{{Name: "Idempotency-Key", In: "header", Schema: &Schema{Type: "string"}}}},
+		{Name: "ItemGetAll", Method: http.MethodGet, Path: "/items", Query: itemQuery{}, Response: []item{}},
+		{Name: "ItemDelete", Method: http.MethodDelete, Path: "/items/:id", Status: http.StatusNoContent, Deprecated: true},
+		{Name: "File", Method: http.MethodGet, Path: "/files/*key", ResponseType: "image/png"},
+		{Name: "ItemExport", Method: http.MethodGet, Path: "/items/export", ResponseType: "text/csv, application/x-ndjson"},
+	})
+}
+
+func TestBuildPaths(t *testing.T) {
+	doc := buildTestDocument()
+
+	assert.True(t, doc.Has(http.MethodPost, "/items"))
+	assert.True(t, doc.Has(http.MethodDelete, "/items/:id"))
+	assert.True(t, doc.Has(http.MethodGet, "/files/*key"))
+	assert.False(t, doc.Has(http.MethodPut, "/items/:id"))
+	assert.False(t, doc.Has(http.MethodGet, "/unknown"))
+
+	deleteItem := doc.Paths["/items/{id}"]["delete"]
+	require.NotNil(t, deleteItem)
+	require.Len(t, deleteItem.Parameters, 1)
+	assert.Equal(t, Parameter{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "integer", Format: "int64"}}, deleteItem.Parameters[0])
+	assert.Nil(t, deleteItem.Responses["204"].Content)
+	assert.Equal(t, "#/components/schemas/problem", deleteItem.Responses["default"].Content["application/problem+json"].Schema.Ref)
+	assert.True(t, deleteItem.Deprecated)
+
+	file := doc.Paths["/files/{key}"]["get"]
+	require.NotNil(t, file)
+	assert.Equal(t, "string", file.Parameters[0].Schema.Type)
+	assert.Equal(t, "binary", file.Responses["200"].Content["image/png"].Schema.Format)
+
+	export := doc.Paths["/items/export"]["get"]
+	require.NotNil(t, export)
+	assert.Len(t, export.Responses["200"].Content, 2)
+	assert.Equal(t, "binary", export.Responses["200"].Content["application/x-ndjson"].Schema.Format)
+}
+
+func TestBuildSchemas(t *testing.T) {
+	doc := buildTestDocument()
+
+	create := doc.Paths["/items"]["post"]
+	require.NotNil(t, create.RequestBody)
+	assert.Equal(t, "#/components/schemas/item", create.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Contains(t, create.Responses, "201")
+	assert.Equal(t, []Parameter{{Name: "Idempotency-Key", In: "header", Schema: &Schema{Type: "string"}}}, create.Parameters)
+
+	schema := doc.Components.Schemas["item"]
+	require.NotNil(t, schema)
+	assert.ElementsMatch(t, []string{"name", "tags"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "Secret")
+	assert.Equal(t, 1, *schema.Properties["name"].MinLength)
+	assert.Equal(t, 20, *schema.Properties["name"].MaxLength)
+	assert.Equal(t, 1, *schema.Properties["tags"].MinItems)
+	assert.Nil(t, schema.Properties["tags"].MaxItems, "rules after dive apply to elements")
+	assert.Equal(t, "#/components/schemas/item", schema.Properties["parent"].Ref)
+	assert.Equal(t, "date-time", schema.Properties["created"].Format)
+	assert.True(t, schema.Properties["closed"].Nullable)
+	assert.Equal(t, &Schema{}, schema.Properties["extra"])
+
+	list := doc.Paths["/items"]["get"].Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, "array", list.Type)
+	assert.Equal(t, "#/components/schemas/item", list.Items.Ref)
+}
+
+func TestBuildQueryParameters(t *testing.T) {
+	params := buildTestDocument().Paths["/items"]["get"].Parameters
+	byName := make(map[string]Parameter)
+	for _, p := range params {
+		assert.Equal(t, "query", p.In)
+		byName[p.Name] = p
+	}
+
+	require.Len(t, byName, 4)
+	assert.Equal(t, 1.0, *byName["page"].Schema.Minimum)
+	assert.Equal(t, 100.0, *byName["limit"].Schema.Maximum)
+	assert.Equal(t, []any{"open", "closed"}, byName["status"].Schema.Enum)
+	assert.Equal(t, "date", byName["from"].Schema.Format)
+}
+
+func TestExplorer(t *testing.T) {
+	page, err := Explorer("Test API", "/openapi.json")
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "<title>Test API</title>")
+	assert.Contains(t, string(page), `"/openapi.json"`)
+}
@@ -0,0 +1,234 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+type generator struct {
+	schemas map[string]*Schema
+}
+
+// schema describes type t. Named structs are stored in components and referenced
+func (g *generator) schema(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	if t.Kind() == reflect.Pointer {
+		s := g.schema(t.Elem())
+		if s.Ref != "" {
+			return s
+		}
+		s.Nullable = true
+		return s
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		// raw JSON can be any value
+		return &Schema{}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		name := t.Name()
+		if _, ok := g.schemas[name]; !ok {
+			// placeholder prevents infinite recursion on self referencing types
+			g.schemas[name] = &Schema{}
+			*g.schemas[name] = *g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	case t.Kind() == reflect.Struct:
+		return g.object(t)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	}
+	return &Schema{}
+}
+
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.fields(t, s)
+	return s
+}
+
+// fields adds exported fields of struct t to s. Embedded structs are flattened like encoding/json does
+func (g *generator) fields(t reflect.Type, s *Schema) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.fields(embedded, s)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		property := g.schema(field.Type)
+		rules := parseBinding(field.Tag.Get("binding"))
+		if property.Ref == "" {
+			applyRules(property, rules)
+		}
+		if rules.required {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = property
+	}
+}
+
+// queryParameters describes fields of struct t that have form tags
+func (g *generator) queryParameters(t reflect.Type) []Parameter {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var params []Parameter
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			params = append(params, g.queryParameters(field.Type)...)
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		ft := field.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		schema := g.schema(ft)
+		if ft == timeType && field.Tag.Get("time_format") == time.DateOnly {
+			schema.Format = "date"
+		}
+		rules := parseBinding(field.Tag.Get("binding"))
+		applyRules(schema, rules)
+		params = append(params, Parameter{Name: name, In: "query", Required: rules.required, Schema: schema})
+	}
+	return params
+}
+
+type bindingRules struct {
+	required bool
+	rules    [][2]string
+}
+
+// parseBinding splits validator tag into rules. Rules after dive apply to elements and are ignored
+func parseBinding(tag string) bindingRules {
+	var result bindingRules
+	for _, rule := range strings.Split(tag, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+		switch name {
+		case "":
+			continue
+		case "dive":
+			return result
+		case "required":
+			result.required = true
+		default:
+			result.rules = append(result.rules, [2]string{name, value})
+		}
+	}
+	return result
+}
+
+func applyRules(s *Schema, rules bindingRules) {
+	for _, rule := range rules.rules {
+		name, value := rule[0], rule[1]
+		switch name {
+		case "min", "gte", "gt":
+			limit(s, value, true, name == "gt")
+		case "max", "lte", "lt":
+			limit(s, value, false, name == "lt")
+		case "len":
+			limit(s, value, true, false)
+			limit(s, value, false, false)
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				s.Enum = append(s.Enum, enumValue(s, option))
+			}
+		case "email":
+			s.Format = "email"
+		case "url":
+			s.Format = "uri"
+		}
+	}
+}
+
+// limit applies bound to a number, string length or array size depending on schema type
+func limit(s *Schema, value string, lower, exclusive bool) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+	count := int(number)
+	switch s.Type {
+	case "integer", "number":
+		if lower {
+			s.Minimum, s.ExclusiveMinimum = &number, exclusive
+		} else {
+			s.Maximum, s.ExclusiveMaximum = &number, exclusive
+		}
+	case "string":
+		if lower {
+			s.MinLength = &count
+		} else {
+			s.MaxLength = &count
+		}
+	case "array":
+		if lower {
+			s.MinItems = &count
+		} else {
+			s.MaxItems = &count
+		}
+	}
+}
+
+func enumValue(s *Schema, option string) any {
+	if s.Type == "integer" {
+		if n, err := strconv.Atoi(option); err == nil {
+			return n
+		}
+	}
+	return option
+}
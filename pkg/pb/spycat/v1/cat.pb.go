@@ -0,0 +1,1676 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: spycat/v1/cat.proto
+
+package spycatv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Cat struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	YearsOfExperience int32                  `protobuf:"varint,3,opt,name=years_of_experience,json=yearsOfExperience,proto3" json:"years_of_experience,omitempty"`
+	Breed             string                 `protobuf:"bytes,4,opt,name=breed,proto3" json:"breed,omitempty"`
+	BreedDetails      *BreedDetails          `protobuf:"bytes,5,opt,name=breed_details,json=breedDetails,proto3" json:"breed_details,omitempty"`
+	Salary            int64                  `protobuf:"varint,6,opt,name=salary,proto3" json:"salary,omitempty"`
+	Status            string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Rank              string                 `protobuf:"bytes,8,opt,name=rank,proto3" json:"rank,omitempty"`
+	// handler_id is 0 when the cat has no handler
+	HandlerId     int64  `protobuf:"varint,9,opt,name=handler_id,json=handlerId,proto3" json:"handler_id,omitempty"`
+	PhotoUrl      string `protobuf:"bytes,10,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cat) Reset() {
+	*x = Cat{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cat) ProtoMessage() {}
+
+func (x *Cat) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cat.ProtoReflect.Descriptor instead.
+func (*Cat) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cat) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Cat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cat) GetYearsOfExperience() int32 {
+	if x != nil {
+		return x.YearsOfExperience
+	}
+	return 0
+}
+
+func (x *Cat) GetBreed() string {
+	if x != nil {
+		return x.Breed
+	}
+	return ""
+}
+
+func (x *Cat) GetBreedDetails() *BreedDetails {
+	if x != nil {
+		return x.BreedDetails
+	}
+	return nil
+}
+
+func (x *Cat) GetSalary() int64 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+func (x *Cat) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Cat) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
+func (x *Cat) GetHandlerId() int64 {
+	if x != nil {
+		return x.HandlerId
+	}
+	return 0
+}
+
+func (x *Cat) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+type BreedDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Origin        string                 `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Temperament   string                 `protobuf:"bytes,3,opt,name=temperament,proto3" json:"temperament,omitempty"`
+	LifeSpan      string                 `protobuf:"bytes,4,opt,name=life_span,json=lifeSpan,proto3" json:"life_span,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BreedDetails) Reset() {
+	*x = BreedDetails{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreedDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreedDetails) ProtoMessage() {}
+
+func (x *BreedDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreedDetails.ProtoReflect.Descriptor instead.
+func (*BreedDetails) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BreedDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BreedDetails) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *BreedDetails) GetTemperament() string {
+	if x != nil {
+		return x.Temperament
+	}
+	return ""
+}
+
+func (x *BreedDetails) GetLifeSpan() string {
+	if x != nil {
+		return x.LifeSpan
+	}
+	return ""
+}
+
+type SalaryChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CatId         int64                  `protobuf:"varint,2,opt,name=cat_id,json=catId,proto3" json:"cat_id,omitempty"`
+	Salary        int64                  `protobuf:"varint,3,opt,name=salary,proto3" json:"salary,omitempty"`
+	EffectiveFrom *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SalaryChange) Reset() {
+	*x = SalaryChange{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SalaryChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SalaryChange) ProtoMessage() {}
+
+func (x *SalaryChange) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SalaryChange.ProtoReflect.Descriptor instead.
+func (*SalaryChange) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SalaryChange) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SalaryChange) GetCatId() int64 {
+	if x != nil {
+		return x.CatId
+	}
+	return 0
+}
+
+func (x *SalaryChange) GetSalary() int64 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+func (x *SalaryChange) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *SalaryChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SalaryChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type StatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CatId         int64                  `protobuf:"varint,2,opt,name=cat_id,json=catId,proto3" json:"cat_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	EffectiveFrom *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusChange) Reset() {
+	*x = StatusChange{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusChange) ProtoMessage() {}
+
+func (x *StatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusChange.ProtoReflect.Descriptor instead.
+func (*StatusChange) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StatusChange) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StatusChange) GetCatId() int64 {
+	if x != nil {
+		return x.CatId
+	}
+	return 0
+}
+
+func (x *StatusChange) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatusChange) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *StatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StatusChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Promotion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CatId         int64                  `protobuf:"varint,2,opt,name=cat_id,json=catId,proto3" json:"cat_id,omitempty"`
+	FromRank      string                 `protobuf:"bytes,3,opt,name=from_rank,json=fromRank,proto3" json:"from_rank,omitempty"`
+	ToRank        string                 `protobuf:"bytes,4,opt,name=to_rank,json=toRank,proto3" json:"to_rank,omitempty"`
+	SalaryRaise   int64                  `protobuf:"varint,5,opt,name=salary_raise,json=salaryRaise,proto3" json:"salary_raise,omitempty"`
+	PromotedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=promoted_at,json=promotedAt,proto3" json:"promoted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Promotion) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Promotion) GetCatId() int64 {
+	if x != nil {
+		return x.CatId
+	}
+	return 0
+}
+
+func (x *Promotion) GetFromRank() string {
+	if x != nil {
+		return x.FromRank
+	}
+	return ""
+}
+
+func (x *Promotion) GetToRank() string {
+	if x != nil {
+		return x.ToRank
+	}
+	return ""
+}
+
+func (x *Promotion) GetSalaryRaise() int64 {
+	if x != nil {
+		return x.SalaryRaise
+	}
+	return 0
+}
+
+func (x *Promotion) GetPromotedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PromotedAt
+	}
+	return nil
+}
+
+type Rank struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MinExperience        int32                  `protobuf:"varint,2,opt,name=min_experience,json=minExperience,proto3" json:"min_experience,omitempty"`
+	MinCompletedMissions int32                  `protobuf:"varint,3,opt,name=min_completed_missions,json=minCompletedMissions,proto3" json:"min_completed_missions,omitempty"`
+	SalaryRaise          int64                  `protobuf:"varint,4,opt,name=salary_raise,json=salaryRaise,proto3" json:"salary_raise,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Rank) Reset() {
+	*x = Rank{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rank) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rank) ProtoMessage() {}
+
+func (x *Rank) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rank.ProtoReflect.Descriptor instead.
+func (*Rank) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Rank) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Rank) GetMinExperience() int32 {
+	if x != nil {
+		return x.MinExperience
+	}
+	return 0
+}
+
+func (x *Rank) GetMinCompletedMissions() int32 {
+	if x != nil {
+		return x.MinCompletedMissions
+	}
+	return 0
+}
+
+func (x *Rank) GetSalaryRaise() int64 {
+	if x != nil {
+		return x.SalaryRaise
+	}
+	return 0
+}
+
+// AddCatRequest ignores id, breed details, rank and photo of the cat
+type AddCatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cat           *Cat                   `protobuf:"bytes,1,opt,name=cat,proto3" json:"cat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCatRequest) Reset() {
+	*x = AddCatRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCatRequest) ProtoMessage() {}
+
+func (x *AddCatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCatRequest.ProtoReflect.Descriptor instead.
+func (*AddCatRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddCatRequest) GetCat() *Cat {
+	if x != nil {
+		return x.Cat
+	}
+	return nil
+}
+
+type GetCatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCatRequest) Reset() {
+	*x = GetCatRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCatRequest) ProtoMessage() {}
+
+func (x *GetCatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCatRequest.ProtoReflect.Descriptor instead.
+func (*GetCatRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCatRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type BatchGetCatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCatsRequest) Reset() {
+	*x = BatchGetCatsRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCatsRequest) ProtoMessage() {}
+
+func (x *BatchGetCatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCatsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetCatsRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchGetCatsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetCatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cats          []*Cat                 `protobuf:"bytes,1,rep,name=cats,proto3" json:"cats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCatsResponse) Reset() {
+	*x = BatchGetCatsResponse{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCatsResponse) ProtoMessage() {}
+
+func (x *BatchGetCatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCatsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetCatsResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchGetCatsResponse) GetCats() []*Cat {
+	if x != nil {
+		return x.Cats
+	}
+	return nil
+}
+
+type ListCatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Skill         string                 `protobuf:"bytes,3,opt,name=skill,proto3" json:"skill,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	HandlerId     int64                  `protobuf:"varint,5,opt,name=handler_id,json=handlerId,proto3" json:"handler_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCatsRequest) Reset() {
+	*x = ListCatsRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCatsRequest) ProtoMessage() {}
+
+func (x *ListCatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCatsRequest.ProtoReflect.Descriptor instead.
+func (*ListCatsRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListCatsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCatsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListCatsRequest) GetSkill() string {
+	if x != nil {
+		return x.Skill
+	}
+	return ""
+}
+
+func (x *ListCatsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListCatsRequest) GetHandlerId() int64 {
+	if x != nil {
+		return x.HandlerId
+	}
+	return 0
+}
+
+type ListCatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cats          []*Cat                 `protobuf:"bytes,1,rep,name=cats,proto3" json:"cats,omitempty"`
+	Meta          *Pagination            `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCatsResponse) Reset() {
+	*x = ListCatsResponse{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCatsResponse) ProtoMessage() {}
+
+func (x *ListCatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCatsResponse.ProtoReflect.Descriptor instead.
+func (*ListCatsResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListCatsResponse) GetCats() []*Cat {
+	if x != nil {
+		return x.Cats
+	}
+	return nil
+}
+
+func (x *ListCatsResponse) GetMeta() *Pagination {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type UpdateCatSalaryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Salary int64                  `protobuf:"varint,2,opt,name=salary,proto3" json:"salary,omitempty"`
+	// effective_from is now when not set
+	EffectiveFrom *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCatSalaryRequest) Reset() {
+	*x = UpdateCatSalaryRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCatSalaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCatSalaryRequest) ProtoMessage() {}
+
+func (x *UpdateCatSalaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCatSalaryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCatSalaryRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateCatSalaryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCatSalaryRequest) GetSalary() int64 {
+	if x != nil {
+		return x.Salary
+	}
+	return 0
+}
+
+func (x *UpdateCatSalaryRequest) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *UpdateCatSalaryRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UpdateCatStatusRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// effective_from is now when not set
+	EffectiveFrom *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCatStatusRequest) Reset() {
+	*x = UpdateCatStatusRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCatStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCatStatusRequest) ProtoMessage() {}
+
+func (x *UpdateCatStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCatStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCatStatusRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateCatStatusRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCatStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateCatStatusRequest) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *UpdateCatStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PatchCatRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	YearsOfExperience *int32                 `protobuf:"varint,3,opt,name=years_of_experience,json=yearsOfExperience,proto3,oneof" json:"years_of_experience,omitempty"`
+	Breed             *string                `protobuf:"bytes,4,opt,name=breed,proto3,oneof" json:"breed,omitempty"`
+	Salary            *int64                 `protobuf:"varint,5,opt,name=salary,proto3,oneof" json:"salary,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PatchCatRequest) Reset() {
+	*x = PatchCatRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchCatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchCatRequest) ProtoMessage() {}
+
+func (x *PatchCatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchCatRequest.ProtoReflect.Descriptor instead.
+func (*PatchCatRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PatchCatRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PatchCatRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *PatchCatRequest) GetYearsOfExperience() int32 {
+	if x != nil && x.YearsOfExperience != nil {
+		return *x.YearsOfExperience
+	}
+	return 0
+}
+
+func (x *PatchCatRequest) GetBreed() string {
+	if x != nil && x.Breed != nil {
+		return *x.Breed
+	}
+	return ""
+}
+
+func (x *PatchCatRequest) GetSalary() int64 {
+	if x != nil && x.Salary != nil {
+		return *x.Salary
+	}
+	return 0
+}
+
+type DeleteCatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCatRequest) Reset() {
+	*x = DeleteCatRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCatRequest) ProtoMessage() {}
+
+func (x *DeleteCatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCatRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCatRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteCatRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetSalaryHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSalaryHistoryRequest) Reset() {
+	*x = GetSalaryHistoryRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSalaryHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSalaryHistoryRequest) ProtoMessage() {}
+
+func (x *GetSalaryHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSalaryHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetSalaryHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetSalaryHistoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetSalaryHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*SalaryChange        `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSalaryHistoryResponse) Reset() {
+	*x = GetSalaryHistoryResponse{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSalaryHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSalaryHistoryResponse) ProtoMessage() {}
+
+func (x *GetSalaryHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSalaryHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetSalaryHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetSalaryHistoryResponse) GetChanges() []*SalaryChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type GetStatusHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusHistoryRequest) Reset() {
+	*x = GetStatusHistoryRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusHistoryRequest) ProtoMessage() {}
+
+func (x *GetStatusHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetStatusHistoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetStatusHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*StatusChange        `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusHistoryResponse) Reset() {
+	*x = GetStatusHistoryResponse{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusHistoryResponse) ProtoMessage() {}
+
+func (x *GetStatusHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetStatusHistoryResponse) GetChanges() []*StatusChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type GetPromotionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromotionsRequest) Reset() {
+	*x = GetPromotionsRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromotionsRequest) ProtoMessage() {}
+
+func (x *GetPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*GetPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetPromotionsRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetPromotionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromotionsResponse) Reset() {
+	*x = GetPromotionsResponse{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromotionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromotionsResponse) ProtoMessage() {}
+
+func (x *GetPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*GetPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPromotionsResponse) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+type ListRanksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRanksRequest) Reset() {
+	*x = ListRanksRequest{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRanksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRanksRequest) ProtoMessage() {}
+
+func (x *ListRanksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRanksRequest.ProtoReflect.Descriptor instead.
+func (*ListRanksRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{22}
+}
+
+type ListRanksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ranks         []*Rank                `protobuf:"bytes,1,rep,name=ranks,proto3" json:"ranks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRanksResponse) Reset() {
+	*x = ListRanksResponse{}
+	mi := &file_spycat_v1_cat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRanksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRanksResponse) ProtoMessage() {}
+
+func (x *ListRanksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_cat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRanksResponse.ProtoReflect.Descriptor instead.
+func (*ListRanksResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_cat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListRanksResponse) GetRanks() []*Rank {
+	if x != nil {
+		return x.Ranks
+	}
+	return nil
+}
+
+var File_spycat_v1_cat_proto protoreflect.FileDescriptor
+
+const file_spycat_v1_cat_proto_rawDesc = "" +
+	"\n" +
+	"\x13spycat/v1/cat.proto\x12\tspycat.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16spycat/v1/common.proto\"\xad\x02\n" +
+	"\x03Cat\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12.\n" +
+	"\x13years_of_experience\x18\x03 \x01(\x05R\x11yearsOfExperience\x12\x14\n" +
+	"\x05breed\x18\x04 \x01(\tR\x05breed\x12<\n" +
+	"\rbreed_details\x18\x05 \x01(\v2\x17.spycat.v1.BreedDetailsR\fbreedDetails\x12\x16\n" +
+	"\x06salary\x18\x06 \x01(\x03R\x06salary\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x12\n" +
+	"\x04rank\x18\b \x01(\tR\x04rank\x12\x1d\n" +
+	"\n" +
+	"handler_id\x18\t \x01(\x03R\thandlerId\x12\x1b\n" +
+	"\tphoto_url\x18\n" +
+	" \x01(\tR\bphotoUrl\"y\n" +
+	"\fBreedDetails\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06origin\x18\x02 \x01(\tR\x06origin\x12 \n" +
+	"\vtemperament\x18\x03 \x01(\tR\vtemperament\x12\x1b\n" +
+	"\tlife_span\x18\x04 \x01(\tR\blifeSpan\"\xe3\x01\n" +
+	"\fSalaryChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06cat_id\x18\x02 \x01(\x03R\x05catId\x12\x16\n" +
+	"\x06salary\x18\x03 \x01(\x03R\x06salary\x12A\n" +
+	"\x0eeffective_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveFrom\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xe3\x01\n" +
+	"\fStatusChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06cat_id\x18\x02 \x01(\x03R\x05catId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12A\n" +
+	"\x0eeffective_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveFrom\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc8\x01\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06cat_id\x18\x02 \x01(\x03R\x05catId\x12\x1b\n" +
+	"\tfrom_rank\x18\x03 \x01(\tR\bfromRank\x12\x17\n" +
+	"\ato_rank\x18\x04 \x01(\tR\x06toRank\x12!\n" +
+	"\fsalary_raise\x18\x05 \x01(\x03R\vsalaryRaise\x12;\n" +
+	"\vpromoted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"promotedAt\"\x9a\x01\n" +
+	"\x04Rank\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x0emin_experience\x18\x02 \x01(\x05R\rminExperience\x124\n" +
+	"\x16min_completed_missions\x18\x03 \x01(\x05R\x14minCompletedMissions\x12!\n" +
+	"\fsalary_raise\x18\x04 \x01(\x03R\vsalaryRaise\"1\n" +
+	"\rAddCatRequest\x12 \n" +
+	"\x03cat\x18\x01 \x01(\v2\x0e.spycat.v1.CatR\x03cat\"\x1f\n" +
+	"\rGetCatRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"'\n" +
+	"\x13BatchGetCatsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\":\n" +
+	"\x14BatchGetCatsResponse\x12\"\n" +
+	"\x04cats\x18\x01 \x03(\v2\x0e.spycat.v1.CatR\x04cats\"\x86\x01\n" +
+	"\x0fListCatsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x14\n" +
+	"\x05skill\x18\x03 \x01(\tR\x05skill\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"handler_id\x18\x05 \x01(\x03R\thandlerId\"a\n" +
+	"\x10ListCatsResponse\x12\"\n" +
+	"\x04cats\x18\x01 \x03(\v2\x0e.spycat.v1.CatR\x04cats\x12)\n" +
+	"\x04meta\x18\x02 \x01(\v2\x15.spycat.v1.PaginationR\x04meta\"\x9b\x01\n" +
+	"\x16UpdateCatSalaryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06salary\x18\x02 \x01(\x03R\x06salary\x12A\n" +
+	"\x0eeffective_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveFrom\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x9b\x01\n" +
+	"\x16UpdateCatStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12A\n" +
+	"\x0eeffective_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveFrom\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xdd\x01\n" +
+	"\x0fPatchCatRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x123\n" +
+	"\x13years_of_experience\x18\x03 \x01(\x05H\x01R\x11yearsOfExperience\x88\x01\x01\x12\x19\n" +
+	"\x05breed\x18\x04 \x01(\tH\x02R\x05breed\x88\x01\x01\x12\x1b\n" +
+	"\x06salary\x18\x05 \x01(\x03H\x03R\x06salary\x88\x01\x01B\a\n" +
+	"\x05_nameB\x16\n" +
+	"\x14_years_of_experienceB\b\n" +
+	"\x06_breedB\t\n" +
+	"\a_salary\"\"\n" +
+	"\x10DeleteCatRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\")\n" +
+	"\x17GetSalaryHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"M\n" +
+	"\x18GetSalaryHistoryResponse\x121\n" +
+	"\achanges\x18\x01 \x03(\v2\x17.spycat.v1.SalaryChangeR\achanges\")\n" +
+	"\x17GetStatusHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"M\n" +
+	"\x18GetStatusHistoryResponse\x121\n" +
+	"\achanges\x18\x01 \x03(\v2\x17.spycat.v1.StatusChangeR\achanges\"&\n" +
+	"\x14GetPromotionsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"M\n" +
+	"\x15GetPromotionsResponse\x124\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x14.spycat.v1.PromotionR\n" +
+	"promotions\"\x12\n" +
+	"\x10ListRanksRequest\":\n" +
+	"\x11ListRanksResponse\x12%\n" +
+	"\x05ranks\x18\x01 \x03(\v2\x0f.spycat.v1.RankR\x05ranks2\xe6\x06\n" +
+	"\n" +
+	"CatService\x122\n" +
+	"\x06AddCat\x12\x18.spycat.v1.AddCatRequest\x1a\x0e.spycat.v1.Cat\x122\n" +
+	"\x06GetCat\x12\x18.spycat.v1.GetCatRequest\x1a\x0e.spycat.v1.Cat\x12O\n" +
+	"\fBatchGetCats\x12\x1e.spycat.v1.BatchGetCatsRequest\x1a\x1f.spycat.v1.BatchGetCatsResponse\x12C\n" +
+	"\bListCats\x12\x1a.spycat.v1.ListCatsRequest\x1a\x1b.spycat.v1.ListCatsResponse\x12D\n" +
+	"\x0fUpdateCatSalary\x12!.spycat.v1.UpdateCatSalaryRequest\x1a\x0e.spycat.v1.Cat\x12D\n" +
+	"\x0fUpdateCatStatus\x12!.spycat.v1.UpdateCatStatusRequest\x1a\x0e.spycat.v1.Cat\x126\n" +
+	"\bPatchCat\x12\x1a.spycat.v1.PatchCatRequest\x1a\x0e.spycat.v1.Cat\x12@\n" +
+	"\tDeleteCat\x12\x1b.spycat.v1.DeleteCatRequest\x1a\x16.google.protobuf.Empty\x12[\n" +
+	"\x10GetSalaryHistory\x12\".spycat.v1.GetSalaryHistoryRequest\x1a#.spycat.v1.GetSalaryHistoryResponse\x12[\n" +
+	"\x10GetStatusHistory\x12\".spycat.v1.GetStatusHistoryRequest\x1a#.spycat.v1.GetStatusHistoryResponse\x12R\n" +
+	"\rGetPromotions\x12\x1f.spycat.v1.GetPromotionsRequest\x1a .spycat.v1.GetPromotionsResponse\x12F\n" +
+	"\tListRanks\x12\x1b.spycat.v1.ListRanksRequest\x1a\x1c.spycat.v1.ListRanksResponseB<Z:github.com/4oBuko/spy-cat-agency/pkg/pb/spycat/v1;spycatv1b\x06proto3"
+
+var (
+	file_spycat_v1_cat_proto_rawDescOnce sync.Once
+	file_spycat_v1_cat_proto_rawDescData []byte
+)
+
+func file_spycat_v1_cat_proto_rawDescGZIP() []byte {
+	file_spycat_v1_cat_proto_rawDescOnce.Do(func() {
+		file_spycat_v1_cat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spycat_v1_cat_proto_rawDesc), len(file_spycat_v1_cat_proto_rawDesc)))
+	})
+	return file_spycat_v1_cat_proto_rawDescData
+}
+
+var file_spycat_v1_cat_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_spycat_v1_cat_proto_goTypes = []any{
+	(*Cat)(nil),                      // 0: spycat.v1.Cat
+	(*BreedDetails)(nil),             // 1: spycat.v1.BreedDetails
+	(*SalaryChange)(nil),             // 2: spycat.v1.SalaryChange
+	(*StatusChange)(nil),             // 3: spycat.v1.StatusChange
+	(*Promotion)(nil),                // 4: spycat.v1.Promotion
+	(*Rank)(nil),                     // 5: spycat.v1.Rank
+	(*AddCatRequest)(nil),            // 6: spycat.v1.AddCatRequest
+	(*GetCatRequest)(nil),            // 7: spycat.v1.GetCatRequest
+	(*BatchGetCatsRequest)(nil),      // 8: spycat.v1.BatchGetCatsRequest
+	(*BatchGetCatsResponse)(nil),     // 9: spycat.v1.BatchGetCatsResponse
+	(*ListCatsRequest)(nil),          // 10: spycat.v1.ListCatsRequest
+	(*ListCatsResponse)(nil),         // 11: spycat.v1.ListCatsResponse
+	(*UpdateCatSalaryRequest)(nil),   // 12: spycat.v1.UpdateCatSalaryRequest
+	(*UpdateCatStatusRequest)(nil),   // 13: spycat.v1.UpdateCatStatusRequest
+	(*PatchCatRequest)(nil),          // 14: spycat.v1.PatchCatRequest
+	(*DeleteCatRequest)(nil),         // 15: spycat.v1.DeleteCatRequest
+	(*GetSalaryHistoryRequest)(nil),  // 16: spycat.v1.GetSalaryHistoryRequest
+	(*GetSalaryHistoryResponse)(nil), // 17: spycat.v1.GetSalaryHistoryResponse
+	(*GetStatusHistoryRequest)(nil),  // 18: spycat.v1.GetStatusHistoryRequest
+	(*GetStatusHistoryResponse)(nil), // 19: spycat.v1.GetStatusHistoryResponse
+	(*GetPromotionsRequest)(nil),     // 20: spycat.v1.GetPromotionsRequest
+	(*GetPromotionsResponse)(nil),    // 21: spycat.v1.GetPromotionsResponse
+	(*ListRanksRequest)(nil),         // 22: spycat.v1.ListRanksRequest
+	(*ListRanksResponse)(nil),        // 23: spycat.v1.ListRanksResponse
+	(*timestamppb.Timestamp)(nil),    // 24: google.protobuf.Timestamp
+	(*Pagination)(nil),               // 25: spycat.v1.Pagination
+	(*emptypb.Empty)(nil),            // 26: google.protobuf.Empty
+}
+var file_spycat_v1_cat_proto_depIdxs = []int32{
+	1,  // 0: spycat.v1.Cat.breed_details:type_name -> spycat.v1.BreedDetails
+	24, // 1: spycat.v1.SalaryChange.effective_from:type_name -> google.protobuf.Timestamp
+	24, // 2: spycat.v1.SalaryChange.created_at:type_name -> google.protobuf.Timestamp
+	24, // 3: spycat.v1.StatusChange.effective_from:type_name -> google.protobuf.Timestamp
+	24, // 4: spycat.v1.StatusChange.created_at:type_name -> google.protobuf.Timestamp
+	24, // 5: spycat.v1.Promotion.promoted_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: spycat.v1.AddCatRequest.cat:type_name -> spycat.v1.Cat
+	0,  // 7: spycat.v1.BatchGetCatsResponse.cats:type_name -> spycat.v1.Cat
+	0,  // 8: spycat.v1.ListCatsResponse.cats:type_name -> spycat.v1.Cat
+	25, // 9: spycat.v1.ListCatsResponse.meta:type_name -> spycat.v1.Pagination
+	24, // 10: spycat.v1.UpdateCatSalaryRequest.effective_from:type_name -> google.protobuf.Timestamp
+	24, // 11: spycat.v1.UpdateCatStatusRequest.effective_from:type_name -> google.protobuf.Timestamp
+	2,  // 12: spycat.v1.GetSalaryHistoryResponse.changes:type_name -> spycat.v1.SalaryChange
+	3,  // 13: spycat.v1.GetStatusHistoryResponse.changes:type_name -> spycat.v1.StatusChange
+	4,  // 14: spycat.v1.GetPromotionsResponse.promotions:type_name -> spycat.v1.Promotion
+	5,  // 15: spycat.v1.ListRanksResponse.ranks:type_name -> spycat.v1.Rank
+	6,  // 16: spycat.v1.CatService.AddCat:input_type -> spycat.v1.AddCatRequest
+	7,  // 17: spycat.v1.CatService.GetCat:input_type -> spycat.v1.GetCatRequest
+	8,  // 18: spycat.v1.CatService.BatchGetCats:input_type -> spycat.v1.BatchGetCatsRequest
+	10, // 19: spycat.v1.CatService.ListCats:input_type -> spycat.v1.ListCatsRequest
+	12, // 20: spycat.v1.CatService.UpdateCatSalary:input_type -> spycat.v1.UpdateCatSalaryRequest
+	13, // 21: spycat.v1.CatService.UpdateCatStatus:input_type -> spycat.v1.UpdateCatStatusRequest
+	14, // 22: spycat.v1.CatService.PatchCat:input_type -> spycat.v1.PatchCatRequest
+	15, // 23: spycat.v1.CatService.DeleteCat:input_type -> spycat.v1.DeleteCatRequest
+	16, // 24: spycat.v1.CatService.GetSalaryHistory:input_type -> spycat.v1.GetSalaryHistoryRequest
+	18, // 25: spycat.v1.CatService.GetStatusHistory:input_type -> spycat.v1.GetStatusHistoryRequest
+	20, // 26: spycat.v1.CatService.GetPromotions:input_type -> spycat.v1.GetPromotionsRequest
+	22, // 27: spycat.v1.CatService.ListRanks:input_type -> spycat.v1.ListRanksRequest
+	0,  // 28: spycat.v1.CatService.AddCat:output_type -> spycat.v1.Cat
+	0,  // 29: spycat.v1.CatService.GetCat:output_type -> spycat.v1.Cat
+	9,  // 30: spycat.v1.CatService.BatchGetCats:output_type -> spycat.v1.BatchGetCatsResponse
+	11, // 31: spycat.v1.CatService.ListCats:output_type -> spycat.v1.ListCatsResponse
+	0,  // 32: spycat.v1.CatService.UpdateCatSalary:output_type -> spycat.v1.Cat
+	0,  // 33: spycat.v1.CatService.UpdateCatStatus:output_type -> spycat.v1.Cat
+	0,  // 34: spycat.v1.CatService.PatchCat:output_type -> spycat.v1.Cat
+	26, // 35: spycat.v1.CatService.DeleteCat:output_type -> google.protobuf.Empty
+	17, // 36: spycat.v1.CatService.GetSalaryHistory:output_type -> spycat.v1.GetSalaryHistoryResponse
+	19, // 37: spycat.v1.CatService.GetStatusHistory:output_type -> spycat.v1.GetStatusHistoryResponse
+	21, // 38: spycat.v1.CatService.GetPromotions:output_type -> spycat.v1.GetPromotionsResponse
+	23, // 39: spycat.v1.CatService.ListRanks:output_type -> spycat.v1.ListRanksResponse
+	28, // [28:40] is the sub-list for method output_type
+	16, // [16:28] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_spycat_v1_cat_proto_init() }
+func file_spycat_v1_cat_proto_init() {
+	if File_spycat_v1_cat_proto != nil {
+		return
+	}
+	file_spycat_v1_common_proto_init()
+	file_spycat_v1_cat_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spycat_v1_cat_proto_rawDesc), len(file_spycat_v1_cat_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spycat_v1_cat_proto_goTypes,
+		DependencyIndexes: file_spycat_v1_cat_proto_depIdxs,
+		MessageInfos:      file_spycat_v1_cat_proto_msgTypes,
+	}.Build()
+	File_spycat_v1_cat_proto = out.File
+	file_spycat_v1_cat_proto_goTypes = nil
+	file_spycat_v1_cat_proto_depIdxs = nil
+}
@@ -0,0 +1,550 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v5.29.3
+// source: spycat/v1/cat.proto
+
+package spycatv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CatService_AddCat_FullMethodName           = "/spycat.v1.CatService/AddCat"
+	CatService_GetCat_FullMethodName           = "/spycat.v1.CatService/GetCat"
+	CatService_BatchGetCats_FullMethodName     = "/spycat.v1.CatService/BatchGetCats"
+	CatService_ListCats_FullMethodName         = "/spycat.v1.CatService/ListCats"
+	CatService_UpdateCatSalary_FullMethodName  = "/spycat.v1.CatService/UpdateCatSalary"
+	CatService_UpdateCatStatus_FullMethodName  = "/spycat.v1.CatService/UpdateCatStatus"
+	CatService_PatchCat_FullMethodName         = "/spycat.v1.CatService/PatchCat"
+	CatService_DeleteCat_FullMethodName        = "/spycat.v1.CatService/DeleteCat"
+	CatService_GetSalaryHistory_FullMethodName = "/spycat.v1.CatService/GetSalaryHistory"
+	CatService_GetStatusHistory_FullMethodName = "/spycat.v1.CatService/GetStatusHistory"
+	CatService_GetPromotions_FullMethodName    = "/spycat.v1.CatService/GetPromotions"
+	CatService_ListRanks_FullMethodName        = "/spycat.v1.CatService/ListRanks"
+)
+
+// CatServiceClient is the client API for CatService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CatService mirrors cat routes of the REST API. Errors have the same codes as problems of the REST API,
+// they are reported in google.rpc.ErrorInfo reason and invalid fields in google.rpc.BadRequest details
+type CatServiceClient interface {
+	AddCat(ctx context.Context, in *AddCatRequest, opts ...grpc.CallOption) (*Cat, error)
+	GetCat(ctx context.Context, in *GetCatRequest, opts ...grpc.CallOption) (*Cat, error)
+	// BatchGetCats returns cats in the order of ids, unknown ids are skipped
+	BatchGetCats(ctx context.Context, in *BatchGetCatsRequest, opts ...grpc.CallOption) (*BatchGetCatsResponse, error)
+	ListCats(ctx context.Context, in *ListCatsRequest, opts ...grpc.CallOption) (*ListCatsResponse, error)
+	UpdateCatSalary(ctx context.Context, in *UpdateCatSalaryRequest, opts ...grpc.CallOption) (*Cat, error)
+	UpdateCatStatus(ctx context.Context, in *UpdateCatStatusRequest, opts ...grpc.CallOption) (*Cat, error)
+	// PatchCat changes only the set fields of the profile like PATCH /cats/:id
+	PatchCat(ctx context.Context, in *PatchCatRequest, opts ...grpc.CallOption) (*Cat, error)
+	DeleteCat(ctx context.Context, in *DeleteCatRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetSalaryHistory(ctx context.Context, in *GetSalaryHistoryRequest, opts ...grpc.CallOption) (*GetSalaryHistoryResponse, error)
+	GetStatusHistory(ctx context.Context, in *GetStatusHistoryRequest, opts ...grpc.CallOption) (*GetStatusHistoryResponse, error)
+	GetPromotions(ctx context.Context, in *GetPromotionsRequest, opts ...grpc.CallOption) (*GetPromotionsResponse, error)
+	ListRanks(ctx context.Context, in *ListRanksRequest, opts ...grpc.CallOption) (*ListRanksResponse, error)
+}
+
+type catServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatServiceClient(cc grpc.ClientConnInterface) CatServiceClient {
+	return &catServiceClient{cc}
+}
+
+func (c *catServiceClient) AddCat(ctx context.Context, in *AddCatRequest, opts ...grpc.CallOption) (*Cat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cat)
+	err := c.cc.Invoke(ctx, CatService_AddCat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) GetCat(ctx context.Context, in *GetCatRequest, opts ...grpc.CallOption) (*Cat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cat)
+	err := c.cc.Invoke(ctx, CatService_GetCat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) BatchGetCats(ctx context.Context, in *BatchGetCatsRequest, opts ...grpc.CallOption) (*BatchGetCatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetCatsResponse)
+	err := c.cc.Invoke(ctx, CatService_BatchGetCats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) ListCats(ctx context.Context, in *ListCatsRequest, opts ...grpc.CallOption) (*ListCatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCatsResponse)
+	err := c.cc.Invoke(ctx, CatService_ListCats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) UpdateCatSalary(ctx context.Context, in *UpdateCatSalaryRequest, opts ...grpc.CallOption) (*Cat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cat)
+	err := c.cc.Invoke(ctx, CatService_UpdateCatSalary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) UpdateCatStatus(ctx context.Context, in *UpdateCatStatusRequest, opts ...grpc.CallOption) (*Cat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cat)
+	err := c.cc.Invoke(ctx, CatService_UpdateCatStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) PatchCat(ctx context.Context, in *PatchCatRequest, opts ...grpc.CallOption) (*Cat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cat)
+	err := c.cc.Invoke(ctx, CatService_PatchCat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) DeleteCat(ctx context.Context, in *DeleteCatRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CatService_DeleteCat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) GetSalaryHistory(ctx context.Context, in *GetSalaryHistoryRequest, opts ...grpc.CallOption) (*GetSalaryHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSalaryHistoryResponse)
+	err := c.cc.Invoke(ctx, CatService_GetSalaryHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) GetStatusHistory(ctx context.Context, in *GetStatusHistoryRequest, opts ...grpc.CallOption) (*GetStatusHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatusHistoryResponse)
+	err := c.cc.Invoke(ctx, CatService_GetStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) GetPromotions(ctx context.Context, in *GetPromotionsRequest, opts ...grpc.CallOption) (*GetPromotionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPromotionsResponse)
+	err := c.cc.Invoke(ctx, CatService_GetPromotions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catServiceClient) ListRanks(ctx context.Context, in *ListRanksRequest, opts ...grpc.CallOption) (*ListRanksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRanksResponse)
+	err := c.cc.Invoke(ctx, CatService_ListRanks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CatServiceServer is the server API for CatService service.
+// All implementations must embed UnimplementedCatServiceServer
+// for forward compatibility.
+//
+// CatService mirrors cat routes of the REST API. Errors have the same codes as problems of the REST API,
+// they are reported in google.rpc.ErrorInfo reason and invalid fields in google.rpc.BadRequest details
+type CatServiceServer interface {
+	AddCat(context.Context, *AddCatRequest) (*Cat, error)
+	GetCat(context.Context, *GetCatRequest) (*Cat, error)
+	// BatchGetCats returns cats in the order of ids, unknown ids are skipped
+	BatchGetCats(context.Context, *BatchGetCatsRequest) (*BatchGetCatsResponse, error)
+	ListCats(context.Context, *ListCatsRequest) (*ListCatsResponse, error)
+	UpdateCatSalary(context.Context, *UpdateCatSalaryRequest) (*Cat, error)
+	UpdateCatStatus(context.Context, *UpdateCatStatusRequest) (*Cat, error)
+	// PatchCat changes only the set fields of the profile like PATCH /cats/:id
+	PatchCat(context.Context, *PatchCatRequest) (*Cat, error)
+	DeleteCat(context.Context, *DeleteCatRequest) (*emptypb.Empty, error)
+	GetSalaryHistory(context.Context, *GetSalaryHistoryRequest) (*GetSalaryHistoryResponse, error)
+	GetStatusHistory(context.Context, *GetStatusHistoryRequest) (*GetStatusHistoryResponse, error)
+	GetPromotions(context.Context, *GetPromotionsRequest) (*GetPromotionsResponse, error)
+	ListRanks(context.Context, *ListRanksRequest) (*ListRanksResponse, error)
+	mustEmbedUnimplementedCatServiceServer()
+}
+
+// UnimplementedCatServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCatServiceServer struct{}
+
+func (UnimplementedCatServiceServer) AddCat(context.Context, *AddCatRequest) (*Cat, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddCat not implemented")
+}
+func (UnimplementedCatServiceServer) GetCat(context.Context, *GetCatRequest) (*Cat, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCat not implemented")
+}
+func (UnimplementedCatServiceServer) BatchGetCats(context.Context, *BatchGetCatsRequest) (*BatchGetCatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetCats not implemented")
+}
+func (UnimplementedCatServiceServer) ListCats(context.Context, *ListCatsRequest) (*ListCatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCats not implemented")
+}
+func (UnimplementedCatServiceServer) UpdateCatSalary(context.Context, *UpdateCatSalaryRequest) (*Cat, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCatSalary not implemented")
+}
+func (UnimplementedCatServiceServer) UpdateCatStatus(context.Context, *UpdateCatStatusRequest) (*Cat, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCatStatus not implemented")
+}
+func (UnimplementedCatServiceServer) PatchCat(context.Context, *PatchCatRequest) (*Cat, error) {
+	return nil, status.Error(codes.Unimplemented, "method PatchCat not implemented")
+}
+func (UnimplementedCatServiceServer) DeleteCat(context.Context, *DeleteCatRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCat not implemented")
+}
+func (UnimplementedCatServiceServer) GetSalaryHistory(context.Context, *GetSalaryHistoryRequest) (*GetSalaryHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSalaryHistory not implemented")
+}
+func (UnimplementedCatServiceServer) GetStatusHistory(context.Context, *GetStatusHistoryRequest) (*GetStatusHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStatusHistory not implemented")
+}
+func (UnimplementedCatServiceServer) GetPromotions(context.Context, *GetPromotionsRequest) (*GetPromotionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPromotions not implemented")
+}
+func (UnimplementedCatServiceServer) ListRanks(context.Context, *ListRanksRequest) (*ListRanksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRanks not implemented")
+}
+func (UnimplementedCatServiceServer) mustEmbedUnimplementedCatServiceServer() {}
+func (UnimplementedCatServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeCatServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatServiceServer will
+// result in compilation errors.
+type UnsafeCatServiceServer interface {
+	mustEmbedUnimplementedCatServiceServer()
+}
+
+func RegisterCatServiceServer(s grpc.ServiceRegistrar, srv CatServiceServer) {
+	// If the following call panics, it indicates UnimplementedCatServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CatService_ServiceDesc, srv)
+}
+
+func _CatService_AddCat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).AddCat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_AddCat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).AddCat(ctx, req.(*AddCatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_GetCat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).GetCat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_GetCat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).GetCat(ctx, req.(*GetCatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_BatchGetCats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetCatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).BatchGetCats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_BatchGetCats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).BatchGetCats(ctx, req.(*BatchGetCatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_ListCats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).ListCats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_ListCats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).ListCats(ctx, req.(*ListCatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_UpdateCatSalary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCatSalaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).UpdateCatSalary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_UpdateCatSalary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).UpdateCatSalary(ctx, req.(*UpdateCatSalaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_UpdateCatStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCatStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).UpdateCatStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_UpdateCatStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).UpdateCatStatus(ctx, req.(*UpdateCatStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_PatchCat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchCatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).PatchCat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_PatchCat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).PatchCat(ctx, req.(*PatchCatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_DeleteCat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).DeleteCat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_DeleteCat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).DeleteCat(ctx, req.(*DeleteCatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_GetSalaryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSalaryHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).GetSalaryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_GetSalaryHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).GetSalaryHistory(ctx, req.(*GetSalaryHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_GetStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).GetStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_GetStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).GetStatusHistory(ctx, req.(*GetStatusHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_GetPromotions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPromotionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).GetPromotions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_GetPromotions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).GetPromotions(ctx, req.(*GetPromotionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatService_ListRanks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRanksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatServiceServer).ListRanks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatService_ListRanks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatServiceServer).ListRanks(ctx, req.(*ListRanksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CatService_ServiceDesc is the grpc.ServiceDesc for CatService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spycat.v1.CatService",
+	HandlerType: (*CatServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddCat",
+			Handler:    _CatService_AddCat_Handler,
+		},
+		{
+			MethodName: "GetCat",
+			Handler:    _CatService_GetCat_Handler,
+		},
+		{
+			MethodName: "BatchGetCats",
+			Handler:    _CatService_BatchGetCats_Handler,
+		},
+		{
+			MethodName: "ListCats",
+			Handler:    _CatService_ListCats_Handler,
+		},
+		{
+			MethodName: "UpdateCatSalary",
+			Handler:    _CatService_UpdateCatSalary_Handler,
+		},
+		{
+			MethodName: "UpdateCatStatus",
+			Handler:    _CatService_UpdateCatStatus_Handler,
+		},
+		{
+			MethodName: "PatchCat",
+			Handler:    _CatService_PatchCat_Handler,
+		},
+		{
+			MethodName: "DeleteCat",
+			Handler:    _CatService_DeleteCat_Handler,
+		},
+		{
+			MethodName: "GetSalaryHistory",
+			Handler:    _CatService_GetSalaryHistory_Handler,
+		},
+		{
+			MethodName: "GetStatusHistory",
+			Handler:    _CatService_GetStatusHistory_Handler,
+		},
+		{
+			MethodName: "GetPromotions",
+			Handler:    _CatService_GetPromotions_Handler,
+		},
+		{
+			MethodName: "ListRanks",
+			Handler:    _CatService_ListRanks_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spycat/v1/cat.proto",
+}
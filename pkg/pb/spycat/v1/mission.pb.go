@@ -0,0 +1,1427 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: spycat/v1/mission.proto
+
+package spycatv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Mission struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// cat_id is 0 when no cat is assigned
+	CatId    int64                  `protobuf:"varint,2,opt,name=cat_id,json=catId,proto3" json:"cat_id,omitempty"`
+	Type     string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	StartsAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	// bonus defaults to the salary bonus of the mission type when not set
+	Bonus         *int64    `protobuf:"varint,6,opt,name=bonus,proto3,oneof" json:"bonus,omitempty"`
+	Targets       []*Target `protobuf:"bytes,7,rep,name=targets,proto3" json:"targets,omitempty"`
+	Completed     bool      `protobuf:"varint,8,opt,name=completed,proto3" json:"completed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mission) Reset() {
+	*x = Mission{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mission) ProtoMessage() {}
+
+func (x *Mission) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mission.ProtoReflect.Descriptor instead.
+func (*Mission) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Mission) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Mission) GetCatId() int64 {
+	if x != nil {
+		return x.CatId
+	}
+	return 0
+}
+
+func (x *Mission) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Mission) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Mission) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Mission) GetBonus() int64 {
+	if x != nil && x.Bonus != nil {
+		return *x.Bonus
+	}
+	return 0
+}
+
+func (x *Mission) GetTargets() []*Target {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *Mission) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+type Target struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Country        string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	CountryCode    string                 `protobuf:"bytes,4,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Notes          string                 `protobuf:"bytes,5,opt,name=notes,proto3" json:"notes,omitempty"`
+	Completed      bool                   `protobuf:"varint,6,opt,name=completed,proto3" json:"completed,omitempty"`
+	RequiredSkills []*SkillRequirement    `protobuf:"bytes,7,rep,name=required_skills,json=requiredSkills,proto3" json:"required_skills,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Target) Reset() {
+	*x = Target{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Target) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Target) ProtoMessage() {}
+
+func (x *Target) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Target.ProtoReflect.Descriptor instead.
+func (*Target) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Target) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Target) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Target) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Target) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *Target) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Target) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *Target) GetRequiredSkills() []*SkillRequirement {
+	if x != nil {
+		return x.RequiredSkills
+	}
+	return nil
+}
+
+type SkillRequirement struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Skill          string                 `protobuf:"bytes,1,opt,name=skill,proto3" json:"skill,omitempty"`
+	MinProficiency int32                  `protobuf:"varint,2,opt,name=min_proficiency,json=minProficiency,proto3" json:"min_proficiency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SkillRequirement) Reset() {
+	*x = SkillRequirement{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkillRequirement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkillRequirement) ProtoMessage() {}
+
+func (x *SkillRequirement) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkillRequirement.ProtoReflect.Descriptor instead.
+func (*SkillRequirement) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SkillRequirement) GetSkill() string {
+	if x != nil {
+		return x.Skill
+	}
+	return ""
+}
+
+func (x *SkillRequirement) GetMinProficiency() int32 {
+	if x != nil {
+		return x.MinProficiency
+	}
+	return 0
+}
+
+type MissionType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MinTargets    int32                  `protobuf:"varint,2,opt,name=min_targets,json=minTargets,proto3" json:"min_targets,omitempty"`
+	MaxTargets    int32                  `protobuf:"varint,3,opt,name=max_targets,json=maxTargets,proto3" json:"max_targets,omitempty"`
+	MinExperience int32                  `protobuf:"varint,4,opt,name=min_experience,json=minExperience,proto3" json:"min_experience,omitempty"`
+	SalaryBonus   int64                  `protobuf:"varint,5,opt,name=salary_bonus,json=salaryBonus,proto3" json:"salary_bonus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MissionType) Reset() {
+	*x = MissionType{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MissionType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MissionType) ProtoMessage() {}
+
+func (x *MissionType) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MissionType.ProtoReflect.Descriptor instead.
+func (*MissionType) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MissionType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MissionType) GetMinTargets() int32 {
+	if x != nil {
+		return x.MinTargets
+	}
+	return 0
+}
+
+func (x *MissionType) GetMaxTargets() int32 {
+	if x != nil {
+		return x.MaxTargets
+	}
+	return 0
+}
+
+func (x *MissionType) GetMinExperience() int32 {
+	if x != nil {
+		return x.MinExperience
+	}
+	return 0
+}
+
+func (x *MissionType) GetSalaryBonus() int64 {
+	if x != nil {
+		return x.SalaryBonus
+	}
+	return 0
+}
+
+// AddMissionRequest ignores ids, cat and completion of the mission and its targets
+type AddMissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mission       *Mission               `protobuf:"bytes,1,opt,name=mission,proto3" json:"mission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMissionRequest) Reset() {
+	*x = AddMissionRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMissionRequest) ProtoMessage() {}
+
+func (x *AddMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMissionRequest.ProtoReflect.Descriptor instead.
+func (*AddMissionRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddMissionRequest) GetMission() *Mission {
+	if x != nil {
+		return x.Mission
+	}
+	return nil
+}
+
+type AddMissionFromTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    int64                  `protobuf:"varint,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMissionFromTemplateRequest) Reset() {
+	*x = AddMissionFromTemplateRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMissionFromTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMissionFromTemplateRequest) ProtoMessage() {}
+
+func (x *AddMissionFromTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMissionFromTemplateRequest.ProtoReflect.Descriptor instead.
+func (*AddMissionFromTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddMissionFromTemplateRequest) GetTemplateId() int64 {
+	if x != nil {
+		return x.TemplateId
+	}
+	return 0
+}
+
+type CloneMissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneMissionRequest) Reset() {
+	*x = CloneMissionRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneMissionRequest) ProtoMessage() {}
+
+func (x *CloneMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneMissionRequest.ProtoReflect.Descriptor instead.
+func (*CloneMissionRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CloneMissionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetMissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMissionRequest) Reset() {
+	*x = GetMissionRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMissionRequest) ProtoMessage() {}
+
+func (x *GetMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMissionRequest.ProtoReflect.Descriptor instead.
+func (*GetMissionRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMissionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListMissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	HandlerId     int64                  `protobuf:"varint,3,opt,name=handler_id,json=handlerId,proto3" json:"handler_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMissionsRequest) Reset() {
+	*x = ListMissionsRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMissionsRequest) ProtoMessage() {}
+
+func (x *ListMissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMissionsRequest.ProtoReflect.Descriptor instead.
+func (*ListMissionsRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListMissionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMissionsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListMissionsRequest) GetHandlerId() int64 {
+	if x != nil {
+		return x.HandlerId
+	}
+	return 0
+}
+
+type ListCatMissionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	CatId int64                  `protobuf:"varint,1,opt,name=cat_id,json=catId,proto3" json:"cat_id,omitempty"`
+	Page  int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size  int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// status is "open" or "completed", missions of both statuses are listed when not set
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCatMissionsRequest) Reset() {
+	*x = ListCatMissionsRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCatMissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCatMissionsRequest) ProtoMessage() {}
+
+func (x *ListCatMissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCatMissionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCatMissionsRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListCatMissionsRequest) GetCatId() int64 {
+	if x != nil {
+		return x.CatId
+	}
+	return 0
+}
+
+func (x *ListCatMissionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCatMissionsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListCatMissionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListCatMissionsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListCatMissionsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type ListMissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Missions      []*Mission             `protobuf:"bytes,1,rep,name=missions,proto3" json:"missions,omitempty"`
+	Meta          *Pagination            `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMissionsResponse) Reset() {
+	*x = ListMissionsResponse{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMissionsResponse) ProtoMessage() {}
+
+func (x *ListMissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMissionsResponse.ProtoReflect.Descriptor instead.
+func (*ListMissionsResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListMissionsResponse) GetMissions() []*Mission {
+	if x != nil {
+		return x.Missions
+	}
+	return nil
+}
+
+func (x *ListMissionsResponse) GetMeta() *Pagination {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type AssignMissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MissionId     int64                  `protobuf:"varint,1,opt,name=mission_id,json=missionId,proto3" json:"mission_id,omitempty"`
+	CatId         int64                  `protobuf:"varint,2,opt,name=cat_id,json=catId,proto3" json:"cat_id,omitempty"`
+	Override      bool                   `protobuf:"varint,3,opt,name=override,proto3" json:"override,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignMissionRequest) Reset() {
+	*x = AssignMissionRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignMissionRequest) ProtoMessage() {}
+
+func (x *AssignMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignMissionRequest.ProtoReflect.Descriptor instead.
+func (*AssignMissionRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AssignMissionRequest) GetMissionId() int64 {
+	if x != nil {
+		return x.MissionId
+	}
+	return 0
+}
+
+func (x *AssignMissionRequest) GetCatId() int64 {
+	if x != nil {
+		return x.CatId
+	}
+	return 0
+}
+
+func (x *AssignMissionRequest) GetOverride() bool {
+	if x != nil {
+		return x.Override
+	}
+	return false
+}
+
+type AssignMissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warnings      []string               `protobuf:"bytes,1,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignMissionResponse) Reset() {
+	*x = AssignMissionResponse{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignMissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignMissionResponse) ProtoMessage() {}
+
+func (x *AssignMissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignMissionResponse.ProtoReflect.Descriptor instead.
+func (*AssignMissionResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AssignMissionResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type CompleteMissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteMissionRequest) Reset() {
+	*x = CompleteMissionRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMissionRequest) ProtoMessage() {}
+
+func (x *CompleteMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteMissionRequest.ProtoReflect.Descriptor instead.
+func (*CompleteMissionRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CompleteMissionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteMissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMissionRequest) Reset() {
+	*x = DeleteMissionRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMissionRequest) ProtoMessage() {}
+
+func (x *DeleteMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMissionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMissionRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteMissionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type AddTargetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MissionId     int64                  `protobuf:"varint,1,opt,name=mission_id,json=missionId,proto3" json:"mission_id,omitempty"`
+	Target        *Target                `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTargetRequest) Reset() {
+	*x = AddTargetRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTargetRequest) ProtoMessage() {}
+
+func (x *AddTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTargetRequest.ProtoReflect.Descriptor instead.
+func (*AddTargetRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AddTargetRequest) GetMissionId() int64 {
+	if x != nil {
+		return x.MissionId
+	}
+	return 0
+}
+
+func (x *AddTargetRequest) GetTarget() *Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type UpdateTargetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MissionId     int64                  `protobuf:"varint,1,opt,name=mission_id,json=missionId,proto3" json:"mission_id,omitempty"`
+	TargetId      int64                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Notes         string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTargetRequest) Reset() {
+	*x = UpdateTargetRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTargetRequest) ProtoMessage() {}
+
+func (x *UpdateTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTargetRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTargetRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateTargetRequest) GetMissionId() int64 {
+	if x != nil {
+		return x.MissionId
+	}
+	return 0
+}
+
+func (x *UpdateTargetRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *UpdateTargetRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type CompleteTargetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MissionId     int64                  `protobuf:"varint,1,opt,name=mission_id,json=missionId,proto3" json:"mission_id,omitempty"`
+	TargetId      int64                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteTargetRequest) Reset() {
+	*x = CompleteTargetRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTargetRequest) ProtoMessage() {}
+
+func (x *CompleteTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTargetRequest.ProtoReflect.Descriptor instead.
+func (*CompleteTargetRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CompleteTargetRequest) GetMissionId() int64 {
+	if x != nil {
+		return x.MissionId
+	}
+	return 0
+}
+
+func (x *CompleteTargetRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+type DeleteTargetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MissionId     int64                  `protobuf:"varint,1,opt,name=mission_id,json=missionId,proto3" json:"mission_id,omitempty"`
+	TargetId      int64                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTargetRequest) Reset() {
+	*x = DeleteTargetRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTargetRequest) ProtoMessage() {}
+
+func (x *DeleteTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTargetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTargetRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteTargetRequest) GetMissionId() int64 {
+	if x != nil {
+		return x.MissionId
+	}
+	return 0
+}
+
+func (x *DeleteTargetRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+type ListMissionTypesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMissionTypesRequest) Reset() {
+	*x = ListMissionTypesRequest{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMissionTypesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMissionTypesRequest) ProtoMessage() {}
+
+func (x *ListMissionTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMissionTypesRequest.ProtoReflect.Descriptor instead.
+func (*ListMissionTypesRequest) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{19}
+}
+
+type ListMissionTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []*MissionType         `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMissionTypesResponse) Reset() {
+	*x = ListMissionTypesResponse{}
+	mi := &file_spycat_v1_mission_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMissionTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMissionTypesResponse) ProtoMessage() {}
+
+func (x *ListMissionTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spycat_v1_mission_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMissionTypesResponse.ProtoReflect.Descriptor instead.
+func (*ListMissionTypesResponse) Descriptor() ([]byte, []int) {
+	return file_spycat_v1_mission_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListMissionTypesResponse) GetTypes() []*MissionType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+var File_spycat_v1_mission_proto protoreflect.FileDescriptor
+
+const file_spycat_v1_mission_proto_rawDesc = "" +
+	"\n" +
+	"\x17spycat/v1/mission.proto\x12\tspycat.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16spycat/v1/common.proto\"\xa2\x02\n" +
+	"\aMission\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06cat_id\x18\x02 \x01(\x03R\x05catId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x127\n" +
+	"\tstarts_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12\x19\n" +
+	"\x05bonus\x18\x06 \x01(\x03H\x00R\x05bonus\x88\x01\x01\x12+\n" +
+	"\atargets\x18\a \x03(\v2\x11.spycat.v1.TargetR\atargets\x12\x1c\n" +
+	"\tcompleted\x18\b \x01(\bR\tcompletedB\b\n" +
+	"\x06_bonus\"\xe3\x01\n" +
+	"\x06Target\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12!\n" +
+	"\fcountry_code\x18\x04 \x01(\tR\vcountryCode\x12\x14\n" +
+	"\x05notes\x18\x05 \x01(\tR\x05notes\x12\x1c\n" +
+	"\tcompleted\x18\x06 \x01(\bR\tcompleted\x12D\n" +
+	"\x0frequired_skills\x18\a \x03(\v2\x1b.spycat.v1.SkillRequirementR\x0erequiredSkills\"Q\n" +
+	"\x10SkillRequirement\x12\x14\n" +
+	"\x05skill\x18\x01 \x01(\tR\x05skill\x12'\n" +
+	"\x0fmin_proficiency\x18\x02 \x01(\x05R\x0eminProficiency\"\xad\x01\n" +
+	"\vMissionType\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vmin_targets\x18\x02 \x01(\x05R\n" +
+	"minTargets\x12\x1f\n" +
+	"\vmax_targets\x18\x03 \x01(\x05R\n" +
+	"maxTargets\x12%\n" +
+	"\x0emin_experience\x18\x04 \x01(\x05R\rminExperience\x12!\n" +
+	"\fsalary_bonus\x18\x05 \x01(\x03R\vsalaryBonus\"A\n" +
+	"\x11AddMissionRequest\x12,\n" +
+	"\amission\x18\x01 \x01(\v2\x12.spycat.v1.MissionR\amission\"@\n" +
+	"\x1dAddMissionFromTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\x03R\n" +
+	"templateId\"%\n" +
+	"\x13CloneMissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"#\n" +
+	"\x11GetMissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\\\n" +
+	"\x13ListMissionsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x1d\n" +
+	"\n" +
+	"handler_id\x18\x03 \x01(\x03R\thandlerId\"\xcb\x01\n" +
+	"\x16ListCatMissionsRequest\x12\x15\n" +
+	"\x06cat_id\x18\x01 \x01(\x03R\x05catId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12.\n" +
+	"\x04from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"q\n" +
+	"\x14ListMissionsResponse\x12.\n" +
+	"\bmissions\x18\x01 \x03(\v2\x12.spycat.v1.MissionR\bmissions\x12)\n" +
+	"\x04meta\x18\x02 \x01(\v2\x15.spycat.v1.PaginationR\x04meta\"h\n" +
+	"\x14AssignMissionRequest\x12\x1d\n" +
+	"\n" +
+	"mission_id\x18\x01 \x01(\x03R\tmissionId\x12\x15\n" +
+	"\x06cat_id\x18\x02 \x01(\x03R\x05catId\x12\x1a\n" +
+	"\boverride\x18\x03 \x01(\bR\boverride\"3\n" +
+	"\x15AssignMissionResponse\x12\x1a\n" +
+	"\bwarnings\x18\x01 \x03(\tR\bwarnings\"(\n" +
+	"\x16CompleteMissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"&\n" +
+	"\x14DeleteMissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\\\n" +
+	"\x10AddTargetRequest\x12\x1d\n" +
+	"\n" +
+	"mission_id\x18\x01 \x01(\x03R\tmissionId\x12)\n" +
+	"\x06target\x18\x02 \x01(\v2\x11.spycat.v1.TargetR\x06target\"g\n" +
+	"\x13UpdateTargetRequest\x12\x1d\n" +
+	"\n" +
+	"mission_id\x18\x01 \x01(\x03R\tmissionId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x03R\btargetId\x12\x14\n" +
+	"\x05notes\x18\x03 \x01(\tR\x05notes\"S\n" +
+	"\x15CompleteTargetRequest\x12\x1d\n" +
+	"\n" +
+	"mission_id\x18\x01 \x01(\x03R\tmissionId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x03R\btargetId\"Q\n" +
+	"\x13DeleteTargetRequest\x12\x1d\n" +
+	"\n" +
+	"mission_id\x18\x01 \x01(\x03R\tmissionId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x03R\btargetId\"\x19\n" +
+	"\x17ListMissionTypesRequest\"H\n" +
+	"\x18ListMissionTypesResponse\x12,\n" +
+	"\x05types\x18\x01 \x03(\v2\x16.spycat.v1.MissionTypeR\x05types2\xae\b\n" +
+	"\x0eMissionService\x12>\n" +
+	"\n" +
+	"AddMission\x12\x1c.spycat.v1.AddMissionRequest\x1a\x12.spycat.v1.Mission\x12V\n" +
+	"\x16AddMissionFromTemplate\x12(.spycat.v1.AddMissionFromTemplateRequest\x1a\x12.spycat.v1.Mission\x12B\n" +
+	"\fCloneMission\x12\x1e.spycat.v1.CloneMissionRequest\x1a\x12.spycat.v1.Mission\x12>\n" +
+	"\n" +
+	"GetMission\x12\x1c.spycat.v1.GetMissionRequest\x1a\x12.spycat.v1.Mission\x12O\n" +
+	"\fListMissions\x12\x1e.spycat.v1.ListMissionsRequest\x1a\x1f.spycat.v1.ListMissionsResponse\x12U\n" +
+	"\x0fListCatMissions\x12!.spycat.v1.ListCatMissionsRequest\x1a\x1f.spycat.v1.ListMissionsResponse\x12R\n" +
+	"\rAssignMission\x12\x1f.spycat.v1.AssignMissionRequest\x1a .spycat.v1.AssignMissionResponse\x12H\n" +
+	"\x0fCompleteMission\x12!.spycat.v1.CompleteMissionRequest\x1a\x12.spycat.v1.Mission\x12H\n" +
+	"\rDeleteMission\x12\x1f.spycat.v1.DeleteMissionRequest\x1a\x16.google.protobuf.Empty\x12<\n" +
+	"\tAddTarget\x12\x1b.spycat.v1.AddTargetRequest\x1a\x12.spycat.v1.Mission\x12A\n" +
+	"\fUpdateTarget\x12\x1e.spycat.v1.UpdateTargetRequest\x1a\x11.spycat.v1.Target\x12J\n" +
+	"\x0eCompleteTarget\x12 .spycat.v1.CompleteTargetRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\fDeleteTarget\x12\x1e.spycat.v1.DeleteTargetRequest\x1a\x16.google.protobuf.Empty\x12[\n" +
+	"\x10ListMissionTypes\x12\".spycat.v1.ListMissionTypesRequest\x1a#.spycat.v1.ListMissionTypesResponseB<Z:github.com/4oBuko/spy-cat-agency/pkg/pb/spycat/v1;spycatv1b\x06proto3"
+
+var (
+	file_spycat_v1_mission_proto_rawDescOnce sync.Once
+	file_spycat_v1_mission_proto_rawDescData []byte
+)
+
+func file_spycat_v1_mission_proto_rawDescGZIP() []byte {
+	file_spycat_v1_mission_proto_rawDescOnce.Do(func() {
+		file_spycat_v1_mission_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spycat_v1_mission_proto_rawDesc), len(file_spycat_v1_mission_proto_rawDesc)))
+	})
+	return file_spycat_v1_mission_proto_rawDescData
+}
+
+var file_spycat_v1_mission_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_spycat_v1_mission_proto_goTypes = []any{
+	(*Mission)(nil),                       // 0: spycat.v1.Mission
+	(*Target)(nil),                        // 1: spycat.v1.Target
+	(*SkillRequirement)(nil),              // 2: spycat.v1.SkillRequirement
+	(*MissionType)(nil),                   // 3: spycat.v1.MissionType
+	(*AddMissionRequest)(nil),             // 4: spycat.v1.AddMissionRequest
+	(*AddMissionFromTemplateRequest)(nil), // 5: spycat.v1.AddMissionFromTemplateRequest
+	(*CloneMissionRequest)(nil),           // 6: spycat.v1.CloneMissionRequest
+	(*GetMissionRequest)(nil),             // 7: spycat.v1.GetMissionRequest
+	(*ListMissionsRequest)(nil),           // 8: spycat.v1.ListMissionsRequest
+	(*ListCatMissionsRequest)(nil),        // 9: spycat.v1.ListCatMissionsRequest
+	(*ListMissionsResponse)(nil),          // 10: spycat.v1.ListMissionsResponse
+	(*AssignMissionRequest)(nil),          // 11: spycat.v1.AssignMissionRequest
+	(*AssignMissionResponse)(nil),         // 12: spycat.v1.AssignMissionResponse
+	(*CompleteMissionRequest)(nil),        // 13: spycat.v1.CompleteMissionRequest
+	(*DeleteMissionRequest)(nil),          // 14: spycat.v1.DeleteMissionRequest
+	(*AddTargetRequest)(nil),              // 15: spycat.v1.AddTargetRequest
+	(*UpdateTargetRequest)(nil),           // 16: spycat.v1.UpdateTargetRequest
+	(*CompleteTargetRequest)(nil),         // 17: spycat.v1.CompleteTargetRequest
+	(*DeleteTargetRequest)(nil),           // 18: spycat.v1.DeleteTargetRequest
+	(*ListMissionTypesRequest)(nil),       // 19: spycat.v1.ListMissionTypesRequest
+	(*ListMissionTypesResponse)(nil),      // 20: spycat.v1.ListMissionTypesResponse
+	(*timestamppb.Timestamp)(nil),         // 21: google.protobuf.Timestamp
+	(*Pagination)(nil),                    // 22: spycat.v1.Pagination
+	(*emptypb.Empty)(nil),                 // 23: google.protobuf.Empty
+}
+var file_spycat_v1_mission_proto_depIdxs = []int32{
+	21, // 0: spycat.v1.Mission.starts_at:type_name -> google.protobuf.Timestamp
+	21, // 1: spycat.v1.Mission.ends_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: spycat.v1.Mission.targets:type_name -> spycat.v1.Target
+	2,  // 3: spycat.v1.Target.required_skills:type_name -> spycat.v1.SkillRequirement
+	0,  // 4: spycat.v1.AddMissionRequest.mission:type_name -> spycat.v1.Mission
+	21, // 5: spycat.v1.ListCatMissionsRequest.from:type_name -> google.protobuf.Timestamp
+	21, // 6: spycat.v1.ListCatMissionsRequest.to:type_name -> google.protobuf.Timestamp
+	0,  // 7: spycat.v1.ListMissionsResponse.missions:type_name -> spycat.v1.Mission
+	22, // 8: spycat.v1.ListMissionsResponse.meta:type_name -> spycat.v1.Pagination
+	1,  // 9: spycat.v1.AddTargetRequest.target:type_name -> spycat.v1.Target
+	3,  // 10: spycat.v1.ListMissionTypesResponse.types:type_name -> spycat.v1.MissionType
+	4,  // 11: spycat.v1.MissionService.AddMission:input_type -> spycat.v1.AddMissionRequest
+	5,  // 12: spycat.v1.MissionService.AddMissionFromTemplate:input_type -> spycat.v1.AddMissionFromTemplateRequest
+	6,  // 13: spycat.v1.MissionService.CloneMission:input_type -> spycat.v1.CloneMissionRequest
+	7,  // 14: spycat.v1.MissionService.GetMission:input_type -> spycat.v1.GetMissionRequest
+	8,  // 15: spycat.v1.MissionService.ListMissions:input_type -> spycat.v1.ListMissionsRequest
+	9,  // 16: spycat.v1.MissionService.ListCatMissions:input_type -> spycat.v1.ListCatMissionsRequest
+	11, // 17: spycat.v1.MissionService.AssignMission:input_type -> spycat.v1.AssignMissionRequest
+	13, // 18: spycat.v1.MissionService.CompleteMission:input_type -> spycat.v1.CompleteMissionRequest
+	14, // 19: spycat.v1.MissionService.DeleteMission:input_type -> spycat.v1.DeleteMissionRequest
+	15, // 20: spycat.v1.MissionService.AddTarget:input_type -> spycat.v1.AddTargetRequest
+	16, // 21: spycat.v1.MissionService.UpdateTarget:input_type -> spycat.v1.UpdateTargetRequest
+	17, // 22: spycat.v1.MissionService.CompleteTarget:input_type -> spycat.v1.CompleteTargetRequest
+	18, // 23: spycat.v1.MissionService.DeleteTarget:input_type -> spycat.v1.DeleteTargetRequest
+	19, // 24: spycat.v1.MissionService.ListMissionTypes:input_type -> spycat.v1.ListMissionTypesRequest
+	0,  // 25: spycat.v1.MissionService.AddMission:output_type -> spycat.v1.Mission
+	0,  // 26: spycat.v1.MissionService.AddMissionFromTemplate:output_type -> spycat.v1.Mission
+	0,  // 27: spycat.v1.MissionService.CloneMission:output_type -> spycat.v1.Mission
+	0,  // 28: spycat.v1.MissionService.GetMission:output_type -> spycat.v1.Mission
+	10, // 29: spycat.v1.MissionService.ListMissions:output_type -> spycat.v1.ListMissionsResponse
+	10, // 30: spycat.v1.MissionService.ListCatMissions:output_type -> spycat.v1.ListMissionsResponse
+	12, // 31: spycat.v1.MissionService.AssignMission:output_type -> spycat.v1.AssignMissionResponse
+	0,  // 32: spycat.v1.MissionService.CompleteMission:output_type -> spycat.v1.Mission
+	23, // 33: spycat.v1.MissionService.DeleteMission:output_type -> google.protobuf.Empty
+	0,  // 34: spycat.v1.MissionService.AddTarget:output_type -> spycat.v1.Mission
+	1,  // 35: spycat.v1.MissionService.UpdateTarget:output_type -> spycat.v1.Target
+	23, // 36: spycat.v1.MissionService.CompleteTarget:output_type -> google.protobuf.Empty
+	23, // 37: spycat.v1.MissionService.DeleteTarget:output_type -> google.protobuf.Empty
+	20, // 38: spycat.v1.MissionService.ListMissionTypes:output_type -> spycat.v1.ListMissionTypesResponse
+	25, // [25:39] is the sub-list for method output_type
+	11, // [11:25] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_spycat_v1_mission_proto_init() }
+func file_spycat_v1_mission_proto_init() {
+	if File_spycat_v1_mission_proto != nil {
+		return
+	}
+	file_spycat_v1_common_proto_init()
+	file_spycat_v1_mission_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spycat_v1_mission_proto_rawDesc), len(file_spycat_v1_mission_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spycat_v1_mission_proto_goTypes,
+		DependencyIndexes: file_spycat_v1_mission_proto_depIdxs,
+		MessageInfos:      file_spycat_v1_mission_proto_msgTypes,
+	}.Build()
+	File_spycat_v1_mission_proto = out.File
+	file_spycat_v1_mission_proto_goTypes = nil
+	file_spycat_v1_mission_proto_depIdxs = nil
+}
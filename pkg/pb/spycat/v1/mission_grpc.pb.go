@@ -0,0 +1,622 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v5.29.3
+// source: spycat/v1/mission.proto
+
+package spycatv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MissionService_AddMission_FullMethodName             = "/spycat.v1.MissionService/AddMission"
+	MissionService_AddMissionFromTemplate_FullMethodName = "/spycat.v1.MissionService/AddMissionFromTemplate"
+	MissionService_CloneMission_FullMethodName           = "/spycat.v1.MissionService/CloneMission"
+	MissionService_GetMission_FullMethodName             = "/spycat.v1.MissionService/GetMission"
+	MissionService_ListMissions_FullMethodName           = "/spycat.v1.MissionService/ListMissions"
+	MissionService_ListCatMissions_FullMethodName        = "/spycat.v1.MissionService/ListCatMissions"
+	MissionService_AssignMission_FullMethodName          = "/spycat.v1.MissionService/AssignMission"
+	MissionService_CompleteMission_FullMethodName        = "/spycat.v1.MissionService/CompleteMission"
+	MissionService_DeleteMission_FullMethodName          = "/spycat.v1.MissionService/DeleteMission"
+	MissionService_AddTarget_FullMethodName              = "/spycat.v1.MissionService/AddTarget"
+	MissionService_UpdateTarget_FullMethodName           = "/spycat.v1.MissionService/UpdateTarget"
+	MissionService_CompleteTarget_FullMethodName         = "/spycat.v1.MissionService/CompleteTarget"
+	MissionService_DeleteTarget_FullMethodName           = "/spycat.v1.MissionService/DeleteTarget"
+	MissionService_ListMissionTypes_FullMethodName       = "/spycat.v1.MissionService/ListMissionTypes"
+)
+
+// MissionServiceClient is the client API for MissionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MissionService mirrors mission and target routes of the REST API. Errors are reported like errors of CatService
+type MissionServiceClient interface {
+	AddMission(ctx context.Context, in *AddMissionRequest, opts ...grpc.CallOption) (*Mission, error)
+	AddMissionFromTemplate(ctx context.Context, in *AddMissionFromTemplateRequest, opts ...grpc.CallOption) (*Mission, error)
+	CloneMission(ctx context.Context, in *CloneMissionRequest, opts ...grpc.CallOption) (*Mission, error)
+	GetMission(ctx context.Context, in *GetMissionRequest, opts ...grpc.CallOption) (*Mission, error)
+	ListMissions(ctx context.Context, in *ListMissionsRequest, opts ...grpc.CallOption) (*ListMissionsResponse, error)
+	ListCatMissions(ctx context.Context, in *ListCatMissionsRequest, opts ...grpc.CallOption) (*ListMissionsResponse, error)
+	// AssignMission assigns the cat to the mission. Cats lacking required skills are assigned only with override
+	AssignMission(ctx context.Context, in *AssignMissionRequest, opts ...grpc.CallOption) (*AssignMissionResponse, error)
+	CompleteMission(ctx context.Context, in *CompleteMissionRequest, opts ...grpc.CallOption) (*Mission, error)
+	DeleteMission(ctx context.Context, in *DeleteMissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddTarget(ctx context.Context, in *AddTargetRequest, opts ...grpc.CallOption) (*Mission, error)
+	UpdateTarget(ctx context.Context, in *UpdateTargetRequest, opts ...grpc.CallOption) (*Target, error)
+	CompleteTarget(ctx context.Context, in *CompleteTargetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteTarget(ctx context.Context, in *DeleteTargetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListMissionTypes(ctx context.Context, in *ListMissionTypesRequest, opts ...grpc.CallOption) (*ListMissionTypesResponse, error)
+}
+
+type missionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMissionServiceClient(cc grpc.ClientConnInterface) MissionServiceClient {
+	return &missionServiceClient{cc}
+}
+
+func (c *missionServiceClient) AddMission(ctx context.Context, in *AddMissionRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_AddMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) AddMissionFromTemplate(ctx context.Context, in *AddMissionFromTemplateRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_AddMissionFromTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) CloneMission(ctx context.Context, in *CloneMissionRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_CloneMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) GetMission(ctx context.Context, in *GetMissionRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_GetMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) ListMissions(ctx context.Context, in *ListMissionsRequest, opts ...grpc.CallOption) (*ListMissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMissionsResponse)
+	err := c.cc.Invoke(ctx, MissionService_ListMissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) ListCatMissions(ctx context.Context, in *ListCatMissionsRequest, opts ...grpc.CallOption) (*ListMissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMissionsResponse)
+	err := c.cc.Invoke(ctx, MissionService_ListCatMissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) AssignMission(ctx context.Context, in *AssignMissionRequest, opts ...grpc.CallOption) (*AssignMissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignMissionResponse)
+	err := c.cc.Invoke(ctx, MissionService_AssignMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) CompleteMission(ctx context.Context, in *CompleteMissionRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_CompleteMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) DeleteMission(ctx context.Context, in *DeleteMissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MissionService_DeleteMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) AddTarget(ctx context.Context, in *AddTargetRequest, opts ...grpc.CallOption) (*Mission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mission)
+	err := c.cc.Invoke(ctx, MissionService_AddTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) UpdateTarget(ctx context.Context, in *UpdateTargetRequest, opts ...grpc.CallOption) (*Target, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Target)
+	err := c.cc.Invoke(ctx, MissionService_UpdateTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) CompleteTarget(ctx context.Context, in *CompleteTargetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MissionService_CompleteTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) DeleteTarget(ctx context.Context, in *DeleteTargetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MissionService_DeleteTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *missionServiceClient) ListMissionTypes(ctx context.Context, in *ListMissionTypesRequest, opts ...grpc.CallOption) (*ListMissionTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMissionTypesResponse)
+	err := c.cc.Invoke(ctx, MissionService_ListMissionTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MissionServiceServer is the server API for MissionService service.
+// All implementations must embed UnimplementedMissionServiceServer
+// for forward compatibility.
+//
+// MissionService mirrors mission and target routes of the REST API. Errors are reported like errors of CatService
+type MissionServiceServer interface {
+	AddMission(context.Context, *AddMissionRequest) (*Mission, error)
+	AddMissionFromTemplate(context.Context, *AddMissionFromTemplateRequest) (*Mission, error)
+	CloneMission(context.Context, *CloneMissionRequest) (*Mission, error)
+	GetMission(context.Context, *GetMissionRequest) (*Mission, error)
+	ListMissions(context.Context, *ListMissionsRequest) (*ListMissionsResponse, error)
+	ListCatMissions(context.Context, *ListCatMissionsRequest) (*ListMissionsResponse, error)
+	// AssignMission assigns the cat to the mission. Cats lacking required skills are assigned only with override
+	AssignMission(context.Context, *AssignMissionRequest) (*AssignMissionResponse, error)
+	CompleteMission(context.Context, *CompleteMissionRequest) (*Mission, error)
+	DeleteMission(context.Context, *DeleteMissionRequest) (*emptypb.Empty, error)
+	AddTarget(context.Context, *AddTargetRequest) (*Mission, error)
+	UpdateTarget(context.Context, *UpdateTargetRequest) (*Target, error)
+	CompleteTarget(context.Context, *CompleteTargetRequest) (*emptypb.Empty, error)
+	DeleteTarget(context.Context, *DeleteTargetRequest) (*emptypb.Empty, error)
+	ListMissionTypes(context.Context, *ListMissionTypesRequest) (*ListMissionTypesResponse, error)
+	mustEmbedUnimplementedMissionServiceServer()
+}
+
+// UnimplementedMissionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMissionServiceServer struct{}
+
+func (UnimplementedMissionServiceServer) AddMission(context.Context, *AddMissionRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddMission not implemented")
+}
+func (UnimplementedMissionServiceServer) AddMissionFromTemplate(context.Context, *AddMissionFromTemplateRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddMissionFromTemplate not implemented")
+}
+func (UnimplementedMissionServiceServer) CloneMission(context.Context, *CloneMissionRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method CloneMission not implemented")
+}
+func (UnimplementedMissionServiceServer) GetMission(context.Context, *GetMissionRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMission not implemented")
+}
+func (UnimplementedMissionServiceServer) ListMissions(context.Context, *ListMissionsRequest) (*ListMissionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMissions not implemented")
+}
+func (UnimplementedMissionServiceServer) ListCatMissions(context.Context, *ListCatMissionsRequest) (*ListMissionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCatMissions not implemented")
+}
+func (UnimplementedMissionServiceServer) AssignMission(context.Context, *AssignMissionRequest) (*AssignMissionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AssignMission not implemented")
+}
+func (UnimplementedMissionServiceServer) CompleteMission(context.Context, *CompleteMissionRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteMission not implemented")
+}
+func (UnimplementedMissionServiceServer) DeleteMission(context.Context, *DeleteMissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteMission not implemented")
+}
+func (UnimplementedMissionServiceServer) AddTarget(context.Context, *AddTargetRequest) (*Mission, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddTarget not implemented")
+}
+func (UnimplementedMissionServiceServer) UpdateTarget(context.Context, *UpdateTargetRequest) (*Target, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateTarget not implemented")
+}
+func (UnimplementedMissionServiceServer) CompleteTarget(context.Context, *CompleteTargetRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteTarget not implemented")
+}
+func (UnimplementedMissionServiceServer) DeleteTarget(context.Context, *DeleteTargetRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTarget not implemented")
+}
+func (UnimplementedMissionServiceServer) ListMissionTypes(context.Context, *ListMissionTypesRequest) (*ListMissionTypesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMissionTypes not implemented")
+}
+func (UnimplementedMissionServiceServer) mustEmbedUnimplementedMissionServiceServer() {}
+func (UnimplementedMissionServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeMissionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MissionServiceServer will
+// result in compilation errors.
+type UnsafeMissionServiceServer interface {
+	mustEmbedUnimplementedMissionServiceServer()
+}
+
+func RegisterMissionServiceServer(s grpc.ServiceRegistrar, srv MissionServiceServer) {
+	// If the following call panics, it indicates UnimplementedMissionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MissionService_ServiceDesc, srv)
+}
+
+func _MissionService_AddMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).AddMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_AddMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).AddMission(ctx, req.(*AddMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_AddMissionFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMissionFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).AddMissionFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_AddMissionFromTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).AddMissionFromTemplate(ctx, req.(*AddMissionFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_CloneMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).CloneMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_CloneMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).CloneMission(ctx, req.(*CloneMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_GetMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).GetMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_GetMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).GetMission(ctx, req.(*GetMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_ListMissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).ListMissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_ListMissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).ListMissions(ctx, req.(*ListMissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_ListCatMissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCatMissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).ListCatMissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_ListCatMissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).ListCatMissions(ctx, req.(*ListCatMissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_AssignMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).AssignMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_AssignMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).AssignMission(ctx, req.(*AssignMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_CompleteMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).CompleteMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_CompleteMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).CompleteMission(ctx, req.(*CompleteMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_DeleteMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).DeleteMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_DeleteMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).DeleteMission(ctx, req.(*DeleteMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_AddTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).AddTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_AddTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).AddTarget(ctx, req.(*AddTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_UpdateTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).UpdateTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_UpdateTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).UpdateTarget(ctx, req.(*UpdateTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_CompleteTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).CompleteTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_CompleteTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).CompleteTarget(ctx, req.(*CompleteTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_DeleteTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).DeleteTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_DeleteTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).DeleteTarget(ctx, req.(*DeleteTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MissionService_ListMissionTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMissionTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MissionServiceServer).ListMissionTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MissionService_ListMissionTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MissionServiceServer).ListMissionTypes(ctx, req.(*ListMissionTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MissionService_ServiceDesc is the grpc.ServiceDesc for MissionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MissionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spycat.v1.MissionService",
+	HandlerType: (*MissionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddMission",
+			Handler:    _MissionService_AddMission_Handler,
+		},
+		{
+			MethodName: "AddMissionFromTemplate",
+			Handler:    _MissionService_AddMissionFromTemplate_Handler,
+		},
+		{
+			MethodName: "CloneMission",
+			Handler:    _MissionService_CloneMission_Handler,
+		},
+		{
+			MethodName: "GetMission",
+			Handler:    _MissionService_GetMission_Handler,
+		},
+		{
+			MethodName: "ListMissions",
+			Handler:    _MissionService_ListMissions_Handler,
+		},
+		{
+			MethodName: "ListCatMissions",
+			Handler:    _MissionService_ListCatMissions_Handler,
+		},
+		{
+			MethodName: "AssignMission",
+			Handler:    _MissionService_AssignMission_Handler,
+		},
+		{
+			MethodName: "CompleteMission",
+			Handler:    _MissionService_CompleteMission_Handler,
+		},
+		{
+			MethodName: "DeleteMission",
+			Handler:    _MissionService_DeleteMission_Handler,
+		},
+		{
+			MethodName: "AddTarget",
+			Handler:    _MissionService_AddTarget_Handler,
+		},
+		{
+			MethodName: "UpdateTarget",
+			Handler:    _MissionService_UpdateTarget_Handler,
+		},
+		{
+			MethodName: "CompleteTarget",
+			Handler:    _MissionService_CompleteTarget_Handler,
+		},
+		{
+			MethodName: "DeleteTarget",
+			Handler:    _MissionService_DeleteTarget_Handler,
+		},
+		{
+			MethodName: "ListMissionTypes",
+			Handler:    _MissionService_ListMissionTypes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spycat/v1/mission.proto",
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewLocalStorage(dir, "/photos/")
+
+	t.Run("put, get and delete object", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "cats/1/photo.png", strings.NewReader("first")))
+		require.NoError(t, store.Put(ctx, "cats/1/photo.png", strings.NewReader("second")))
+
+		r, err := store.Get(ctx, "cats/1/photo.png")
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+		assert.Equal(t, "second", string(data))
+
+		entries, err := os.ReadDir(filepath.Join(dir, "cats", "1"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(entries), "temporary files must be removed")
+
+		require.NoError(t, store.Delete(ctx, "cats/1/photo.png"))
+		require.NoError(t, store.Delete(ctx, "cats/1/photo.png"))
+		_, err = store.Get(ctx, "cats/1/photo.png")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("directory is not an object", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "cats/2/photo.png", strings.NewReader("photo")))
+		_, err := store.Get(ctx, "cats/2")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("reject keys outside of the directory", func(t *testing.T) {
+		for _, key := range []string{"../secret", "/etc/passwd", "cats/../../secret", "", "."} {
+			assert.Error(t, store.Put(ctx, key, strings.NewReader("x")), key)
+			_, err := store.Get(ctx, key)
+			assert.Error(t, err, key)
+		}
+	})
+
+	t.Run("url of the object", func(t *testing.T) {
+		assert.Equal(t, "/photos/cats/1/photo.png", store.URL("cats/1/photo.png"))
+	})
+}
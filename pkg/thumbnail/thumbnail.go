@@ -0,0 +1,57 @@
+// Package thumbnail scales images to square thumbnails without external dependencies
+package thumbnail
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Square crops the centre square of the image and scales it to size x size pixels.
+// Every pixel of the result is the area weighted average of the source pixels it covers
+func Square(src image.Image, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	if side == 0 || size <= 0 {
+		return dst
+	}
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	scale := float64(side) / float64(size)
+
+	for dy := 0; dy < size; dy++ {
+		sy0 := float64(dy) * scale
+		sy1 := sy0 + scale
+		for dx := 0; dx < size; dx++ {
+			sx0 := float64(dx) * scale
+			sx1 := sx0 + scale
+			var r, g, bl, a, total float64
+			for sy := int(sy0); float64(sy) < sy1 && sy < side; sy++ {
+				wy := math.Min(float64(sy+1), sy1) - math.Max(float64(sy), sy0)
+				for sx := int(sx0); float64(sx) < sx1 && sx < side; sx++ {
+					wx := math.Min(float64(sx+1), sx1) - math.Max(float64(sx), sx0)
+					w := wx * wy
+					// RGBA returns alpha premultiplied 16 bit values
+					pr, pg, pb, pa := src.At(x0+sx, y0+sy).RGBA()
+					r += float64(pr) * w
+					g += float64(pg) * w
+					bl += float64(pb) * w
+					a += float64(pa) * w
+					total += w
+				}
+			}
+			dst.SetRGBA(dx, dy, color.RGBA{
+				R: channel(r, total),
+				G: channel(g, total),
+				B: channel(bl, total),
+				A: channel(a, total),
+			})
+		}
+	}
+	return dst
+}
+
+func channel(sum, weight float64) uint8 {
+	return uint8(math.Round(sum / weight / 257))
+}
@@ -0,0 +1,49 @@
+package thumbnail
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSquare(t *testing.T) {
+	t.Run("crops centre of wide image", func(t *testing.T) {
+		// red stripes on the sides must be cropped away
+		src := image.NewRGBA(image.Rect(0, 0, 300, 100))
+		for x := 0; x < 300; x++ {
+			for y := 0; y < 100; y++ {
+				c := color.RGBA{B: 255, A: 255}
+				if x < 100 || x >= 200 {
+					c = color.RGBA{R: 255, A: 255}
+				}
+				src.SetRGBA(x, y, c)
+			}
+		}
+		thumb := Square(src, 10)
+		assert.Equal(t, image.Rect(0, 0, 10, 10), thumb.Bounds())
+		for x := 0; x < 10; x++ {
+			for y := 0; y < 10; y++ {
+				assert.Equal(t, color.RGBA{B: 255, A: 255}, thumb.RGBAAt(x, y))
+			}
+		}
+	})
+
+	t.Run("averages covered pixels", func(t *testing.T) {
+		src := image.NewGray(image.Rect(0, 0, 2, 2))
+		src.SetGray(0, 0, color.Gray{Y: 255})
+		src.SetGray(1, 1, color.Gray{Y: 255})
+		thumb := Square(src, 1)
+		assert.Equal(t, color.RGBA{R: 128, G: 128, B: 128, A: 255}, thumb.RGBAAt(0, 0))
+	})
+
+	t.Run("upscales small image", func(t *testing.T) {
+		src := image.NewRGBA(image.Rect(5, 5, 7, 7))
+		src.SetRGBA(5, 5, color.RGBA{R: 255, A: 255})
+		thumb := Square(src, 4)
+		assert.Equal(t, color.RGBA{R: 255, A: 255}, thumb.RGBAAt(0, 0))
+		assert.Equal(t, color.RGBA{R: 255, A: 255}, thumb.RGBAAt(1, 1))
+		assert.Equal(t, color.RGBA{}, thumb.RGBAAt(3, 3))
+	})
+}
@@ -0,0 +1,166 @@
+// Package xlsx writes Office Open XML workbooks with a single sheet. Rows are streamed to the
+// underlying writer so large sheets are not kept in memory
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// MaxRows is the number of rows a sheet can have
+const MaxRows = 1 << 20
+
+var ErrTooManyRows = fmt.Errorf("sheet can't have more than %d rows", MaxRows)
+
+var ErrClosed = errors.New("writer is closed")
+
+const contentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const rootRelationships = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbookRelationships = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
+
+const workbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const sheetStart = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const sheetEnd = `</sheetData></worksheet>`
+
+// Writer writes rows of the sheet. Close must be called to finish the workbook
+type Writer struct {
+	zip    *zip.Writer
+	sheet  *bufio.Writer
+	rows   int
+	closed bool
+}
+
+// NewWriter writes parts of the workbook that precede the sheet and returns writer of the sheet rows
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	archive := zip.NewWriter(w)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRelationships},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, escape(sheetName))},
+		{"xl/_rels/workbook.xml.rels", workbookRelationships},
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return nil, err
+		}
+	}
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(file)
+	if _, err := sheet.WriteString(sheetStart); err != nil {
+		return nil, err
+	}
+	return &Writer{zip: archive, sheet: sheet}, nil
+}
+
+// Write adds a row. Strings, booleans, integers and floats keep their types,
+// times are written as RFC 3339 strings and nil values leave the cell empty
+func (w *Writer) Write(values []any) error {
+	if w.closed {
+		return ErrClosed
+	}
+	if w.rows == MaxRows {
+		return ErrTooManyRows
+	}
+	w.rows++
+	row := strconv.Itoa(w.rows)
+	w.sheet.WriteString(`<row r="` + row + `">`)
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		ref := column(i) + row
+		switch v := value.(type) {
+		case string:
+			w.writeString(ref, v)
+		case bool:
+			b := "0"
+			if v {
+				b = "1"
+			}
+			w.sheet.WriteString(`<c r="` + ref + `" t="b"><v>` + b + `</v></c>`)
+		case int:
+			w.writeNumber(ref, strconv.Itoa(v))
+		case int64:
+			w.writeNumber(ref, strconv.FormatInt(v, 10))
+		case float64:
+			w.writeNumber(ref, strconv.FormatFloat(v, 'g', -1, 64))
+		case time.Time:
+			w.writeString(ref, v.Format(time.RFC3339))
+		default:
+			w.writeString(ref, fmt.Sprint(v))
+		}
+	}
+	_, err := w.sheet.WriteString(`</row>`)
+	return err
+}
+
+// Close finishes the sheet and the workbook. It doesn't close the underlying writer
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if _, err := w.sheet.WriteString(sheetEnd); err != nil {
+		return err
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+func (w *Writer) writeString(ref, value string) {
+	w.sheet.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">` + escape(value) + `</t></is></c>`)
+}
+
+func (w *Writer) writeNumber(ref, value string) {
+	w.sheet.WriteString(`<c r="` + ref + `"><v>` + value + `</v></c>`)
+}
+
+// escape escapes XML special characters. Characters that XML can't contain are replaced with U+FFFD
+func escape(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+// column returns letters of the zero based column index: A, B, ..., Z, AA, AB, ...
+func column(index int) string {
+	var name []byte
+	for index >= 0 {
+		name = append([]byte{byte('A' + index%26)}, name...)
+		index = index/26 - 1
+	}
+	return string(name)
+}
@@ -0,0 +1,80 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sheet struct {
+	Rows []struct {
+		Ref   string `xml:"r,attr"`
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "cats & missions")
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]any{"id", "name", "active", "salary", "since"}))
+	require.NoError(t, w.Write([]any{int64(1), "Tom <the cat>", true, 1500.5, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}))
+	require.NoError(t, w.Write([]any{int64(2), nil, false, 0, nil}))
+	require.NoError(t, w.Close())
+	assert.ErrorIs(t, w.Write([]any{"late"}), ErrClosed)
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		files[file.Name] = content
+	}
+	require.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, string(files["xl/workbook.xml"]), `name="cats &amp; missions"`)
+
+	var s sheet
+	require.NoError(t, xml.Unmarshal(files["xl/worksheets/sheet1.xml"], &s))
+	require.Len(t, s.Rows, 3)
+	assert.Equal(t, "2", s.Rows[1].Ref)
+
+	cells := s.Rows[1].Cells
+	require.Len(t, cells, 5)
+	assert.Equal(t, "A2", cells[0].Ref)
+	assert.Equal(t, "1", cells[0].Value)
+	assert.Equal(t, "inlineStr", cells[1].Type)
+	assert.Equal(t, "Tom <the cat>", cells[1].Inline)
+	assert.Equal(t, "b", cells[2].Type)
+	assert.Equal(t, "1", cells[2].Value)
+	assert.Equal(t, "1500.5", cells[3].Value)
+	assert.Equal(t, "2025-03-01T00:00:00Z", cells[4].Inline)
+
+	// nil values leave cells empty
+	cells = s.Rows[2].Cells
+	require.Len(t, cells, 3)
+	assert.Equal(t, "C3", cells[1].Ref)
+}
+
+func TestColumn(t *testing.T) {
+	assert.Equal(t, "A", column(0))
+	assert.Equal(t, "Z", column(25))
+	assert.Equal(t, "AA", column(26))
+	assert.Equal(t, "AZ", column(51))
+	assert.Equal(t, "BA", column(52))
+	assert.Equal(t, "ZZ", column(701))
+	assert.Equal(t, "AAA", column(702))
+}
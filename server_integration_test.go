@@ -1,17 +1,25 @@
 package spycatagency_test
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
 	"log"
 	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -19,9 +27,13 @@ import (
 
 	spycatagency "github.com/4oBuko/spy-cat-agency/internal"
 	"github.com/4oBuko/spy-cat-agency/internal/models"
+	"github.com/4oBuko/spy-cat-agency/internal/myerrors"
 	"github.com/4oBuko/spy-cat-agency/internal/repositories"
 	"github.com/4oBuko/spy-cat-agency/internal/services"
 	"github.com/4oBuko/spy-cat-agency/pkg/catapi"
+	"github.com/4oBuko/spy-cat-agency/pkg/country"
+	"github.com/4oBuko/spy-cat-agency/pkg/storage"
+	"github.com/4oBuko/spy-cat-agency/pkg/xlsx"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -33,6 +45,32 @@ import (
 
 var server *spycatagency.Server
 var cleaner *dbCleaner
+var catAPI = NewFakeCatAPI()
+var ranks = services.NewRanks(testRanks)
+
+// testHandler owns cats of tests that don't care about handlers
+var testHandler models.Handler
+
+const testPhotoMaxSize = 64 << 10
+
+// testPayrollBudget is high enough for all tests except the ones that check the cap
+const testPayrollBudget = 1_000_000_000
+
+var testThumbnailSizes = []int{16, 32}
+
+const testIdempotencyTTL = time.Hour
+
+var testMissionTypes = []models.MissionType{
+	{Name: "standard", MinTargets: 1, MaxTargets: 3},
+	{Name: "extraction", MinTargets: 1, MaxTargets: 1, MinExperience: 3, SalaryBonus: 2000},
+	{Name: "sabotage", MinTargets: 2, MaxTargets: 4, MinExperience: 5, SalaryBonus: 3500},
+}
+
+var testRanks = []models.Rank{
+	{Name: "cadet"},
+	{Name: "field agent", MinExperience: 2, MinCompletedMissions: 2, SalaryRaise: 500},
+	{Name: "senior agent", MinExperience: 5, MinCompletedMissions: 4, SalaryRaise: 1000},
+}
 
 func TestMain(m *testing.M) {
 	fmt.Println("Test env initialization started")
@@ -57,7 +95,7 @@ func TestMain(m *testing.M) {
 		return
 	}
 	fmt.Println("Container started!")
-	connectionString, err := mysqlContainer.ConnectionString(ctx)
+	connectionString, err := mysqlContainer.ConnectionString(ctx, "parseTime=true")
 	if err != nil {
 		log.Fatal("failed to get connection string:%w", err)
 	}
@@ -73,12 +111,43 @@ func TestMain(m *testing.M) {
 
 	cleaner = &dbCleaner{db: db}
 	catRepo := repositories.NewMySQLCatRepository(db)
-	catAPI := NewFakeCatAPI()
-	catService := services.NewDefaultCatService(catRepo, catAPI)
+	salaryRepo := repositories.NewMySQLSalaryRepository(db)
+	statusRepo := repositories.NewMySQLStatusRepository(db)
+	rankRepo := repositories.NewMySQLRankRepository(db)
+	transactor := repositories.NewMySQLTransactor(db)
+	budgetService := services.NewDefaultBudgetService(repositories.NewMySQLBudgetRepository(db), testPayrollBudget)
+	photoDir, err := os.MkdirTemp("", "photos")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(photoDir)
+	photoStorage := storage.NewLocalStorage(photoDir, spycatagency.PhotoBaseURL)
+	photoService := services.NewDefaultPhotoService(catRepo, photoStorage, testPhotoMaxSize, testThumbnailSizes)
+	catService := services.NewDefaultCatService(catRepo, salaryRepo, statusRepo, rankRepo, transactor, catAPI, ranks, budgetService, photoService)
 	missionRepo := repositories.NewMySQLMissionRepository(db)
 	targetRepo := repositories.NewMySQLTargetRepository(db)
-	missionService := services.NewDefaultMissionService(missionRepo, targetRepo, catRepo)
-	server = spycatagency.NewServer(catService, catAPI, missionService)
+	missionTypes := services.NewMissionTypes(testMissionTypes, "standard")
+	templateRepo := repositories.NewMySQLTemplateRepository(db)
+	skillRepo := repositories.NewMySQLSkillRepository(db)
+	absenceRepo := repositories.NewMySQLAbsenceRepository(db)
+	ledgerRepo := repositories.NewMySQLLedgerRepository(db)
+	missionService := services.NewDefaultMissionService(missionRepo, targetRepo, catRepo, templateRepo, skillRepo, absenceRepo, rankRepo, ledgerRepo, transactor, missionTypes, ranks, budgetService)
+	templateService := services.NewDefaultTemplateService(templateRepo, missionTypes)
+	payrollService := services.NewDefaultPayrollService(salaryRepo)
+	skillService := services.NewDefaultSkillService(skillRepo, catRepo)
+	absenceService := services.NewDefaultAbsenceService(absenceRepo, catRepo)
+	statsService := services.NewDefaultStatsService(repositories.NewMySQLStatsRepository(db))
+	ledgerService := services.NewDefaultLedgerService(ledgerRepo, catRepo)
+	handlerService := services.NewDefaultHandlerService(repositories.NewMySQLHandlerRepository(db), catRepo, transactor, catService, missionService, budgetService)
+	importService := services.NewDefaultImportService(catRepo, transactor, catAPI, ranks, budgetService)
+	exportService := services.NewDefaultExportService(catRepo, missionRepo)
+	server = spycatagency.NewServer(catService, catAPI, missionService, templateService, payrollService, skillService, absenceService, statsService, ledgerService,
+		handlerService, photoService, budgetService, importService, exportService, transactor,
+		repositories.NewMySQLIdempotencyRepository(db), testIdempotencyTTL)
+	testHandler, err = handlerService.Add(ctx, models.Handler{Name: "Default"})
+	if err != nil {
+		log.Fatal(err)
+	}
 	fmt.Println("Initialization finished. Starting tests")
 	code := m.Run()
 	os.Exit(code)
@@ -89,10 +158,11 @@ type dbCleaner struct {
 }
 
 func (d *dbCleaner) cleanDB() error {
-	deleteCats := "DELETE FROM cats"
+	deleteAssignments := "DELETE FROM mission_assignments"
 	deleteTargets := "DELETE FROM targets"
 	deleteMissions := "DELETE FROM missions"
-	_, err := d.db.Exec(deleteCats)
+	deleteCats := "DELETE FROM cats"
+	_, err := d.db.Exec(deleteAssignments)
 	if err != nil {
 		return err
 	}
@@ -104,6 +174,10 @@ func (d *dbCleaner) cleanDB() error {
 	if err != nil {
 		return err
 	}
+	_, err = d.db.Exec(deleteCats)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -118,6 +192,10 @@ func TestAddNewCat(t *testing.T) {
 		}
 		cat := addNewCatSuccessfully(t, newCat)
 		newCat.Id = cat.Id
+		newCat.Status = models.CatStatusActive
+		newCat.Rank = "cadet"
+		newCat.HandlerId = testHandler.Id
+		newCat.BreedDetails = &models.BreedDetails{Name: "Abyssinian", Origin: "Egypt", Temperament: "Active, Energetic, Independent, Intelligent, Gentle", LifeSpan: "14 - 15"}
 		assert.Equal(t, newCat, cat)
 	})
 
@@ -177,6 +255,148 @@ func TestUpdateSalary(t *testing.T) {
 	})
 }
 
+func TestSalaryHistory(t *testing.T) {
+	now := time.Now().UTC()
+	nextMonth := time.Date(now.Year(), now.Month()+2, 1, 0, 0, 0, 0, time.UTC)
+	monthAfter := nextMonth.AddDate(0, 1, 0)
+
+	cat := addNewCatSuccessfully(t, models.Cat{
+		Name:              "Accountant",
+		Breed:             "abys",
+		YearsOfExperience: 2,
+		Salary:            1000,
+	})
+
+	t.Run("future salary change is recorded but not applied", func(t *testing.T) {
+		update := models.CatUpdate{Salary: 3000, EffectiveFrom: &nextMonth, Reason: "promotion"}
+		updatedCat := updateSalarySuccessfully(t, cat.Id, update)
+		assert.Equal(t, cat, updatedCat)
+
+		update = models.CatUpdate{Salary: 6000, EffectiveFrom: &monthAfter, Reason: "another promotion"}
+		updateSalarySuccessfully(t, cat.Id, update)
+
+		url := strings.Replace(spycatagency.Endpoints.CatSalaryHistory, ":id", strconv.Itoa(int(cat.Id)), 1)
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+
+		history := unmarshal[[]models.SalaryChange](t, response.Body.Bytes())
+		require.Equal(t, 3, len(history))
+		assert.Equal(t, 1000, history[0].Salary)
+		assert.Equal(t, 3000, history[1].Salary)
+		assert.Equal(t, "promotion", history[1].Reason)
+		assert.True(t, nextMonth.Equal(history[1].EffectiveFrom))
+		assert.Equal(t, 6000, history[2].Salary)
+	})
+
+	t.Run("attempt to change salary in the past", func(t *testing.T) {
+		past := now.AddDate(0, -1, 0)
+		url := strings.Replace(spycatagency.Endpoints.CatUpdate, ":id", strconv.Itoa(int(cat.Id)), 1)
+		body := marshal(t, models.CatUpdate{Salary: 1, EffectiveFrom: &past})
+		request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+
+	t.Run("attempt to get salary history of non existing cat", func(t *testing.T) {
+		url := strings.Replace(spycatagency.Endpoints.CatSalaryHistory, ":id", strconv.Itoa(math.MaxInt64), 1)
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		doRequestAndExpect(t, request, http.StatusNotFound)
+	})
+
+	t.Run("payroll uses salary effective in the period", func(t *testing.T) {
+		lastDay := monthAfter.AddDate(0, 1, -1)
+		payroll := getPayrollSuccessfully(t, nextMonth, lastDay)
+		assert.Equal(t, nextMonth.Format(time.DateOnly), payroll.From)
+		assert.Equal(t, 9000.0, catPayroll(t, payroll, cat.Id).Amount)
+
+		payroll = getPayrollSuccessfully(t, nextMonth, monthAfter.AddDate(0, 0, -1))
+		assert.Equal(t, 3000.0, catPayroll(t, payroll, cat.Id).Amount)
+	})
+
+	t.Run("payroll prorates partial months", func(t *testing.T) {
+		days := monthAfter.AddDate(0, 1, 0).Sub(monthAfter).Hours() / 24
+		payroll := getPayrollSuccessfully(t, monthAfter, monthAfter)
+		assert.InDelta(t, 6000/days, catPayroll(t, payroll, cat.Id).Amount, 0.01)
+	})
+
+	t.Run("attempt to get payroll with from after to", func(t *testing.T) {
+		url := spycatagency.Endpoints.PayrollGet + "?from=2025-02-01&to=2025-01-01"
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+}
+
+func TestPatchCat(t *testing.T) {
+	newCat := models.Cat{
+		Name:              "Tpyo",
+		Breed:             "abys",
+		YearsOfExperience: 3,
+		Salary:            1500,
+	}
+
+	t.Run("fix name and breed of a busy cat", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, newCat)
+		mission := addNewMissionSuccessfully(t, models.Mission{
+			Targets: []models.Target{{Name: "Laser pointer", Country: "Spain"}},
+		})
+		assignMissionSuccessfully(t, mission, cat)
+
+		response := patchCat(t, cat.Id, `{"name":"Typo","breed":"acur"}`)
+		require.Equal(t, http.StatusOK, response.Code)
+
+		cat.Name = "Typo"
+		cat.Breed = "acur"
+		cat.BreedDetails = &models.BreedDetails{Name: "American Curl"}
+		assert.Equal(t, cat, unmarshal[models.Cat](t, response.Body.Bytes()))
+		assert.Equal(t, cat, getCatByIDSuccessfully(t, int(cat.Id)))
+	})
+
+	t.Run("patch salary records salary change", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, newCat)
+
+		response := patchCat(t, cat.Id, `{"salary":2000,"yearsOfExperience":4}`)
+		require.Equal(t, http.StatusOK, response.Code)
+		patchedCat := unmarshal[models.Cat](t, response.Body.Bytes())
+		assert.Equal(t, 2000, patchedCat.Salary)
+		assert.Equal(t, 4, patchedCat.YearsOfExperience)
+
+		url := strings.Replace(spycatagency.Endpoints.CatSalaryHistory, ":id", strconv.Itoa(int(cat.Id)), 1)
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		response = httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, 2, len(unmarshal[[]models.SalaryChange](t, response.Body.Bytes())))
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, newCat)
+		cases := []struct {
+			name  string
+			patch string
+		}{
+			{name: "remove required field", patch: `{"name":null}`},
+			{name: "too long name", patch: `{"name":"` + strings.Repeat("a", 51) + `"}`},
+			{name: "negative experience", patch: `{"yearsOfExperience":-1}`},
+			{name: "unknown breed", patch: `{"breed":"fraud"}`},
+			{name: "not patchable field", patch: `{"id":1}`},
+			{name: "not an object", patch: `["name"]`},
+		}
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				response := patchCat(t, cat.Id, c.patch)
+				assert.Equal(t, http.StatusBadRequest, response.Code)
+			})
+		}
+		assert.Equal(t, cat, getCatByIDSuccessfully(t, int(cat.Id)))
+	})
+
+	t.Run("attempt to patch non existing cat", func(t *testing.T) {
+		response := patchCat(t, math.MaxInt64, `{"name":"Ghost"}`)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
 func TestDeleteCat(t *testing.T) {
 	t.Run("delete cat and try to get by id", func(t *testing.T) {
 		newCat := models.Cat{
@@ -1039,6 +1259,7 @@ func TestUpdateMissionTargets(t *testing.T) {
 
 		uMission := unmarshal[models.Mission](t, response.Body.Bytes())
 		mission.Targets = append(mission.Targets, newTarget)
+		normalizeCountries(t, mission.Targets)
 		require.Equal(t, len(mission.Targets), len(uMission.Targets))
 
 		mission.Targets[1].Id = uMission.Targets[1].Id
@@ -1146,132 +1367,1797 @@ func TestMissionDelete(t *testing.T) {
 	})
 }
 
-func getMissionByIdSuccessfully(t *testing.T, id int) models.Mission {
-	t.Helper()
-	request := newGetMissionByIdRequest(id)
-	response := httptest.NewRecorder()
+func TestTargetCountryNormalization(t *testing.T) {
+	t.Run("accept country name, alias and codes", func(t *testing.T) {
+		newMission := models.Mission{
+			Targets: []models.Target{
+				{Name: "James Bond", Country: "UK"},
+				{Name: "Jason Bourne", Country: "usa"},
+				{Name: "Ethan Hunt", Country: "FRA"},
+			},
+		}
+		body := marshal(t, newMission)
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.MissionCreate, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusCreated, response.Code)
+
+		mission := unmarshal[models.Mission](t, response.Body.Bytes())
+		require.Equal(t, 3, len(mission.Targets))
+		assert.Equal(t, "United Kingdom", mission.Targets[0].Country)
+		assert.Equal(t, "GB", mission.Targets[0].CountryCode)
+		assert.Equal(t, "United States", mission.Targets[1].Country)
+		assert.Equal(t, "US", mission.Targets[1].CountryCode)
+		assert.Equal(t, "France", mission.Targets[2].Country)
+		assert.Equal(t, "FR", mission.Targets[2].CountryCode)
+	})
 
-	server.Handler().ServeHTTP(response, request)
-	require.Equal(t, http.StatusOK, response.Code)
-	mission := unmarshal[models.Mission](t, response.Body.Bytes())
-	return mission
-}
+	t.Run("attempt to create mission with unknown country", func(t *testing.T) {
+		newMission := models.Mission{
+			Targets: []models.Target{
+				{Name: "Aquaman", Country: "Atlantis"},
+			},
+		}
+		request := newAddMissionRequest(t, newMission)
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
 
-func getCatByIDSuccessfully(t *testing.T, id int) models.Cat {
-	t.Helper()
-	request := newGetCatByIdRequest(id)
-	response := httptest.NewRecorder()
+	t.Run("attempt to add target with unknown country", func(t *testing.T) {
+		mission := models.Mission{
+			Targets: []models.Target{
+				{Name: "Jane Doe", Country: "USA"},
+			},
+		}
+		mission = addNewMissionSuccessfully(t, mission)
 
-	server.Handler().ServeHTTP(response, request)
-	require.Equal(t, http.StatusOK, response.Code)
-	cat := unmarshal[models.Cat](t, response.Body.Bytes())
-	return cat
+		newTarget := models.Target{
+			Name:    "Bilbo Baggins",
+			Country: "Middle-earth",
+		}
+		request := newAddTargetRequest(t, int(mission.Id), newTarget)
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
 }
 
-func addNewMissionSuccessfully(t *testing.T, newMission models.Mission) models.Mission {
-	t.Helper()
-	request := newAddMissionRequest(t, newMission)
-	response := httptest.NewRecorder()
-	server.Handler().ServeHTTP(response, request)
-	require.Equal(t, http.StatusCreated, response.Code)
+func TestMissionTypes(t *testing.T) {
+	t.Run("get configured mission types", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, spycatagency.Endpoints.MissionTypeGetAll, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
 
-	mission := unmarshal[models.Mission](t, response.Body.Bytes())
-	require.Equal(t, len(newMission.Targets), len(mission.Targets))
-	newMission.Id = mission.Id
-	for i := range mission.Targets {
-		newMission.Targets[i].Id = mission.Targets[i].Id
-	}
-	assertMissions(t, mission, newMission)
+		types := unmarshal[[]models.MissionType](t, response.Body.Bytes())
+		assert.Equal(t, testMissionTypes, types)
+	})
 
-	require.Equal(t, newMission, mission)
-	return mission
-}
+	t.Run("attempt to create mission of unknown type", func(t *testing.T) {
+		mission := models.Mission{
+			Type:    "assassination",
+			Targets: []models.Target{{Name: "John Wick", Country: "USA"}},
+		}
+		request := newAddMissionRequest(t, mission)
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
 
-func completeTargetSuccessfully(t *testing.T, missionId, targetId int64) models.Mission {
-	t.Helper()
-	request := newCompleteTargetRequest(int(missionId), int(targetId))
-	response := httptest.NewRecorder()
-	server.Handler().ServeHTTP(response, request)
-	require.Equal(t, http.StatusOK, response.Code)
+	t.Run("attempt to create mission with fewer targets than type requires", func(t *testing.T) {
+		mission := models.Mission{
+			Type:    "sabotage",
+			Targets: []models.Target{{Name: "Death Star", Country: "USA"}},
+		}
+		request := newAddMissionRequest(t, mission)
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
 
-	mission := getMissionByIdSuccessfully(t, int(missionId))
-	return mission
-}
+	t.Run("add targets up to the type limit", func(t *testing.T) {
+		mission := models.Mission{
+			Type: "sabotage",
+			Targets: []models.Target{
+				{Name: "Power plant", Country: "Germany"},
+				{Name: "Bridge", Country: "Germany"},
+				{Name: "Dam", Country: "Germany"},
+			},
+		}
+		mission = addNewMissionSuccessfully(t, mission)
 
-func addNewCatSuccessfully(t *testing.T, cat models.Cat) models.Cat {
-	t.Helper()
-	body := marshal(t, cat)
-	request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.CatCreate, bytes.NewReader(body))
-	response := httptest.NewRecorder()
+		request := newAddTargetRequest(t, int(mission.Id), models.Target{Name: "Railway", Country: "Germany"})
+		doRequestAndExpect(t, request, http.StatusOK)
 
-	server.Handler().ServeHTTP(response, request)
-	require.Equal(t, http.StatusCreated, response.Code)
+		request = newAddTargetRequest(t, int(mission.Id), models.Target{Name: "Airport", Country: "Germany"})
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
 
-	persistedCat := unmarshal[models.Cat](t, response.Body.Bytes())
-	cat.Id = persistedCat.Id
-	require.Equal(t, cat, persistedCat)
-	return persistedCat
-}
+	t.Run("attempt to delete target below the type minimum", func(t *testing.T) {
+		mission := models.Mission{
+			Type: "sabotage",
+			Targets: []models.Target{
+				{Name: "Factory", Country: "Spain"},
+				{Name: "Warehouse", Country: "Spain"},
+			},
+		}
+		mission = addNewMissionSuccessfully(t, mission)
 
-func assignMissionSuccessfully(t *testing.T, mission models.Mission, cat models.Cat) models.Mission {
-	t.Helper()
-	request := newAssignMissionRequest(int(mission.Id), int(cat.Id))
-	response := httptest.NewRecorder()
-	server.Handler().ServeHTTP(response, request)
-	require.Equal(t, http.StatusOK, response.Code)
+		request := newDeleteTargetRequest(int(mission.Id), int(mission.Targets[0].Id))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
 
-	mission = getMissionByIdSuccessfully(t, int(mission.Id))
-	require.Equal(t, mission.CatId, cat.Id)
-	return mission
-}
+	t.Run("attempt to assign cat without required experience", func(t *testing.T) {
+		cat := models.Cat{
+			Name:              "Kitten",
+			Breed:             "abys",
+			YearsOfExperience: 1,
+			Salary:            100,
+		}
+		mission := models.Mission{
+			Type:    "extraction",
+			Targets: []models.Target{{Name: "Hostage", Country: "Mexico"}},
+		}
+		cat = addNewCatSuccessfully(t, cat)
+		mission = addNewMissionSuccessfully(t, mission)
 
-func completeMissionSuccessfully(t *testing.T, mission models.Mission) models.Mission {
-	t.Helper()
-	request := newCompleteMissionRequest(int(mission.Id))
-	response := httptest.NewRecorder()
+		request := newAssignMissionRequest(int(mission.Id), int(cat.Id))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
 
-	server.Handler().ServeHTTP(response, request)
-	require.Equal(t, http.StatusOK, response.Code)
+	t.Run("mission of removed type follows the default type", func(t *testing.T) {
+		mission := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Embassy", Country: "Italy"}}})
+		_, err := cleaner.db.Exec("UPDATE missions SET mission_type = 'surveillance' WHERE id = ?", mission.Id)
+		require.NoError(t, err)
 
-	cMission := unmarshal[models.Mission](t, response.Body.Bytes())
-	require.Equal(t, true, cMission.Completed)
-	mission.Completed = cMission.Completed
-	assert.Equal(t, mission, cMission)
-	return cMission
+		request := newAddTargetRequest(t, int(mission.Id), models.Target{Name: "Consulate", Country: "Italy"})
+		doRequestAndExpect(t, request, http.StatusOK)
+		request = newDeleteTargetRequest(int(mission.Id), int(mission.Targets[0].Id))
+		doRequestAndExpect(t, request, http.StatusOK)
+	})
 }
 
-func newDeleteCatRequest(id int) *http.Request {
-	url := strings.Replace(spycatagency.Endpoints.CatDelete, ":id", strconv.Itoa(id), 1)
-	request, _ := http.NewRequest(http.MethodDelete, url, nil)
-	return request
-}
+func TestMissionTemplates(t *testing.T) {
+	newTemplate := models.MissionTemplate{
+		Name: "Weekly yarn patrol",
+		Targets: []models.TemplateTarget{
+			{Name: "Yarn shop", Country: "UK", Notes: "Check the red balls"},
+			{Name: "Yarn factory", Country: "Scotland"},
+		},
+	}
 
-func newGetCatByIdRequest(id int) *http.Request {
-	url := strings.Replace(spycatagency.Endpoints.CatGet, ":id", strconv.Itoa(id), 1)
-	request, _ := http.NewRequest(http.MethodGet, url, nil)
-	return request
-}
-func newGetMissionByIdRequest(id int) *http.Request {
-	url := strings.Replace(spycatagency.Endpoints.MissionGet, ":id", strconv.Itoa(id), 1)
-	request, _ := http.NewRequest(http.MethodGet, url, nil)
-	return request
-}
+	t.Run("create template and get it by id", func(t *testing.T) {
+		template := addNewTemplateSuccessfully(t, newTemplate)
+		assert.Equal(t, "standard", template.Type)
+		assert.Equal(t, "United Kingdom", template.Targets[0].Country)
+		assert.Equal(t, "GB", template.Targets[1].CountryCode)
 
-func newAssignMissionRequest(missionId, catId int) *http.Request {
-	url := strings.Replace(spycatagency.Endpoints.MissionAssign, ":id", strconv.Itoa(missionId), 1)
-	url = strings.Replace(url, ":catId", strconv.Itoa(catId), 1)
-	request, _ := http.NewRequest(http.MethodPost, url, nil)
-	return request
-}
+		url := strings.Replace(spycatagency.Endpoints.TemplateGet, ":id", strconv.Itoa(int(template.Id)), 1)
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, template, unmarshal[models.MissionTemplate](t, response.Body.Bytes()))
+	})
 
-func newAddMissionRequest(t *testing.T, mission models.Mission) *http.Request {
-	t.Helper()
-	body := marshal(t, mission)
-	request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.MissionCreate, bytes.NewReader(body))
-	return request
-}
+	t.Run("attempt to create template with too many targets for its type", func(t *testing.T) {
+		template := models.MissionTemplate{
+			Name: "Greedy extraction",
+			Type: "extraction",
+			Targets: []models.TemplateTarget{
+				{Name: "Hostage 1", Country: "Mexico"},
+				{Name: "Hostage 2", Country: "Mexico"},
+			},
+		}
+		body := marshal(t, template)
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.TemplateCreate, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
 
-func newCompleteTargetRequest(missionId, targetId int) *http.Request {
+	t.Run("update template", func(t *testing.T) {
+		template := addNewTemplateSuccessfully(t, newTemplate)
+		update := models.MissionTemplate{
+			Name:    "Monthly yarn patrol",
+			Targets: []models.TemplateTarget{{Name: "Yarn market", Country: "Ireland"}},
+		}
+		url := strings.Replace(spycatagency.Endpoints.TemplateUpdate, ":id", strconv.Itoa(int(template.Id)), 1)
+		request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(marshal(t, update)))
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+
+		updated := unmarshal[models.MissionTemplate](t, response.Body.Bytes())
+		assert.Equal(t, "Monthly yarn patrol", updated.Name)
+		require.Equal(t, 1, len(updated.Targets))
+		assert.Equal(t, "IE", updated.Targets[0].CountryCode)
+	})
+
+	t.Run("delete template", func(t *testing.T) {
+		template := addNewTemplateSuccessfully(t, newTemplate)
+		url := strings.Replace(spycatagency.Endpoints.TemplateDelete, ":id", strconv.Itoa(int(template.Id)), 1)
+		request, _ := http.NewRequest(http.MethodDelete, url, nil)
+		doRequestAndExpect(t, request, http.StatusOK)
+
+		url = strings.Replace(spycatagency.Endpoints.TemplateGet, ":id", strconv.Itoa(int(template.Id)), 1)
+		request, _ = http.NewRequest(http.MethodGet, url, nil)
+		doRequestAndExpect(t, request, http.StatusNotFound)
+	})
+
+	t.Run("create mission from template", func(t *testing.T) {
+		template := addNewTemplateSuccessfully(t, newTemplate)
+		url := strings.Replace(spycatagency.Endpoints.MissionFromTemplate, ":templateId", strconv.Itoa(int(template.Id)), 1)
+		request, _ := http.NewRequest(http.MethodPost, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusCreated, response.Code)
+
+		mission := unmarshal[models.Mission](t, response.Body.Bytes())
+		assert.Equal(t, template.Type, mission.Type)
+		require.Equal(t, len(template.Targets), len(mission.Targets))
+		for i := range template.Targets {
+			assert.Equal(t, template.Targets[i].Name, mission.Targets[i].Name)
+			assert.Equal(t, template.Targets[i].Country, mission.Targets[i].Country)
+			assert.Equal(t, template.Targets[i].Notes, mission.Targets[i].Notes)
+		}
+	})
+
+	t.Run("attempt to create mission from non existing template", func(t *testing.T) {
+		url := strings.Replace(spycatagency.Endpoints.MissionFromTemplate, ":templateId", strconv.Itoa(math.MaxInt64), 1)
+		request, _ := http.NewRequest(http.MethodPost, url, nil)
+		doRequestAndExpect(t, request, http.StatusNotFound)
+	})
+}
+
+func TestCloneMission(t *testing.T) {
+	t.Run("clone completed mission", func(t *testing.T) {
+		cat := models.Cat{
+			Name:              "Copycat",
+			Breed:             "abys",
+			YearsOfExperience: 4,
+			Salary:            1200,
+		}
+		mission := models.Mission{
+			Targets: []models.Target{
+				{Name: "Mouse", Country: "Canada", Notes: "Hides under the fridge"},
+			},
+		}
+		cat = addNewCatSuccessfully(t, cat)
+		mission = addNewMissionSuccessfully(t, mission)
+		mission = assignMissionSuccessfully(t, mission, cat)
+		mission = completeTargetSuccessfully(t, mission.Id, mission.Targets[0].Id)
+		mission = completeMissionSuccessfully(t, mission)
+
+		url := strings.Replace(spycatagency.Endpoints.MissionClone, ":id", strconv.Itoa(int(mission.Id)), 1)
+		request, _ := http.NewRequest(http.MethodPost, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusCreated, response.Code)
+
+		clone := unmarshal[models.Mission](t, response.Body.Bytes())
+		assert.NotEqual(t, mission.Id, clone.Id)
+		assert.Equal(t, int64(0), clone.CatId)
+		assert.False(t, clone.Completed)
+		require.Equal(t, 1, len(clone.Targets))
+		assert.False(t, clone.Targets[0].Completed)
+		assert.Equal(t, mission.Targets[0].Name, clone.Targets[0].Name)
+		assert.Equal(t, mission.Targets[0].Notes, clone.Targets[0].Notes)
+	})
+
+	t.Run("attempt to clone non existing mission", func(t *testing.T) {
+		url := strings.Replace(spycatagency.Endpoints.MissionClone, ":id", strconv.Itoa(math.MaxInt64), 1)
+		request, _ := http.NewRequest(http.MethodPost, url, nil)
+		doRequestAndExpect(t, request, http.StatusNotFound)
+	})
+}
+
+func TestSkills(t *testing.T) {
+	t.Run("add skill to the catalog", func(t *testing.T) {
+		skill := models.Skill{Name: "climbing", Description: "Getting on top of anything"}
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.SkillCreate, bytes.NewReader(marshal(t, skill)))
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusCreated, response.Code)
+
+		request, _ = http.NewRequest(http.MethodPost, spycatagency.Endpoints.SkillCreate, bytes.NewReader(marshal(t, skill)))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+
+	t.Run("set cat skills", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Houdini", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		skills := []models.CatSkill{{Skill: "lockpicking", Proficiency: 5}, {Skill: "disguise", Proficiency: 2}}
+		saved := setCatSkillsSuccessfully(t, cat.Id, skills)
+		assert.Equal(t, []models.CatSkill{{Skill: "disguise", Proficiency: 2}, {Skill: "lockpicking", Proficiency: 5}}, saved)
+
+		url := strings.Replace(spycatagency.Endpoints.CatSkillsUpdate, ":id", strconv.Itoa(int(cat.Id)), 1)
+		body := marshal(t, []models.CatSkill{{Skill: "teleportation", Proficiency: 5}})
+		request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		body = marshal(t, []models.CatSkill{{Skill: "hacking", Proficiency: 6}})
+		request, _ = http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+
+	t.Run("filter cats by skill", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Polyglot", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		setCatSkillsSuccessfully(t, cat.Id, []models.CatSkill{{Skill: "languages", Proficiency: 3}})
+
+		request, _ := http.NewRequest(http.MethodGet, spycatagency.Endpoints.CatGetAll+"?skill=languages", nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+
+		pc := unmarshal[models.PaginatedCats](t, response.Body.Bytes())
+		assert.Contains(t, pc.Cats, cat)
+		for _, c := range pc.Cats {
+			skills := getCatSkills(t, c.Id)
+			assert.Contains(t, skills, models.CatSkill{Skill: "languages", Proficiency: skillProficiency(skills, "languages")})
+		}
+	})
+
+	t.Run("assign requires target skills unless overridden", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Clumsy", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		setCatSkillsSuccessfully(t, cat.Id, []models.CatSkill{{Skill: "lockpicking", Proficiency: 1}})
+		mission := models.Mission{
+			Targets: []models.Target{{
+				Name:           "Bank vault",
+				Country:        "Switzerland",
+				RequiredSkills: []models.SkillRequirement{{Skill: "lockpicking", MinProficiency: 4}},
+			}},
+		}
+		mission = addNewMissionSuccessfully(t, mission)
+
+		request := newAssignMissionRequest(int(mission.Id), int(cat.Id))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		request = newAssignMissionRequest(int(mission.Id), int(cat.Id))
+		request.URL.RawQuery = "override=true"
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		result := unmarshal[models.AssignResult](t, response.Body.Bytes())
+		assert.Equal(t, 1, len(result.Warnings))
+	})
+
+	t.Run("attempt to create target requiring unknown skill", func(t *testing.T) {
+		mission := models.Mission{
+			Targets: []models.Target{{
+				Name:           "Moon base",
+				Country:        "USA",
+				RequiredSkills: []models.SkillRequirement{{Skill: "flying"}},
+			}},
+		}
+		request := newAddMissionRequest(t, mission)
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+}
+
+func TestAbsences(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("manage absences of the cat", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Sleepy", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		absence := addAbsenceSuccessfully(t, cat.Id, models.Absence{Kind: "leave", From: now.AddDate(0, 0, 1), To: now.AddDate(0, 0, 8)})
+		assert.Equal(t, cat.Id, absence.CatId)
+
+		absence.Kind = "medical"
+		absence.Note = "vet appointment"
+		url := strings.Replace(spycatagency.Endpoints.CatAbsenceUpdate, ":id", strconv.Itoa(int(cat.Id)), 1)
+		url = strings.Replace(url, ":absenceId", strconv.Itoa(int(absence.Id)), 1)
+		request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(marshal(t, absence)))
+		doRequestAndExpect(t, request, http.StatusOK)
+
+		absences := getAbsences(t, cat.Id)
+		require.Equal(t, 1, len(absences))
+		assert.Equal(t, absence, absences[0])
+
+		request, _ = http.NewRequest(http.MethodDelete, url, nil)
+		doRequestAndExpect(t, request, http.StatusOK)
+		assert.Empty(t, getAbsences(t, cat.Id))
+	})
+
+	t.Run("attempt to add absence that ends before it starts", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Confused", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		absence := models.Absence{Kind: "training", From: now.AddDate(0, 0, 2), To: now.AddDate(0, 0, 1)}
+		url := strings.Replace(spycatagency.Endpoints.CatAbsenceCreate, ":id", strconv.Itoa(int(cat.Id)), 1)
+		request, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(marshal(t, absence)))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+
+	t.Run("attempt to change absence of another cat", func(t *testing.T) {
+		owner := addNewCatSuccessfully(t, models.Cat{Name: "Owner", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		other := addNewCatSuccessfully(t, models.Cat{Name: "Other", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		absence := addAbsenceSuccessfully(t, owner.Id, models.Absence{Kind: "leave", From: now, To: now.AddDate(0, 0, 1)})
+
+		url := strings.Replace(spycatagency.Endpoints.CatAbsenceDelete, ":id", strconv.Itoa(int(other.Id)), 1)
+		url = strings.Replace(url, ":absenceId", strconv.Itoa(int(absence.Id)), 1)
+		request, _ := http.NewRequest(http.MethodDelete, url, nil)
+		doRequestAndExpect(t, request, http.StatusNotFound)
+	})
+
+	t.Run("assign cat on leave", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Vacationer", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		addAbsenceSuccessfully(t, cat.Id, models.Absence{Kind: "leave", From: now.AddDate(0, 0, 10), To: now.AddDate(0, 0, 20)})
+
+		startsAt, endsAt := now.AddDate(0, 0, 15), now.AddDate(0, 0, 25)
+		mission := addNewMissionSuccessfully(t, models.Mission{
+			StartsAt: &startsAt,
+			EndsAt:   &endsAt,
+			Targets:  []models.Target{{Name: "Beach house", Country: "Spain"}},
+		})
+		request := newAssignMissionRequest(int(mission.Id), int(cat.Id))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		// mission that ends before the leave doesn't conflict with it
+		startsAt, endsAt = now.AddDate(0, 0, 1), now.AddDate(0, 0, 10)
+		mission = addNewMissionSuccessfully(t, models.Mission{
+			StartsAt: &startsAt,
+			EndsAt:   &endsAt,
+			Targets:  []models.Target{{Name: "Mountain hut", Country: "Austria"}},
+		})
+		request = newAssignMissionRequest(int(mission.Id), int(cat.Id))
+		doRequestAndExpect(t, request, http.StatusOK)
+	})
+
+	t.Run("assign cat to missions in separate windows", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Planner", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		startsAt, endsAt := now.AddDate(0, 0, 1), now.AddDate(0, 0, 5)
+		first := addNewMissionSuccessfully(t, models.Mission{
+			StartsAt: &startsAt,
+			EndsAt:   &endsAt,
+			Targets:  []models.Target{{Name: "Harbour", Country: "Spain"}},
+		})
+		assignMissionSuccessfully(t, first, cat)
+
+		startsAt, endsAt = now.AddDate(0, 0, 4), now.AddDate(0, 0, 8)
+		overlapping := addNewMissionSuccessfully(t, models.Mission{
+			StartsAt: &startsAt,
+			EndsAt:   &endsAt,
+			Targets:  []models.Target{{Name: "Lighthouse", Country: "Spain"}},
+		})
+		request := newAssignMissionRequest(int(overlapping.Id), int(cat.Id))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		startsAt, endsAt = now.AddDate(0, 0, 5), now.AddDate(0, 0, 8)
+		later := addNewMissionSuccessfully(t, models.Mission{
+			StartsAt: &startsAt,
+			EndsAt:   &endsAt,
+			Targets:  []models.Target{{Name: "Pier", Country: "Spain"}},
+		})
+		assignMissionSuccessfully(t, later, cat)
+
+		// mission without window lasts until it's completed
+		unbounded := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Castle", Country: "Spain"}}})
+		request = newAssignMissionRequest(int(unbounded.Id), int(cat.Id))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+
+	t.Run("move mission window", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Mover", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		addAbsenceSuccessfully(t, cat.Id, models.Absence{Kind: "leave", From: now.AddDate(0, 0, 10), To: now.AddDate(0, 0, 20)})
+		startsAt, endsAt := now.AddDate(0, 0, 1), now.AddDate(0, 0, 5)
+		mission := addNewMissionSuccessfully(t, models.Mission{
+			StartsAt: &startsAt,
+			EndsAt:   &endsAt,
+			Targets:  []models.Target{{Name: "Market", Country: "Spain"}},
+		})
+		assignMissionSuccessfully(t, mission, cat)
+
+		url := strings.Replace(spycatagency.Endpoints.MissionWindow, ":id", strconv.Itoa(int(mission.Id)), 1)
+		startsAt, endsAt = now.AddDate(0, 0, 15), now.AddDate(0, 0, 25)
+		body := marshal(t, models.MissionWindow{StartsAt: &startsAt, EndsAt: &endsAt})
+		request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		startsAt, endsAt = now.AddDate(0, 0, 2), now.AddDate(0, 0, 1)
+		body = marshal(t, models.MissionWindow{StartsAt: &startsAt, EndsAt: &endsAt})
+		request, _ = http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		startsAt, endsAt = now.AddDate(0, 0, 2), now.AddDate(0, 0, 9)
+		body = marshal(t, models.MissionWindow{StartsAt: &startsAt, EndsAt: &endsAt})
+		request, _ = http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		updated := unmarshal[models.Mission](t, response.Body.Bytes())
+		assert.Equal(t, startsAt, *updated.StartsAt)
+		assert.Equal(t, endsAt, *updated.EndsAt)
+		assert.Equal(t, cat.Id, updated.CatId)
+	})
+
+	t.Run("get available cats", func(t *testing.T) {
+		free := addNewCatSuccessfully(t, models.Cat{Name: "Free", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		training := addNewCatSuccessfully(t, models.Cat{Name: "Student", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		busy := addNewCatSuccessfully(t, models.Cat{Name: "Busy", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		from := time.Date(now.Year()+1, time.March, 1, 0, 0, 0, 0, time.UTC)
+		addAbsenceSuccessfully(t, training.Id, models.Absence{Kind: "training", From: from.AddDate(0, 0, 3), To: from.AddDate(0, 0, 5)})
+		startsAt, endsAt := from.AddDate(0, 0, 4), from.AddDate(0, 0, 6)
+		mission := addNewMissionSuccessfully(t, models.Mission{
+			StartsAt: &startsAt,
+			EndsAt:   &endsAt,
+			Targets:  []models.Target{{Name: "Museum", Country: "Spain"}},
+		})
+		assignMissionSuccessfully(t, mission, busy)
+
+		url := fmt.Sprintf("%s?from=%s&to=%s", spycatagency.Endpoints.CatAvailable,
+			from.Format(time.DateOnly), from.AddDate(0, 0, 6).Format(time.DateOnly))
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		cats := unmarshal[[]models.Cat](t, response.Body.Bytes())
+		assert.Contains(t, cats, free)
+		assert.NotContains(t, cats, training)
+		assert.NotContains(t, cats, busy)
+
+		url = fmt.Sprintf("%s?from=%s&to=%s", spycatagency.Endpoints.CatAvailable,
+			from.Format(time.DateOnly), from.AddDate(0, 0, 2).Format(time.DateOnly))
+		request, _ = http.NewRequest(http.MethodGet, url, nil)
+		response = httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		cats = unmarshal[[]models.Cat](t, response.Body.Bytes())
+		assert.Contains(t, cats, training)
+		assert.Contains(t, cats, busy)
+	})
+}
+
+func TestCatStatus(t *testing.T) {
+	t.Run("recruit becomes active and retires", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Rookie", Breed: "abys", YearsOfExperience: 4, Salary: 1000, Status: models.CatStatusRecruit})
+		mission := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Training ground", Country: "Spain"}}})
+		request := newAssignMissionRequest(int(mission.Id), int(cat.Id))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		cat = updateStatusSuccessfully(t, cat.Id, models.StatusUpdate{Status: models.CatStatusActive, Reason: "graduated"})
+		assert.Equal(t, models.CatStatusActive, cat.Status)
+		mission = assignMissionSuccessfully(t, mission, cat)
+
+		// busy cat can't retire
+		url := strings.Replace(spycatagency.Endpoints.CatStatusUpdate, ":id", strconv.Itoa(int(cat.Id)), 1)
+		body := marshal(t, models.StatusUpdate{Status: models.CatStatusRetired})
+		request, _ = http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		mission = completeTargetSuccessfully(t, mission.Id, mission.Targets[0].Id)
+		completeMissionSuccessfully(t, mission)
+		cat = updateStatusSuccessfully(t, cat.Id, models.StatusUpdate{Status: models.CatStatusRetired, Reason: "well deserved rest"})
+		assert.Equal(t, models.CatStatusRetired, cat.Status)
+
+		// retired cat keeps its history
+		request = newDeleteCatRequest(int(cat.Id))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+		assert.Equal(t, cat.Id, getMissionByIdSuccessfully(t, int(mission.Id)).CatId)
+
+		url = strings.Replace(spycatagency.Endpoints.CatStatusHistory, ":id", strconv.Itoa(int(cat.Id)), 1)
+		request, _ = http.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		history := unmarshal[[]models.StatusChange](t, response.Body.Bytes())
+		require.Equal(t, 3, len(history))
+		assert.Equal(t, models.CatStatusRecruit, history[0].Status)
+		assert.Equal(t, models.CatStatusActive, history[1].Status)
+		assert.Equal(t, "graduated", history[1].Reason)
+		assert.Equal(t, models.CatStatusRetired, history[2].Status)
+	})
+
+	t.Run("deceased cat releases its mission", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Unlucky", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		mission := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Volcano lair", Country: "Iceland"}}})
+		assignMissionSuccessfully(t, mission, cat)
+
+		updateStatusSuccessfully(t, cat.Id, models.StatusUpdate{Status: models.CatStatusDeceased})
+		assert.Equal(t, int64(0), getMissionByIdSuccessfully(t, int(mission.Id)).CatId)
+
+		// released mission keeps the cat in its history
+		request := newDeleteMissionRequest(int(mission.Id))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+		var assignments int
+		err := cleaner.db.QueryRow("SELECT COUNT(*) FROM mission_assignments WHERE mission_id = ? AND cat_id = ? AND released_at IS NOT NULL",
+			mission.Id, cat.Id).Scan(&assignments)
+		require.NoError(t, err)
+		assert.Equal(t, 1, assignments)
+
+		replacement := addNewCatSuccessfully(t, models.Cat{Name: "Lucky", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		assignMissionSuccessfully(t, mission, replacement)
+	})
+
+	t.Run("cat that completed a mission released by another cat keeps its history", func(t *testing.T) {
+		fallen := addNewCatSuccessfully(t, models.Cat{Name: "Fallen", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		mission := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Casino", Country: "Monaco"}}})
+		assignMissionSuccessfully(t, mission, fallen)
+		updateStatusSuccessfully(t, fallen.Id, models.StatusUpdate{Status: models.CatStatusDeceased})
+
+		finisher := addNewCatSuccessfully(t, models.Cat{Name: "Finisher", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		completeNewMissionWith(t, getMissionByIdSuccessfully(t, int(mission.Id)), finisher)
+
+		doRequestAndExpect(t, newDeleteCatRequest(int(finisher.Id)), http.StatusBadRequest)
+		assert.Equal(t, finisher.Id, getMissionByIdSuccessfully(t, int(mission.Id)).CatId)
+		missions := getCatMissionsSuccessfully(t, fallen.Id, "")
+		require.Equal(t, 1, len(missions.Missions))
+		assert.Equal(t, mission.Id, missions.Missions[0].Id)
+	})
+
+	t.Run("attempt forbidden transitions", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Ghost", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		updateStatusSuccessfully(t, cat.Id, models.StatusUpdate{Status: models.CatStatusDeceased})
+
+		url := strings.Replace(spycatagency.Endpoints.CatStatusUpdate, ":id", strconv.Itoa(int(cat.Id)), 1)
+		body := marshal(t, models.StatusUpdate{Status: models.CatStatusActive})
+		request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		future := time.Now().AddDate(0, 0, 1)
+		cat = addNewCatSuccessfully(t, models.Cat{Name: "Planner", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		url = strings.Replace(spycatagency.Endpoints.CatStatusUpdate, ":id", strconv.Itoa(int(cat.Id)), 1)
+		body = marshal(t, models.StatusUpdate{Status: models.CatStatusSuspended, EffectiveFrom: &future})
+		request, _ = http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+
+	t.Run("filter cats by status", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Naughty", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		cat = updateStatusSuccessfully(t, cat.Id, models.StatusUpdate{Status: models.CatStatusSuspended})
+
+		request, _ := http.NewRequest(http.MethodGet, spycatagency.Endpoints.CatGetAll+"?status=suspended", nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		pc := unmarshal[models.PaginatedCats](t, response.Body.Bytes())
+		assert.Contains(t, pc.Cats, cat)
+		for _, c := range pc.Cats {
+			assert.Equal(t, models.CatStatusSuspended, c.Status)
+		}
+	})
+}
+
+func TestCatStats(t *testing.T) {
+	cat := addNewCatSuccessfully(t, models.Cat{Name: "Achiever", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+
+	t.Run("stats of a cat without missions", func(t *testing.T) {
+		stats := getCatStatsSuccessfully(t, cat.Id)
+		assert.Equal(t, models.CatStats{CatId: cat.Id, Name: cat.Name}, stats)
+	})
+
+	t.Run("stats of a cat with missions", func(t *testing.T) {
+		mission := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{
+			{Name: "Bank", Country: "France"},
+			{Name: "Museum", Country: "France"},
+		}})
+		mission = assignMissionSuccessfully(t, mission, cat)
+		completeTargetSuccessfully(t, mission.Id, mission.Targets[0].Id)
+		mission = completeTargetSuccessfully(t, mission.Id, mission.Targets[1].Id)
+		completeMissionSuccessfully(t, mission)
+
+		mission = addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Casino", Country: "Monaco"}}})
+		assignMissionSuccessfully(t, mission, cat)
+
+		stats := getCatStatsSuccessfully(t, cat.Id)
+		assert.Equal(t, 2, stats.AssignedMissions)
+		assert.Equal(t, 1, stats.CompletedMissions)
+		assert.Equal(t, 2, stats.CompletedTargets)
+		// open mission isn't finished yet
+		assert.Equal(t, 1.0, stats.SuccessRate)
+		assert.GreaterOrEqual(t, stats.AverageMissionHours, 0.0)
+		assert.GreaterOrEqual(t, stats.CostPerCompletedMission, 0.0)
+	})
+
+	t.Run("released mission lowers success rate", func(t *testing.T) {
+		unlucky := addNewCatSuccessfully(t, models.Cat{Name: "Dropout", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		mission := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Vault", Country: "France"}}})
+		mission = assignMissionSuccessfully(t, mission, unlucky)
+		mission = completeTargetSuccessfully(t, mission.Id, mission.Targets[0].Id)
+		completeMissionSuccessfully(t, mission)
+
+		mission = addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Palace", Country: "Monaco"}}})
+		assignMissionSuccessfully(t, mission, unlucky)
+		updateStatusSuccessfully(t, unlucky.Id, models.StatusUpdate{Status: models.CatStatusDeceased})
+
+		stats := getCatStatsSuccessfully(t, unlucky.Id)
+		assert.Equal(t, 2, stats.AssignedMissions)
+		assert.Equal(t, 1, stats.CompletedMissions)
+		assert.Equal(t, 0.5, stats.SuccessRate)
+	})
+
+	t.Run("leaderboard is ranked", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, spycatagency.Endpoints.CatLeaderboard+"?limit=5", nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+
+		leaderboard := unmarshal[[]models.CatStats](t, response.Body.Bytes())
+		require.LessOrEqual(t, len(leaderboard), 5)
+		for i, s := range leaderboard {
+			assert.Equal(t, i+1, s.Rank)
+			if i > 0 {
+				assert.LessOrEqual(t, s.CompletedMissions, leaderboard[i-1].CompletedMissions)
+			}
+		}
+	})
+
+	t.Run("stats of non existing cat", func(t *testing.T) {
+		url := strings.Replace(spycatagency.Endpoints.CatStats, ":id", strconv.Itoa(math.MaxInt32), 1)
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		doRequestAndExpect(t, request, http.StatusNotFound)
+	})
+}
+
+func TestPromotions(t *testing.T) {
+	t.Run("cat is promoted when it completes enough missions", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Climber", Breed: "abys", YearsOfExperience: 3, Salary: 1000})
+		assert.Equal(t, "cadet", cat.Rank)
+
+		completeNewMission(t, cat)
+		assert.Equal(t, "cadet", getCatByIDSuccessfully(t, int(cat.Id)).Rank)
+
+		completeNewMission(t, cat)
+		promoted := getCatByIDSuccessfully(t, int(cat.Id))
+		assert.Equal(t, "field agent", promoted.Rank)
+		assert.Equal(t, 1500, promoted.Salary)
+
+		promotions := getPromotionsSuccessfully(t, cat.Id)
+		require.Equal(t, 1, len(promotions))
+		assert.Equal(t, "cadet", promotions[0].FromRank)
+		assert.Equal(t, "field agent", promotions[0].ToRank)
+		assert.Equal(t, 500, promotions[0].SalaryRaise)
+		assert.False(t, promotions[0].PromotedAt.IsZero())
+	})
+
+	t.Run("promotion raises scheduled salary", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Planner", Breed: "abys", YearsOfExperience: 3, Salary: 1000})
+		now := time.Now().UTC()
+		nextMonth := time.Date(now.Year(), now.Month()+2, 1, 0, 0, 0, 0, time.UTC)
+		updateSalarySuccessfully(t, cat.Id, models.CatUpdate{Salary: 2000, EffectiveFrom: &nextMonth, Reason: "planned raise"})
+
+		completeNewMission(t, cat)
+		completeNewMission(t, cat)
+		assert.Equal(t, 1500, getCatByIDSuccessfully(t, int(cat.Id)).Salary)
+
+		url := strings.Replace(spycatagency.Endpoints.CatSalaryHistory, ":id", strconv.Itoa(int(cat.Id)), 1)
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		history := unmarshal[[]models.SalaryChange](t, response.Body.Bytes())
+		require.Equal(t, 3, len(history))
+		assert.Equal(t, 1500, history[1].Salary)
+		assert.Equal(t, 2500, history[2].Salary)
+		assert.True(t, nextMonth.Equal(history[2].EffectiveFrom))
+	})
+
+	t.Run("inexperienced cat is not promoted", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Newbie", Breed: "abys", YearsOfExperience: 1, Salary: 1000})
+		completeNewMission(t, cat)
+		completeNewMission(t, cat)
+		assert.Equal(t, cat, getCatByIDSuccessfully(t, int(cat.Id)))
+		assert.Empty(t, getPromotionsSuccessfully(t, cat.Id))
+	})
+
+	t.Run("experience alone is not enough for a promotion", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Veteran", Breed: "abys", YearsOfExperience: 20, Salary: 1000})
+		assert.Equal(t, "cadet", cat.Rank)
+	})
+}
+
+func TestLedger(t *testing.T) {
+	t.Run("completed mission posts a bonus", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Earner", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		mission := addNewMissionSuccessfully(t, models.Mission{
+			Type:    "extraction",
+			Targets: []models.Target{{Name: "Embassy", Country: "Germany"}},
+		})
+		assert.Equal(t, intPtr(2000), mission.Bonus)
+		mission = assignMissionSuccessfully(t, mission, cat)
+		mission = completeTargetSuccessfully(t, mission.Id, mission.Targets[0].Id)
+		completeMissionSuccessfully(t, mission)
+
+		custom := addNewMissionSuccessfully(t, models.Mission{Bonus: intPtr(300), Targets: []models.Target{{Name: "Office", Country: "Italy"}}})
+		completeNewMissionWith(t, custom, cat)
+
+		addLedgerEntrySuccessfully(t, cat.Id, models.LedgerEntry{Kind: models.LedgerSalary, Amount: 1000, Description: "monthly salary"})
+		addLedgerEntrySuccessfully(t, cat.Id, models.LedgerEntry{Kind: models.LedgerAdjustment, Amount: -200, Description: "broken vase"})
+
+		ledger := getLedgerSuccessfully(t, cat.Id)
+		require.Equal(t, 4, len(ledger.Entries))
+		assert.Equal(t, models.LedgerBonus, ledger.Entries[0].Kind)
+		assert.Equal(t, mission.Id, ledger.Entries[0].MissionId)
+		balances := []int{2000, 2300, 3300, 3100}
+		for i, e := range ledger.Entries {
+			assert.Equal(t, balances[i], e.Balance)
+		}
+		assert.Equal(t, 3100, ledger.Balance)
+	})
+
+	t.Run("mission without bonus posts nothing", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Volunteer", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		completeNewMission(t, cat)
+		ledger := getLedgerSuccessfully(t, cat.Id)
+		assert.Empty(t, ledger.Entries)
+		assert.Equal(t, 0, ledger.Balance)
+	})
+
+	t.Run("explicit zero bonus overrides the mission type", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Patriot", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		mission := addNewMissionSuccessfully(t, models.Mission{
+			Type:    "extraction",
+			Bonus:   intPtr(0),
+			Targets: []models.Target{{Name: "Embassy", Country: "Germany"}},
+		})
+		assert.Equal(t, intPtr(0), mission.Bonus)
+		completeNewMissionWith(t, mission, cat)
+		ledger := getLedgerSuccessfully(t, cat.Id)
+		assert.Empty(t, ledger.Entries)
+	})
+
+	t.Run("attempt to post a bonus manually", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Greedy", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		url := strings.Replace(spycatagency.Endpoints.CatLedgerCreate, ":id", strconv.Itoa(int(cat.Id)), 1)
+		body := marshal(t, models.LedgerEntry{Kind: models.LedgerBonus, Amount: 1000000})
+		request, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+}
+
+func TestHandlers(t *testing.T) {
+	t.Run("handler runs cats and their missions", func(t *testing.T) {
+		handler := addHandlerSuccessfully(t, models.Handler{Name: "Jane Doe", Email: "jane@agency.org"})
+		other := addHandlerSuccessfully(t, models.Handler{Name: "John Roe"})
+
+		owned := addNewCatSuccessfully(t, models.Cat{Name: "Loyal", Breed: "abys", YearsOfExperience: 4, Salary: 1000, HandlerId: handler.Id})
+		moved := addNewCatSuccessfully(t, models.Cat{Name: "Drifter", Breed: "abys", YearsOfExperience: 4, Salary: 1000, HandlerId: other.Id})
+		assert.Equal(t, handler.Id, owned.HandlerId)
+
+		cat := transferCatSuccessfully(t, moved.Id, handler.Id)
+		assert.Equal(t, handler.Id, cat.HandlerId)
+
+		mission := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{
+			{Name: "Harbour", Country: "Spain"},
+			{Name: "Lighthouse", Country: "Spain"},
+		}})
+		mission = assignMissionSuccessfully(t, mission, owned)
+		completeTargetSuccessfully(t, mission.Id, mission.Targets[0].Id)
+		completeNewMission(t, cat)
+
+		url := strings.Replace(spycatagency.Endpoints.HandlerCats, ":id", strconv.Itoa(int(handler.Id)), 1)
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		cats := unmarshal[models.PaginatedCats](t, response.Body.Bytes())
+		assert.Equal(t, 2, cats.Meta.Total)
+
+		url = strings.Replace(spycatagency.Endpoints.HandlerMissions, ":id", strconv.Itoa(int(handler.Id)), 1)
+		request, _ = http.NewRequest(http.MethodGet, url, nil)
+		response = httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		missions := unmarshal[models.PaginatedMissions](t, response.Body.Bytes())
+		assert.Equal(t, 2, missions.Meta.Total)
+
+		url = strings.Replace(spycatagency.Endpoints.HandlerDashboard, ":id", strconv.Itoa(int(handler.Id)), 1)
+		request, _ = http.NewRequest(http.MethodGet, url, nil)
+		response = httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		dashboard := unmarshal[models.HandlerDashboard](t, response.Body.Bytes())
+		assert.Equal(t, handler, dashboard.Handler)
+		assert.Equal(t, 2, dashboard.Cats)
+		assert.Equal(t, 2, dashboard.ActiveCats)
+		assert.Equal(t, 1, dashboard.BusyCats)
+		assert.Equal(t, 1, dashboard.OpenMissions)
+		assert.Equal(t, 1, dashboard.OpenTargets)
+		require.Equal(t, 1, len(dashboard.Operations))
+		assert.Equal(t, mission.Id, dashboard.Operations[0].MissionId)
+		assert.Equal(t, owned.Id, dashboard.Operations[0].CatId)
+		assert.Equal(t, 2, dashboard.Operations[0].TotalTargets)
+		assert.Equal(t, 1, dashboard.Operations[0].CompletedTargets)
+	})
+
+	t.Run("handler without cats has an empty dashboard", func(t *testing.T) {
+		handler := addHandlerSuccessfully(t, models.Handler{Name: "Newbie"})
+		url := strings.Replace(spycatagency.Endpoints.HandlerDashboard, ":id", strconv.Itoa(int(handler.Id)), 1)
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		dashboard := unmarshal[models.HandlerDashboard](t, response.Body.Bytes())
+		assert.Zero(t, dashboard.Cats)
+		assert.Empty(t, dashboard.Operations)
+	})
+
+	t.Run("finished missions stay with the handler after a transfer", func(t *testing.T) {
+		previous := addHandlerSuccessfully(t, models.Handler{Name: "Old Hand"})
+		next := addHandlerSuccessfully(t, models.Handler{Name: "New Hand"})
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Turncoat", Breed: "abys", YearsOfExperience: 4, Salary: 1000, HandlerId: previous.Id})
+		completed := completeNewMission(t, cat)
+		open := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Station", Country: "Austria"}}})
+		open = assignMissionSuccessfully(t, open, cat)
+
+		transferCatSuccessfully(t, cat.Id, next.Id)
+
+		missions := getHandlerMissionsSuccessfully(t, previous.Id)
+		require.Equal(t, 1, len(missions.Missions))
+		assert.Equal(t, completed.Id, missions.Missions[0].Id)
+		missions = getHandlerMissionsSuccessfully(t, next.Id)
+		require.Equal(t, 1, len(missions.Missions))
+		assert.Equal(t, open.Id, missions.Missions[0].Id)
+	})
+
+	t.Run("new cat without handler", func(t *testing.T) {
+		body := marshal(t, models.Cat{Name: "Loner", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.CatCreate, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+
+	t.Run("cat with unknown handler", func(t *testing.T) {
+		body := marshal(t, models.Cat{Name: "Orphan", Breed: "abys", YearsOfExperience: 4, Salary: 1000, HandlerId: 100000})
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.CatCreate, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Stray", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		url := strings.Replace(spycatagency.Endpoints.CatTransfer, ":id", strconv.Itoa(int(cat.Id)), 1)
+		request, _ = http.NewRequest(http.MethodPost, url, bytes.NewReader(marshal(t, models.CatTransfer{HandlerId: 100000})))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+
+	t.Run("get non-existing handler", func(t *testing.T) {
+		url := strings.Replace(spycatagency.Endpoints.HandlerDashboard, ":id", "100000", 1)
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		doRequestAndExpect(t, request, http.StatusNotFound)
+	})
+}
+
+func TestCatMissions(t *testing.T) {
+	cat := addNewCatSuccessfully(t, models.Cat{Name: "Veteran", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+	other := addNewCatSuccessfully(t, models.Cat{Name: "Bystander", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+	completed := completeNewMission(t, cat)
+	completeNewMission(t, other)
+	open := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{
+		{Name: "Bakery", Country: "France"},
+		{Name: "Cheese shop", Country: "France"},
+	}})
+	open = assignMissionSuccessfully(t, open, cat)
+	today := time.Now().UTC().Format("2006-01-02")
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+
+	cases := []struct {
+		name     string
+		query    string
+		expected []models.Mission
+	}{
+		{name: "all missions of the cat", query: "", expected: []models.Mission{completed, open}},
+		{name: "open missions", query: "?status=open", expected: []models.Mission{open}},
+		{name: "completed missions", query: "?status=completed", expected: []models.Mission{completed}},
+		{name: "missions still running tomorrow", query: "?from=" + tomorrow, expected: []models.Mission{open}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			missions := getCatMissionsSuccessfully(t, cat.Id, c.query)
+			assert.Equal(t, len(c.expected), missions.Meta.Total)
+			require.Equal(t, len(c.expected), len(missions.Missions))
+			for i, ms := range missions.Missions {
+				assert.Equal(t, c.expected[i].Id, ms.Id)
+				assert.Equal(t, len(c.expected[i].Targets), len(ms.Targets))
+			}
+		})
+	}
+
+	t.Run("missions the cat was released from stay in its history", func(t *testing.T) {
+		fallen := addNewCatSuccessfully(t, models.Cat{Name: "Fallen", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		done := completeNewMission(t, fallen)
+		dropped := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Museum", Country: "Belgium"}}})
+		dropped = assignMissionSuccessfully(t, dropped, fallen)
+		updateStatusSuccessfully(t, fallen.Id, models.StatusUpdate{Status: models.CatStatusDeceased})
+
+		missions := getCatMissionsSuccessfully(t, fallen.Id, "?from="+today)
+		require.Equal(t, 2, len(missions.Missions))
+		assert.Equal(t, done.Id, missions.Missions[0].Id)
+		assert.Equal(t, dropped.Id, missions.Missions[1].Id)
+		assert.Zero(t, missions.Missions[1].CatId)
+
+		missions = getCatMissionsSuccessfully(t, fallen.Id, "?status=completed")
+		require.Equal(t, 1, len(missions.Missions))
+		assert.Equal(t, done.Id, missions.Missions[0].Id)
+
+		// GraphQL shows the same history
+		query := fmt.Sprintf(`{ cat(id: %d) { missions { id } } }`, fallen.Id)
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.GraphQL, bytes.NewReader(marshal(t, map[string]string{"query": query})))
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		var result struct {
+			Data struct {
+				Cat struct {
+					Missions []struct{ Id int64 }
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &result))
+		require.Equal(t, 2, len(result.Data.Cat.Missions))
+		assert.Equal(t, done.Id, result.Data.Cat.Missions[0].Id)
+		assert.Equal(t, dropped.Id, result.Data.Cat.Missions[1].Id)
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		url := strings.Replace(spycatagency.Endpoints.CatMissions, ":id", strconv.Itoa(int(cat.Id)), 1)
+		for _, query := range []string{"?status=lost", "?from=" + tomorrow + "&to=" + today, "?from=yesterday"} {
+			request, _ := http.NewRequest(http.MethodGet, url+query, nil)
+			doRequestAndExpect(t, request, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("missions of non-existing cat", func(t *testing.T) {
+		url := strings.Replace(spycatagency.Endpoints.CatMissions, ":id", "100000", 1)
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		doRequestAndExpect(t, request, http.StatusNotFound)
+	})
+}
+
+func TestBudget(t *testing.T) {
+	limit := 1500
+	handler := addHandlerSuccessfully(t, models.Handler{Name: "Frugal", Budget: &limit})
+
+	t.Run("handler budget caps new cats and raises", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Cheap", Breed: "abys", YearsOfExperience: 4, Salary: 1000, HandlerId: handler.Id})
+
+		body := marshal(t, models.Cat{Name: "Pricey", Breed: "abys", YearsOfExperience: 4, Salary: 1000, HandlerId: handler.Id})
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.CatCreate, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		url := strings.Replace(spycatagency.Endpoints.CatUpdate, ":id", strconv.Itoa(int(cat.Id)), 1)
+		request, _ = http.NewRequest(http.MethodPut, url, bytes.NewReader(marshal(t, models.CatUpdate{Salary: 2000})))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		request, _ = http.NewRequest(http.MethodPut, url, bytes.NewReader(marshal(t, models.CatUpdate{Salary: 1500})))
+		doRequestAndExpect(t, request, http.StatusOK)
+	})
+
+	t.Run("transfer respects budget of the new handler", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Mover", Breed: "abys", YearsOfExperience: 4, Salary: 500})
+		url := strings.Replace(spycatagency.Endpoints.CatTransfer, ":id", strconv.Itoa(int(cat.Id)), 1)
+		request, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(marshal(t, models.CatTransfer{HandlerId: handler.Id})))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+
+		url = strings.Replace(spycatagency.Endpoints.HandlerBudget, ":id", strconv.Itoa(int(handler.Id)), 1)
+		request, _ = http.NewRequest(http.MethodPut, url, bytes.NewReader(marshal(t, models.HandlerBudgetUpdate{})))
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Nil(t, unmarshal[models.Handler](t, response.Body.Bytes()).Budget)
+
+		transferCatSuccessfully(t, cat.Id, handler.Id)
+	})
+
+	t.Run("promotion over budget rejects the completion", func(t *testing.T) {
+		tight := 1200
+		owner := addHandlerSuccessfully(t, models.Handler{Name: "Stingy", Budget: &tight})
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Ambitious", Breed: "abys", YearsOfExperience: 3, Salary: 1000, HandlerId: owner.Id})
+		completeNewMission(t, cat)
+
+		mission := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Target", Country: "Spain"}}})
+		mission = assignMissionSuccessfully(t, mission, cat)
+		mission = completeTargetSuccessfully(t, mission.Id, mission.Targets[0].Id)
+		doRequestAndExpect(t, newCompleteMissionRequest(int(mission.Id)), http.StatusBadRequest)
+
+		assert.False(t, getMissionByIdSuccessfully(t, int(mission.Id)).Completed)
+		assert.Equal(t, cat, getCatByIDSuccessfully(t, int(cat.Id)))
+		assert.Empty(t, getPromotionsSuccessfully(t, cat.Id))
+	})
+
+	t.Run("agency budget caps payroll", func(t *testing.T) {
+		body := marshal(t, models.Cat{Name: "Millionaire", Breed: "abys", YearsOfExperience: 4, Salary: testPayrollBudget})
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.CatCreate, bytes.NewReader(body))
+		doRequestAndExpect(t, request, http.StatusBadRequest)
+	})
+
+	t.Run("get budget", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, spycatagency.Endpoints.BudgetGet, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		budget := unmarshal[models.Budget](t, response.Body.Bytes())
+		require.NotNil(t, budget.Limit)
+		assert.Equal(t, testPayrollBudget, *budget.Limit)
+		assert.Greater(t, budget.Used, 0)
+		assert.Equal(t, testPayrollBudget-budget.Used, *budget.Remaining)
+
+		idx := slices.IndexFunc(budget.Handlers, func(h models.HandlerBudget) bool { return h.HandlerId == handler.Id })
+		require.NotEqual(t, -1, idx)
+		assert.Equal(t, 2000, budget.Handlers[idx].Used)
+		assert.Nil(t, budget.Handlers[idx].Limit)
+	})
+}
+
+func TestCatPhotos(t *testing.T) {
+	t.Run("upload photo and replace it", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Photogenic", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+
+		photo := uploadPhotoSuccessfully(t, cat.Id, "image/png", encodeTestImage(t, "png", 40, 20))
+		assert.True(t, strings.HasSuffix(photo.PhotoUrl, ".png"))
+		require.Equal(t, len(testThumbnailSizes), len(photo.Thumbnails))
+		assert.Equal(t, photo.PhotoUrl, getCatByIDSuccessfully(t, int(cat.Id)).PhotoUrl)
+
+		for i, thumb := range photo.Thumbnails {
+			assert.Equal(t, testThumbnailSizes[i], thumb.Size)
+			request, _ := http.NewRequest(http.MethodGet, thumb.Url, nil)
+			response := httptest.NewRecorder()
+			server.Handler().ServeHTTP(response, request)
+			require.Equal(t, http.StatusOK, response.Code)
+			assert.Equal(t, "image/png", response.Header().Get("Content-Type"))
+			img, err := png.Decode(response.Body)
+			require.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, thumb.Size, thumb.Size), img.Bounds())
+		}
+
+		replaced := uploadPhotoSuccessfully(t, cat.Id, "image/jpeg", encodeTestImage(t, "jpeg", 20, 40))
+		assert.True(t, strings.HasSuffix(replaced.PhotoUrl, ".jpg"))
+		request, _ := http.NewRequest(http.MethodGet, photo.PhotoUrl, nil)
+		doRequestAndExpect(t, request, http.StatusNotFound)
+
+		url := strings.Replace(spycatagency.Endpoints.CatPhotoGet, ":id", strconv.Itoa(int(cat.Id)), 1)
+		request, _ = http.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, replaced, unmarshal[models.CatPhoto](t, response.Body.Bytes()))
+	})
+
+	t.Run("reject invalid photos", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Camera shy", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		url := strings.Replace(spycatagency.Endpoints.CatPhotoUpload, ":id", strconv.Itoa(int(cat.Id)), 1)
+
+		request, _ := http.NewRequest(http.MethodPut, url, strings.NewReader("definitely not an image"))
+		request.Header.Set("Content-Type", "image/png")
+		doRequestAndExpect(t, request, http.StatusUnsupportedMediaType)
+
+		request, _ = http.NewRequest(http.MethodPut, url, bytes.NewReader(encodeTestImage(t, "png", 10, 10)))
+		request.Header.Set("Content-Type", "application/octet-stream")
+		doRequestAndExpect(t, request, http.StatusUnsupportedMediaType)
+
+		request, _ = http.NewRequest(http.MethodPut, url, bytes.NewReader(make([]byte, testPhotoMaxSize+1)))
+		request.Header.Set("Content-Type", "image/png")
+		doRequestAndExpect(t, request, http.StatusRequestEntityTooLarge)
+
+		request, _ = http.NewRequest(http.MethodGet, url, nil)
+		doRequestAndExpect(t, request, http.StatusNotFound)
+	})
+
+	t.Run("deleted cat loses its photo", func(t *testing.T) {
+		cat := addNewCatSuccessfully(t, models.Cat{Name: "Fleeting", Breed: "abys", YearsOfExperience: 4, Salary: 1000})
+		photo := uploadPhotoSuccessfully(t, cat.Id, "image/png", encodeTestImage(t, "png", 10, 10))
+		doRequestAndExpect(t, newDeleteCatRequest(int(cat.Id)), http.StatusOK)
+
+		for _, url := range []string{photo.PhotoUrl, photo.Thumbnails[0].Url} {
+			request, _ := http.NewRequest(http.MethodGet, url, nil)
+			doRequestAndExpect(t, request, http.StatusNotFound)
+		}
+	})
+
+	t.Run("upload photo of non-existing cat", func(t *testing.T) {
+		url := strings.Replace(spycatagency.Endpoints.CatPhotoUpload, ":id", "100000", 1)
+		request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(encodeTestImage(t, "png", 10, 10)))
+		request.Header.Set("Content-Type", "image/png")
+		doRequestAndExpect(t, request, http.StatusNotFound)
+	})
+}
+
+func TestIdempotency(t *testing.T) {
+	cleaner.cleanDB()
+	newCat := models.Cat{Name: "Retry", Breed: "abys", YearsOfExperience: 2, Salary: 1000, HandlerId: testHandler.Id}
+	post := func(key string, cat models.Cat) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.APIV1+spycatagency.Endpoints.CatCreate, bytes.NewReader(marshal(t, cat)))
+		request.Header.Set(spycatagency.IdempotencyKeyHeader, key)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		return response
+	}
+
+	t.Run("retry replays stored response", func(t *testing.T) {
+		first := post("add-cat-retry", newCat)
+		require.Equal(t, http.StatusCreated, first.Code)
+		assert.Empty(t, first.Header().Get(spycatagency.IdempotentReplayedHeader))
+
+		retry := post("add-cat-retry", newCat)
+		require.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get(spycatagency.IdempotentReplayedHeader))
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+
+		request, _ := http.NewRequest(http.MethodGet, spycatagency.Endpoints.CatGetAll, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, 1, unmarshal[models.PaginatedCats](t, response.Body.Bytes()).Meta.Total)
+	})
+
+	t.Run("key reused for a different request", func(t *testing.T) {
+		require.Equal(t, http.StatusCreated, post("add-cat-reused", newCat).Code)
+
+		changedCat := newCat
+		changedCat.Salary = 2000
+		response := post("add-cat-reused", changedCat)
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Equal(t, myerrors.CodeIdempotencyKeyReused, unmarshal[myerrors.Problem](t, response.Body.Bytes()).Code)
+	})
+
+	t.Run("error responses are replayed", func(t *testing.T) {
+		invalidCat := models.Cat{Name: "Fraud", Breed: "fraud", YearsOfExperience: 1, Salary: 1}
+		first := post("add-cat-invalid", invalidCat)
+		require.Equal(t, http.StatusBadRequest, first.Code)
+
+		retry := post("add-cat-invalid", invalidCat)
+		assert.Equal(t, http.StatusBadRequest, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get(spycatagency.IdempotentReplayedHeader))
+		assert.Equal(t, myerrors.ProblemContentType, retry.Header().Get("Content-Type"))
+	})
+
+	t.Run("requests without key are not deduplicated", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, post("", newCat).Code)
+		assert.Equal(t, http.StatusCreated, post("", newCat).Code)
+	})
+}
+
+func TestImportCats(t *testing.T) {
+	handlerId := strconv.Itoa(int(testHandler.Id))
+	importCats := func(mode, contentType, file string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.CatImport+"?mode="+mode, strings.NewReader(file))
+		request.Header.Set("Content-Type", contentType)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		return response
+	}
+	// listing of an empty table is rejected, so cats are counted in the database
+	countCats := func() int {
+		var count int
+		require.NoError(t, cleaner.db.QueryRow("SELECT COUNT(*) FROM cats").Scan(&count))
+		return count
+	}
+
+	t.Run("import CSV", func(t *testing.T) {
+		cleaner.cleanDB()
+		fetches := catAPI.catalogFetches
+		file := "name,yearsOfExperience,breed,salary,handlerId\n" +
+			"Tom,3,abys,1000," + handlerId + "\n" +
+			"Kitty,1,aege,800," + handlerId + "\n"
+
+		response := importCats(models.ImportModeAllOrNothing, "text/csv", file)
+		require.Equal(t, http.StatusCreated, response.Code)
+		report := unmarshal[models.CatImportReport](t, response.Body.Bytes())
+		assert.Equal(t, 2, report.Created)
+		assert.Zero(t, report.Failed)
+		assert.Equal(t, 2, report.Rows[0].Line)
+		assert.NotZero(t, report.Rows[1].Id)
+		assert.Equal(t, fetches+1, catAPI.catalogFetches)
+
+		cat := getCatByIDSuccessfully(t, int(report.Rows[0].Id))
+		require.NotNil(t, cat.BreedDetails)
+		assert.Equal(t, "Abyssinian", cat.BreedDetails.Name)
+		assert.Equal(t, models.CatStatusActive, cat.Status)
+	})
+
+	t.Run("import NDJSON", func(t *testing.T) {
+		cleaner.cleanDB()
+		file := `{"name":"Tom","yearsOfExperience":3,"breed":"abys","salary":1000,"handlerId":` + handlerId + "}\n\n" +
+			`{"name":"Kitty","yearsOfExperience":1,"breed":"aege","salary":800,"handlerId":` + handlerId + "}\n"
+
+		response := importCats("", "application/x-ndjson", file)
+		require.Equal(t, http.StatusCreated, response.Code)
+		report := unmarshal[models.CatImportReport](t, response.Body.Bytes())
+		assert.Equal(t, models.ImportModeAllOrNothing, report.Mode)
+		assert.Equal(t, 2, report.Created)
+		assert.Equal(t, 3, report.Rows[1].Line)
+		assert.Equal(t, 2, countCats())
+	})
+
+	invalidFile := "name,yearsOfExperience,breed,salary,handlerId\n" +
+		"Tom,3,abys,1000," + handlerId + "\n" +
+		"Fraud,1,fraud,800," + handlerId + "\n" +
+		"Kitty,many,aege,800," + handlerId + "\n"
+
+	t.Run("all-or-nothing import adds nothing when a row fails", func(t *testing.T) {
+		cleaner.cleanDB()
+		response := importCats(models.ImportModeAllOrNothing, "text/csv", invalidFile)
+		require.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		report := unmarshal[models.CatImportReport](t, response.Body.Bytes())
+		assert.Zero(t, report.Created)
+		assert.Equal(t, 2, report.Failed)
+		assert.Empty(t, report.Rows[0].Error)
+		assert.Zero(t, report.Rows[0].Id)
+		assert.Equal(t, catapi.ErrBreedNotFound.Error(), report.Rows[1].Error)
+		require.Len(t, report.Rows[2].Violations, 1)
+		assert.Equal(t, "yearsOfExperience", report.Rows[2].Violations[0].Field)
+		assert.Zero(t, countCats())
+	})
+
+	t.Run("best-effort import adds valid rows", func(t *testing.T) {
+		cleaner.cleanDB()
+		response := importCats(models.ImportModeBestEffort, "text/csv", invalidFile)
+		require.Equal(t, http.StatusOK, response.Code)
+		report := unmarshal[models.CatImportReport](t, response.Body.Bytes())
+		assert.Equal(t, 1, report.Created)
+		assert.Equal(t, 2, report.Failed)
+		assert.NotZero(t, report.Rows[0].Id)
+		assert.Zero(t, report.Rows[1].Id)
+		assert.Equal(t, 1, countCats())
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		response := importCats("", "text/csv", "name,color\nTom,black\n")
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		response := importCats("", "application/json", "[]")
+		assert.Equal(t, http.StatusUnsupportedMediaType, response.Code)
+	})
+}
+
+func TestExport(t *testing.T) {
+	cleaner.cleanDB()
+	handler := addHandlerSuccessfully(t, models.Handler{Name: "Analyst"})
+	tom := addNewCatSuccessfully(t, models.Cat{Name: "Tom", Breed: "abys", YearsOfExperience: 3, Salary: 1000, HandlerId: handler.Id})
+	addNewCatSuccessfully(t, models.Cat{Name: "Kitty", Breed: "aege", YearsOfExperience: 1, Salary: 800})
+	mission := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{
+		{Name: "Harbour", Country: "Spain"},
+		{Name: "Lighthouse", Country: "Spain"},
+	}})
+	mission = assignMissionSuccessfully(t, mission, tom)
+	addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Bakery", Country: "France"}}})
+
+	export := func(url string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		return response
+	}
+
+	t.Run("cats as CSV", func(t *testing.T) {
+		response := export(spycatagency.Endpoints.CatExport)
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", response.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="cats.csv"`, response.Header().Get("Content-Disposition"))
+
+		records, err := csv.NewReader(response.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, "name", records[0][1])
+		assert.Equal(t, []string{strconv.Itoa(int(tom.Id)), "Tom", "abys", "Abyssinian", "3", "1000"}, records[1][:6])
+		assert.Equal(t, strconv.Itoa(int(handler.Id)), records[1][8])
+		assert.Equal(t, strconv.Itoa(int(testHandler.Id)), records[2][8])
+	})
+
+	t.Run("filtered cats as NDJSON", func(t *testing.T) {
+		response := export(spycatagency.Endpoints.CatExport + "?format=ndjson&handler=" + strconv.Itoa(int(handler.Id)))
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "application/x-ndjson", response.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(response.Body.String()), "\n")
+		require.Len(t, lines, 1)
+		assert.Equal(t, tom.Id, unmarshal[models.Cat](t, []byte(lines[0])).Id)
+	})
+
+	t.Run("cats as XLSX", func(t *testing.T) {
+		response := export(spycatagency.Endpoints.CatExport + "?format=xlsx")
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, xlsx.ContentType, response.Header().Get("Content-Type"))
+
+		archive, err := zip.NewReader(bytes.NewReader(response.Body.Bytes()), int64(response.Body.Len()))
+		require.NoError(t, err)
+		sheet, err := archive.Open("xl/worksheets/sheet1.xml")
+		require.NoError(t, err)
+		content, err := io.ReadAll(sheet)
+		require.NoError(t, err)
+		assert.Equal(t, 3, strings.Count(string(content), "<row "))
+		assert.Contains(t, string(content), "Kitty")
+	})
+
+	t.Run("missions have a row per target", func(t *testing.T) {
+		response := export(spycatagency.Endpoints.MissionExport)
+		require.Equal(t, http.StatusOK, response.Code)
+		records, err := csv.NewReader(response.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		assert.Equal(t, "targetName", records[0][8])
+		assert.Equal(t, strconv.Itoa(int(mission.Id)), records[1][0])
+		assert.Equal(t, strconv.Itoa(int(tom.Id)), records[1][1])
+		assert.Equal(t, "Harbour", records[1][8])
+		assert.Equal(t, "Lighthouse", records[2][8])
+		assert.Equal(t, "Bakery", records[3][8])
+	})
+
+	t.Run("missions of the handler", func(t *testing.T) {
+		response := export(spycatagency.Endpoints.MissionExport + "?format=ndjson&handler=" + strconv.Itoa(int(handler.Id)))
+		require.Equal(t, http.StatusOK, response.Code)
+		lines := strings.Split(strings.TrimSpace(response.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		row := unmarshal[models.MissionExportRow](t, []byte(lines[1]))
+		assert.Equal(t, mission.Targets[1].Id, row.TargetId)
+		assert.Equal(t, "ES", row.CountryCode)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		response := export(spycatagency.Endpoints.CatExport + "?format=pdf")
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Empty(t, response.Header().Get("Content-Disposition"))
+	})
+}
+
+func TestBatch(t *testing.T) {
+	cleaner.cleanDB()
+	cat := addNewCatSuccessfully(t, models.Cat{Name: "Tom", Breed: "abys", YearsOfExperience: 3, Salary: 1000})
+	batch := func(body string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(http.MethodPost, spycatagency.APIV1+spycatagency.Endpoints.Batch, strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		return response
+	}
+	countMissions := func() int {
+		request, _ := http.NewRequest(http.MethodGet, spycatagency.APIV1+spycatagency.Endpoints.MissionGetAll, nil)
+		response := httptest.NewRecorder()
+		server.Handler().ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		return unmarshal[models.PaginatedMissions](t, response.Body.Bytes()).Meta.Total
+	}
+
+	t.Run("mission is created, extended and assigned at once", func(t *testing.T) {
+		response := batch(fmt.Sprintf(`{"operations":[
+			{"ref":"m","method":"POST","path":"/missions","body":{"targets":[{"name":"Harbour","country":"Spain"}]}},
+			{"method":"POST","path":"/missions/$m/targets","body":{"name":"Lighthouse","country":"Spain"}},
+			{"method":"POST","path":"/missions/$m/assign/%d"}
+		]}`, cat.Id))
+		require.Equal(t, http.StatusOK, response.Code)
+
+		results := unmarshal[models.BatchResponse](t, response.Body.Bytes()).Results
+		require.Len(t, results, 3)
+		missionId := unmarshal[models.Mission](t, results[0].Body).Id
+		mission := getMissionByIdSuccessfully(t, int(missionId))
+		assert.Len(t, mission.Targets, 2)
+		assert.Equal(t, cat.Id, mission.CatId)
+	})
+
+	t.Run("failed operation rolls back earlier operations", func(t *testing.T) {
+		before := countMissions()
+		response := batch(`{"operations":[
+			{"ref":"m","method":"POST","path":"/missions","body":{"targets":[{"name":"Harbour","country":"Spain"}]}},
+			{"method":"POST","path":"/missions/$m/assign/999999"}
+		]}`)
+		require.Equal(t, http.StatusNotFound, response.Code)
+
+		problem := unmarshal[myerrors.Problem](t, response.Body.Bytes())
+		require.NotNil(t, problem.Operation)
+		assert.Equal(t, 1, *problem.Operation)
+		assert.Equal(t, before, countMissions())
+	})
+}
+
+func getMissionByIdSuccessfully(t *testing.T, id int) models.Mission {
+	t.Helper()
+	request := newGetMissionByIdRequest(id)
+	response := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	mission := unmarshal[models.Mission](t, response.Body.Bytes())
+	return mission
+}
+
+func getCatByIDSuccessfully(t *testing.T, id int) models.Cat {
+	t.Helper()
+	request := newGetCatByIdRequest(id)
+	response := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	cat := unmarshal[models.Cat](t, response.Body.Bytes())
+	return cat
+}
+
+func addNewMissionSuccessfully(t *testing.T, newMission models.Mission) models.Mission {
+	t.Helper()
+	request := newAddMissionRequest(t, newMission)
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusCreated, response.Code)
+
+	mission := unmarshal[models.Mission](t, response.Body.Bytes())
+	require.Equal(t, len(newMission.Targets), len(mission.Targets))
+	newMission.Id = mission.Id
+	if newMission.Type == "" {
+		newMission.Type = "standard"
+	}
+	if newMission.Bonus == nil {
+		newMission.Bonus = intPtr(testMissionType(newMission.Type).SalaryBonus)
+	}
+	normalizeCountries(t, newMission.Targets)
+	for i := range mission.Targets {
+		newMission.Targets[i].Id = mission.Targets[i].Id
+	}
+	assertMissions(t, mission, newMission)
+
+	require.Equal(t, newMission, mission)
+	return mission
+}
+
+func testMissionType(name string) models.MissionType {
+	for _, mt := range testMissionTypes {
+		if mt.Name == name {
+			return mt
+		}
+	}
+	return models.MissionType{}
+}
+
+func addNewTemplateSuccessfully(t *testing.T, template models.MissionTemplate) models.MissionTemplate {
+	t.Helper()
+	body := marshal(t, template)
+	request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.TemplateCreate, bytes.NewReader(body))
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusCreated, response.Code)
+
+	savedTemplate := unmarshal[models.MissionTemplate](t, response.Body.Bytes())
+	require.Equal(t, len(template.Targets), len(savedTemplate.Targets))
+	assert.Equal(t, template.Name, savedTemplate.Name)
+	return savedTemplate
+}
+
+func completeTargetSuccessfully(t *testing.T, missionId, targetId int64) models.Mission {
+	t.Helper()
+	request := newCompleteTargetRequest(int(missionId), int(targetId))
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	mission := getMissionByIdSuccessfully(t, int(missionId))
+	return mission
+}
+
+func patchCat(t *testing.T, id int64, patch string) *httptest.ResponseRecorder {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatPatch, ":id", strconv.Itoa(int(id)), 1)
+	request, _ := http.NewRequest(http.MethodPatch, url, strings.NewReader(patch))
+	request.Header.Set("Content-Type", "application/merge-patch+json")
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	return response
+}
+
+func setCatSkillsSuccessfully(t *testing.T, catId int64, skills []models.CatSkill) []models.CatSkill {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatSkillsUpdate, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(marshal(t, skills)))
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[[]models.CatSkill](t, response.Body.Bytes())
+}
+
+func getCatSkills(t *testing.T, catId int64) []models.CatSkill {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatSkillsGet, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[[]models.CatSkill](t, response.Body.Bytes())
+}
+
+func skillProficiency(skills []models.CatSkill, name string) int {
+	for _, s := range skills {
+		if s.Skill == name {
+			return s.Proficiency
+		}
+	}
+	return 0
+}
+
+func addAbsenceSuccessfully(t *testing.T, catId int64, absence models.Absence) models.Absence {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatAbsenceCreate, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(marshal(t, absence)))
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusCreated, response.Code)
+	return unmarshal[models.Absence](t, response.Body.Bytes())
+}
+
+func getAbsences(t *testing.T, catId int64) []models.Absence {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatAbsenceGetAll, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[[]models.Absence](t, response.Body.Bytes())
+}
+
+func updateStatusSuccessfully(t *testing.T, catId int64, update models.StatusUpdate) models.Cat {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatStatusUpdate, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(marshal(t, update)))
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[models.Cat](t, response.Body.Bytes())
+}
+
+func getCatStatsSuccessfully(t *testing.T, catId int64) models.CatStats {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatStats, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[models.CatStats](t, response.Body.Bytes())
+}
+
+// completeNewMission creates a mission with a single target and completes it with the cat
+func completeNewMission(t *testing.T, cat models.Cat) models.Mission {
+	t.Helper()
+	mission := addNewMissionSuccessfully(t, models.Mission{Targets: []models.Target{{Name: "Target", Country: "Spain"}}})
+	return completeNewMissionWith(t, mission, cat)
+}
+
+// completeNewMissionWith assigns the mission to the cat and completes all its targets and the mission
+func completeNewMissionWith(t *testing.T, mission models.Mission, cat models.Cat) models.Mission {
+	t.Helper()
+	mission = assignMissionSuccessfully(t, mission, cat)
+	for _, target := range mission.Targets {
+		mission = completeTargetSuccessfully(t, mission.Id, target.Id)
+	}
+	return completeMissionSuccessfully(t, mission)
+}
+
+func addLedgerEntrySuccessfully(t *testing.T, catId int64, entry models.LedgerEntry) models.LedgerEntry {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatLedgerCreate, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(marshal(t, entry)))
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusCreated, response.Code)
+	return unmarshal[models.LedgerEntry](t, response.Body.Bytes())
+}
+
+func getLedgerSuccessfully(t *testing.T, catId int64) models.Ledger {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatLedgerGet, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[models.Ledger](t, response.Body.Bytes())
+}
+
+func getPromotionsSuccessfully(t *testing.T, catId int64) []models.Promotion {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatPromotions, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[[]models.Promotion](t, response.Body.Bytes())
+}
+
+func updateSalarySuccessfully(t *testing.T, catId int64, update models.CatUpdate) models.Cat {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatUpdate, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(marshal(t, update)))
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[models.Cat](t, response.Body.Bytes())
+}
+
+func getPayrollSuccessfully(t *testing.T, from, to time.Time) models.Payroll {
+	t.Helper()
+	url := fmt.Sprintf("%s?from=%s&to=%s", spycatagency.Endpoints.PayrollGet, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[models.Payroll](t, response.Body.Bytes())
+}
+
+func catPayroll(t *testing.T, payroll models.Payroll, catId int64) models.CatPayroll {
+	t.Helper()
+	for _, c := range payroll.Cats {
+		if c.CatId == catId {
+			return c
+		}
+	}
+	t.Fatalf("cat %d is missing in payroll", catId)
+	return models.CatPayroll{}
+}
+
+func getHandlerMissionsSuccessfully(t *testing.T, handlerId int64) models.PaginatedMissions {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.HandlerMissions, ":id", strconv.Itoa(int(handlerId)), 1)
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[models.PaginatedMissions](t, response.Body.Bytes())
+}
+
+func addNewCatSuccessfully(t *testing.T, cat models.Cat) models.Cat {
+	t.Helper()
+	if cat.HandlerId == 0 {
+		cat.HandlerId = testHandler.Id
+	}
+	body := marshal(t, cat)
+	request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.CatCreate, bytes.NewReader(body))
+	response := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusCreated, response.Code)
+
+	persistedCat := unmarshal[models.Cat](t, response.Body.Bytes())
+	cat.Id = persistedCat.Id
+	if cat.Status == "" {
+		cat.Status = models.CatStatusActive
+	}
+	cat.Rank = ranks.For(cat.YearsOfExperience, 0).Name
+	breed, err := NewFakeCatAPI().GetBreedById(context.Background(), cat.Breed)
+	require.NoError(t, err)
+	cat.BreedDetails = services.NewBreedDetails(breed)
+	require.Equal(t, cat, persistedCat)
+	return persistedCat
+}
+
+func assignMissionSuccessfully(t *testing.T, mission models.Mission, cat models.Cat) models.Mission {
+	t.Helper()
+	request := newAssignMissionRequest(int(mission.Id), int(cat.Id))
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	mission = getMissionByIdSuccessfully(t, int(mission.Id))
+	require.Equal(t, mission.CatId, cat.Id)
+	return mission
+}
+
+func completeMissionSuccessfully(t *testing.T, mission models.Mission) models.Mission {
+	t.Helper()
+	request := newCompleteMissionRequest(int(mission.Id))
+	response := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	cMission := unmarshal[models.Mission](t, response.Body.Bytes())
+	require.Equal(t, true, cMission.Completed)
+	mission.Completed = cMission.Completed
+	assert.Equal(t, mission, cMission)
+	return cMission
+}
+
+func newDeleteCatRequest(id int) *http.Request {
+	url := strings.Replace(spycatagency.Endpoints.CatDelete, ":id", strconv.Itoa(id), 1)
+	request, _ := http.NewRequest(http.MethodDelete, url, nil)
+	return request
+}
+
+func newGetCatByIdRequest(id int) *http.Request {
+	url := strings.Replace(spycatagency.Endpoints.CatGet, ":id", strconv.Itoa(id), 1)
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	return request
+}
+func newGetMissionByIdRequest(id int) *http.Request {
+	url := strings.Replace(spycatagency.Endpoints.MissionGet, ":id", strconv.Itoa(id), 1)
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	return request
+}
+
+func newAssignMissionRequest(missionId, catId int) *http.Request {
+	url := strings.Replace(spycatagency.Endpoints.MissionAssign, ":id", strconv.Itoa(missionId), 1)
+	url = strings.Replace(url, ":catId", strconv.Itoa(catId), 1)
+	request, _ := http.NewRequest(http.MethodPost, url, nil)
+	return request
+}
+
+func newAddMissionRequest(t *testing.T, mission models.Mission) *http.Request {
+	t.Helper()
+	body := marshal(t, mission)
+	request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.MissionCreate, bytes.NewReader(body))
+	return request
+}
+
+func newCompleteTargetRequest(missionId, targetId int) *http.Request {
 	url := strings.Replace(spycatagency.Endpoints.TargetComplete, ":id", strconv.Itoa(missionId), 1)
 	url = strings.Replace(url, ":targetId", strconv.Itoa(targetId), 1)
 	request, _ := http.NewRequest(http.MethodPost, url, nil)
@@ -1314,6 +3200,21 @@ func newDeleteMissionRequest(missionId int) *http.Request {
 	return request
 }
 
+// normalizeCountries sets ISO 3166 country names and codes that server stores for targets
+func normalizeCountries(t *testing.T, targets []models.Target) {
+	t.Helper()
+	for i := range targets {
+		c, err := country.Lookup(targets[i].Country)
+		require.NoError(t, err)
+		targets[i].Country = c.Name
+		targets[i].CountryCode = c.Alpha2
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
 func unmarshal[T any](t *testing.T, body []byte) T {
 	t.Helper()
 	var result T
@@ -1375,14 +3276,19 @@ func testPaginationValidation(t *testing.T, url string) {
 
 type FakeCatAPI struct {
 	breeds []catapi.Breed
+	// catalogFetches counts calls of GetAllBreeds
+	catalogFetches int
 }
 
 func NewFakeCatAPI() *FakeCatAPI {
 	return &FakeCatAPI{
-		[]catapi.Breed{
+		breeds: []catapi.Breed{
 			{
-				Id:   "abys",
-				Name: "Abyssinian",
+				Id:          "abys",
+				Name:        "Abyssinian",
+				Origin:      "Egypt",
+				Temperament: "Active, Energetic, Independent, Intelligent, Gentle",
+				LifeSpan:    "14 - 15",
 			},
 			{
 				Id:   "aege",
@@ -1412,3 +3318,71 @@ func (n *FakeCatAPI) GetBreedById(ctx context.Context, id string) (catapi.Breed,
 	}
 	return catapi.Breed{}, catapi.ErrBreedNotFound
 }
+
+func (n *FakeCatAPI) GetAllBreeds(ctx context.Context) ([]catapi.Breed, error) {
+	n.catalogFetches++
+	return n.breeds, nil
+}
+
+func addHandlerSuccessfully(t *testing.T, handler models.Handler) models.Handler {
+	t.Helper()
+	request, _ := http.NewRequest(http.MethodPost, spycatagency.Endpoints.HandlerCreate, bytes.NewReader(marshal(t, handler)))
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusCreated, response.Code)
+	newHandler := unmarshal[models.Handler](t, response.Body.Bytes())
+	handler.Id = newHandler.Id
+	require.Equal(t, handler, newHandler)
+	return newHandler
+}
+
+func transferCatSuccessfully(t *testing.T, catId, handlerId int64) models.Cat {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatTransfer, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(marshal(t, models.CatTransfer{HandlerId: handlerId})))
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[models.Cat](t, response.Body.Bytes())
+}
+
+func uploadPhotoSuccessfully(t *testing.T, catId int64, contentType string, photo []byte) models.CatPhoto {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatPhotoUpload, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(photo))
+	request.Header.Set("Content-Type", contentType)
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[models.CatPhoto](t, response.Body.Bytes())
+}
+
+// encodeTestImage returns gradient image encoded in the format
+func encodeTestImage(t *testing.T, format string, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.SetRGBA(x, y, color.RGBA{R: uint8(x * 255 / width), G: uint8(y * 255 / height), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	var err error
+	if format == "png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, nil)
+	}
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func getCatMissionsSuccessfully(t *testing.T, catId int64, query string) models.PaginatedMissions {
+	t.Helper()
+	url := strings.Replace(spycatagency.Endpoints.CatMissions, ":id", strconv.Itoa(int(catId)), 1)
+	request, _ := http.NewRequest(http.MethodGet, url+query, nil)
+	response := httptest.NewRecorder()
+	server.Handler().ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	return unmarshal[models.PaginatedMissions](t, response.Body.Bytes())
+}